@@ -1,33 +1,123 @@
-// Command flyontime implements interactive Slack/Mattermost bot that monitors
-// Concourse CI jobs and sends notifications on significant events.
+// Command flyontime implements interactive chat bot that monitors Concourse CI
+// jobs and sends notifications on significant events. It supports Slack,
+// Mattermost, Discord, Matrix, Telegram, Rocket.Chat and Zulip.
 package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net"
+	"net/http"
+	"net/smtp"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/discord"
+	"github.com/Bo0mer/flyontime/pkg/email"
+	"github.com/Bo0mer/flyontime/pkg/escalation"
 	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/Bo0mer/flyontime/pkg/health"
+	"github.com/Bo0mer/flyontime/pkg/matrix"
 	"github.com/Bo0mer/flyontime/pkg/mattermost"
+	"github.com/Bo0mer/flyontime/pkg/msteams"
+	"github.com/Bo0mer/flyontime/pkg/rocketchat"
 	"github.com/Bo0mer/flyontime/pkg/slacker"
+	"github.com/Bo0mer/flyontime/pkg/telegram"
+	"github.com/Bo0mer/flyontime/pkg/webhook"
+	"github.com/Bo0mer/flyontime/pkg/zulip"
+	"github.com/concourse/atc"
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/namsral/flag"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var (
-	slackChannelID string
-	slackToken     string
+	slackChannelID     string
+	slackToken         string
+	slackAppToken      string
+	slackSigningSecret string
+	slackSlashResponse string
 
 	mattermostURL       string
 	mattermostChannelID string
 	mattermostToken     string
+	mattermostCmdToken  string
+	mattermostSlashResp string
 
-	concourseURL      string
-	concourseUsername string
-	concoursePassword string
-	concourseTeam     string
+	discordChannelID string
+	discordToken     string
+
+	matrixHomeserver  string
+	matrixAccessToken string
+	matrixRoomID      string
+
+	telegramToken  string
+	telegramChatID string
+
+	rocketChatURL     string
+	rocketChatUserID  string
+	rocketChatToken   string
+	rocketChatChannel string
+
+	zulipSite   string
+	zulipEmail  string
+	zulipAPIKey string
+	zulipStream string
+
+	msteamsWebhookURL string
+
+	smtpAddr                string
+	smtpUsername            string
+	smtpPassword            string
+	emailFrom               string
+	emailTo                 string
+	emailPipelineRecipients string
+	emailDigestWindow       time.Duration
+
+	criticalPipelines    string
+	escalationFailures   int
+	escalationFailingFor time.Duration
+	pagerDutyRoutingKey  string
+	opsgenieAPIKey       string
+	opsgenieURL          string
+
+	webhookURL          string
+	webhookSecret       string
+	webhookTemplateFile string
+	webhookHeaders      = headerFlags{}
+	webhookRetries      int
+	webhookTimeout      time.Duration
+
+	concourseURL       string
+	concourseUsername  string
+	concoursePassword  string
+	concourseTeam      string
+	concourseAuth      string
+	concourseToken     string
+	concourseTokenFile string
+	flyTarget          string
+
+	commandAliases        string
+	notificationRetention time.Duration
+	seenBuildsFile        string
+
+	listenAddr string
+
+	concourseStaleAfter   time.Duration
+	concourseStreamEvents bool
+	notifyStarted         bool
+
+	backoffMin    time.Duration
+	backoffMax    time.Duration
+	backoffJitter float64
 
 	verbose bool
 )
@@ -35,15 +125,80 @@ var (
 func init() {
 	flag.StringVar(&slackChannelID, "slack-channel-id", "", "Slack channel id for sending alerts")
 	flag.StringVar(&slackToken, "slack-token", "", "Slack token for sending alerts")
+	flag.StringVar(&slackAppToken, "slack-app-token", "", "Slack app-level token for receiving commands using Socket Mode")
+	flag.StringVar(&slackSigningSecret, "slack-signing-secret", "", "Slack signing secret for receiving commands using the Events API at /slack/events and slash commands at /slack/commands, requires -listen-addr")
+	flag.StringVar(&slackSlashResponse, "slack-slash-response-type", slacker.ResponseEphemeral, "Type of responses to Slack slash commands, either ephemeral or in_channel")
 
 	flag.StringVar(&mattermostURL, "mattermost-url", "", "Mattermost channel id for sending alerts")
 	flag.StringVar(&mattermostChannelID, "mattermost-channel-id", "", "Mattermost channel id for sending alerts")
 	flag.StringVar(&mattermostToken, "mattermost-token", "", "Mattermost token for sending alerts")
+	flag.StringVar(&mattermostCmdToken, "mattermost-command-token", "", "Mattermost slash command token for receiving commands at /mattermost/commands, requires -listen-addr")
+	flag.StringVar(&mattermostSlashResp, "mattermost-slash-response-type", model.COMMAND_RESPONSE_TYPE_EPHEMERAL, "Type of responses to Mattermost slash commands, either ephemeral or in_channel")
+
+	flag.StringVar(&discordChannelID, "discord-channel-id", "", "Discord channel id for sending alerts")
+	flag.StringVar(&discordToken, "discord-token", "", "Discord bot token for sending alerts")
+
+	flag.StringVar(&matrixHomeserver, "matrix-homeserver", "", "Matrix homeserver URL, e.g. https://matrix.org")
+	flag.StringVar(&matrixAccessToken, "matrix-access-token", "", "Matrix access token for sending alerts")
+	flag.StringVar(&matrixRoomID, "matrix-room-id", "", "Matrix room id for sending alerts")
+
+	flag.StringVar(&telegramToken, "telegram-token", "", "Telegram bot token for sending alerts")
+	flag.StringVar(&telegramChatID, "telegram-chat-id", "", "Telegram chat id (or @username) for sending alerts")
+
+	flag.StringVar(&rocketChatURL, "rocketchat-url", "", "Rocket.Chat server URL")
+	flag.StringVar(&rocketChatUserID, "rocketchat-user-id", "", "Rocket.Chat bot user id")
+	flag.StringVar(&rocketChatToken, "rocketchat-token", "", "Rocket.Chat personal access token for sending alerts")
+	flag.StringVar(&rocketChatChannel, "rocketchat-channel", "", "Rocket.Chat channel name for sending alerts")
+
+	flag.StringVar(&zulipSite, "zulip-site", "", "Zulip server URL, e.g. https://example.zulipchat.com")
+	flag.StringVar(&zulipEmail, "zulip-email", "", "Zulip bot email")
+	flag.StringVar(&zulipAPIKey, "zulip-api-key", "", "Zulip bot API key for sending alerts")
+	flag.StringVar(&zulipStream, "zulip-stream", "", "Zulip stream for sending alerts, using a topic per pipeline/job")
+
+	flag.StringVar(&msteamsWebhookURL, "msteams-webhook-url", "", "Microsoft Teams incoming webhook URL for sending alerts")
+
+	flag.StringVar(&smtpAddr, "smtp-addr", "", "SMTP server address for sending alerts as emails, e.g. smtp.example.com:587")
+	flag.StringVar(&smtpUsername, "smtp-username", "", "SMTP username")
+	flag.StringVar(&smtpPassword, "smtp-password", "", "SMTP password")
+	flag.StringVar(&emailFrom, "email-from", "", "Sender address of alert emails")
+	flag.StringVar(&emailTo, "email-to", "", "Comma separated list of alert email recipients")
+	flag.StringVar(&emailPipelineRecipients, "email-pipeline-recipients", "", "Alert email recipients per pipeline, e.g. p1=a@example.com;b@example.com,p2=c@example.com")
+	flag.DurationVar(&emailDigestWindow, "email-digest-window", 0, "Batch alert emails sent within this window into a single digest (disabled if zero)")
+
+	flag.StringVar(&criticalPipelines, "critical-pipelines", "", "Comma separated list of critical pipelines (or patterns, e.g. prod-*), whose failures are escalated")
+	flag.IntVar(&escalationFailures, "escalation-failures", 3, "Escalate after this many consecutive failures of a critical job (disabled if zero)")
+	flag.DurationVar(&escalationFailingFor, "escalation-failing-for", 0, "Escalate once a critical job has been failing for this long (disabled if zero)")
+	flag.StringVar(&pagerDutyRoutingKey, "pagerduty-routing-key", "", "PagerDuty Events API v2 routing key for escalating failures")
+	flag.StringVar(&opsgenieAPIKey, "opsgenie-api-key", "", "Opsgenie API key for escalating failures")
+	flag.StringVar(&opsgenieURL, "opsgenie-url", escalation.DefaultOpsgenieURL, "Opsgenie API URL")
+
+	flag.StringVar(&webhookURL, "webhook-url", "", "URL to which notifications are posted as JSON")
+	flag.StringVar(&webhookSecret, "webhook-secret", "", "Secret for signing webhook requests with HMAC-SHA256")
+	flag.StringVar(&webhookTemplateFile, "webhook-template-file", "", "Go template file for the webhook request body")
+	flag.Var(webhookHeaders, "webhook-header", "Additional webhook request header, e.g. Authorization=Bearer xyz (could be repeated)")
+	flag.IntVar(&webhookRetries, "webhook-retries", 3, "Number of retries for failed webhook requests")
+	flag.DurationVar(&webhookTimeout, "webhook-timeout", webhook.DefaultTimeout, "Maximum time spent delivering a notification to the webhook, including retries")
 
 	flag.StringVar(&concourseURL, "concourse-url", "http://localhost:8080", "Concourse URL")
 	flag.StringVar(&concourseUsername, "concourse-username", "", "Concourse Username")
 	flag.StringVar(&concoursePassword, "concourse-password", "", "Concourse Password")
 	flag.StringVar(&concourseTeam, "concourse-team", "main", "Concourse Team")
+	flag.StringVar(&concourseAuth, "concourse-auth", flyontime.AuthBasic, "How to authenticate to Concourse, either basic (before 4.0), password (local user) or token")
+	flag.StringVar(&concourseToken, "concourse-token", "", "Concourse bearer token, used with -concourse-auth=token")
+	flag.StringVar(&flyTarget, "fly-target", "", "Target of fly in ~/.flyrc whose URL, team and token are used instead of the -concourse-* flags")
+	flag.StringVar(&concourseTokenFile, "concourse-token-file", "", "File from which the Concourse bearer token is read whenever it changes, used with -concourse-auth=token")
+	flag.DurationVar(&backoffMin, "backoff-min", backoff.DefaultPolicy.Min, "Delay before retrying failed Concourse polls and chat reconnects, doubled after each consecutive failure")
+	flag.DurationVar(&backoffMax, "backoff-max", backoff.DefaultPolicy.Max, "Maximum delay before retrying failed Concourse polls and chat reconnects")
+	flag.Float64Var(&backoffJitter, "backoff-jitter", backoff.DefaultPolicy.Jitter, "Fraction of retry delays which is randomized, between 0 and 1")
+	flag.DurationVar(&concourseStaleAfter, "concourse-stale-after", time.Minute, "Warn that Concourse cannot be reached once polling it has not succeeded for this long")
+	flag.BoolVar(&concourseStreamEvents, "concourse-stream-events", false, "Follow the events of running builds in order to notify as soon as they finish")
+	flag.BoolVar(&notifyStarted, "notify-started", false, "Notify when builds start, requires -concourse-stream-events")
+
+	flag.DurationVar(&notificationRetention, "notification-retention", chat.DefaultTTL, "For how long replies to chat notifications are handled")
+	flag.StringVar(&seenBuildsFile, "seen-builds-file", "", "File in which the handled builds are remembered, so that they are not notified again after a restart")
+	flag.StringVar(&commandAliases, "command-aliases", "", "Comma separated list of command aliases, e.g. redo=rerun,shh=mute")
+
+	flag.StringVar(&listenAddr, "listen-addr", "", "Address on which to serve HTTP endpoints, e.g. :8081")
 
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose output")
 }
@@ -58,48 +213,392 @@ func main() {
 	}
 	logger.RegisterSink(lager.NewWriterSink(os.Stdout, lvl))
 
-	pilot, err := flyontime.NewAutoPilot(
-		concourseURL,
-		concourseTeam,
-		concourseUsername,
-		concoursePassword,
-		logger.Session("pilot"),
-	)
+	if backoffJitter < 0 || backoffJitter > 1 {
+		log.Fatalf("invalid backoff jitter %v, must be between 0 and 1", backoffJitter)
+	}
+	if notifyStarted && !concourseStreamEvents {
+		log.Fatal("-notify-started requires -concourse-stream-events")
+	}
+	if slackSigningSecret != "" && listenAddr == "" {
+		log.Fatal("-slack-signing-secret requires -listen-addr")
+	}
+	if mattermostCmdToken != "" && listenAddr == "" {
+		log.Fatal("-mattermost-command-token requires -listen-addr")
+	}
+
+	url, team, auth, err := concourseFromFlags()
+	if err != nil {
+		log.Fatal(err)
+	}
+	pilot, err := flyontime.NewAutoPilot(url, team, auth, logger.Session("pilot"))
 	if err != nil {
 		log.Fatal(err)
 	}
-	nc := chatFromFlags(logger.Session("messenger"))
-	m := flyontime.NewMonitor(pilot, nc, nc, logger.Session("monitor"))
+	pilot.StaleAfter = concourseStaleAfter
+	pilot.Backoff = backoffFromFlags()
+	pilot.StreamEvents = concourseStreamEvents
+	started := make(chan atc.Build, 100)
+	if notifyStarted {
+		pilot.OnEvent = flyontime.SendStarted(started)
+	}
+	var notifiers flyontime.MultiNotifier
+	var commander flyontime.Commander = noCommands{}
+	// Concourse polls as well as chat adapter initialization and connections
+	// are retried, hence liveness does not depend on them. Restarting would
+	// not help either.
+	var liveness, readiness health.Checks
+	readiness.Add("concourse", pilot.Health)
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", &liveness)
+	mux.Handle("/readyz", &readiness)
+	nc, backend, err := chatFromFlags(logger.Session("messenger"), mux)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if nc != nil {
+		notifiers = append(notifiers, flyontime.Instrument(backend, nc))
+		commander = nc
+		readiness.Add(backend, nc.Health)
+	}
+	if msteamsWebhookURL != "" {
+		notifiers = append(notifiers, flyontime.Instrument("msteams", &msteams.Notifier{
+			WebhookURL: msteamsWebhookURL,
+			Logger:     logger.Session("msteams"),
+		}))
+	}
+	em, err := emailFromFlags(logger.Session("email"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if em != nil {
+		notifiers = append(notifiers, flyontime.Instrument("email", em))
+	}
+	wh, err := webhookFromFlags(logger.Session("webhook"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if wh != nil {
+		notifiers = append(notifiers, flyontime.Instrument("webhook", wh))
+	}
+
+	m := flyontime.NewMonitor(pilot, notifiers, commander, logger.Session("monitor"))
+	if err := aliasesFromFlags(m); err != nil {
+		log.Fatal(err)
+	}
+	if e := escalatorFromFlags(); e != nil && criticalPipelines != "" {
+		m.Escalate(e, flyontime.EscalationPolicy{
+			Pipelines:           strings.Split(criticalPipelines, ","),
+			ConsecutiveFailures: escalationFailures,
+			FailingFor:          escalationFailingFor,
+		})
+	}
+	if seenBuildsFile != "" {
+		seen, err := flyontime.LoadSeenBuilds(seenBuildsFile, flyontime.DefaultSeenBuilds)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m.RememberBuilds(seen, flyontime.DefaultSeenBuildsSaveInterval)
+	}
+	if notifyStarted {
+		m.NotifyStarted(started)
+	}
+	m.WatchConcourse(concourseStaleAfter / 4)
+	flyontime.RegisterGauges(prometheus.DefaultRegisterer, m)
+	mux.Handle("/metrics", promhttp.Handler())
 	go m.Start()
 
+	if listenAddr != "" {
+		go func() {
+			log.Fatal(http.ListenAndServe(listenAddr, mux))
+		}()
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	<-sigChan
 	m.Stop()
+	if em != nil {
+		em.Close()
+	}
 
 	fmt.Printf("Bye\n")
 }
 
-type chat interface {
+type chatBot interface {
 	flyontime.Notifier
 	flyontime.Commander
+	Health() (map[string]interface{}, error)
+}
+
+// concourseFromFlags returns the URL and team of Concourse and how to
+// authenticate to it. Those of the fly target, if any, take precedence over
+// the -concourse-* flags, which are used for whatever the target lacks.
+func concourseFromFlags() (url, team string, auth flyontime.ConcourseAuth, err error) {
+	url, team = concourseURL, concourseTeam
+	auth = flyontime.ConcourseAuth{
+		Mode:      concourseAuth,
+		Username:  concourseUsername,
+		Password:  concoursePassword,
+		Token:     concourseToken,
+		TokenFile: concourseTokenFile,
+	}
+	if flyTarget == "" {
+		return url, team, auth, nil
+	}
+
+	flyrc := flyontime.DefaultFlyrc()
+	t, err := flyontime.LoadFlyTarget(flyrc, flyTarget)
+	if err != nil {
+		return "", "", auth, err
+	}
+	if t.API != "" {
+		url = t.API
+	}
+	if t.Team != "" {
+		team = t.Team
+	}
+	if t.Token.Value != "" {
+		auth.Mode = flyontime.AuthToken
+		auth.FlyTarget, auth.Flyrc = flyTarget, flyrc
+	}
+	return url, team, auth, nil
+}
+
+func backoffFromFlags() backoff.Policy {
+	return backoff.Policy{
+		Min:    backoffMin,
+		Max:    backoffMax,
+		Jitter: backoffJitter,
+	}
 }
 
-func chatFromFlags(logger lager.Logger) (n chat) {
+// chatFromFlags returns the configured chat bot, if any, along with the name
+// of its backend. HTTP endpoints of the bot are registered in mux. It is an
+// error to configure more than one chat backend.
+func chatFromFlags(logger lager.Logger, mux *http.ServeMux) (n chatBot, backend string, err error) {
+	var configured []string
+	for _, b := range []struct {
+		name string
+		set  bool
+	}{
+		{"slack", slackToken != ""},
+		{"mattermost", mattermostToken != ""},
+		{"matrix", matrixAccessToken != ""},
+		{"telegram", telegramToken != ""},
+		{"rocketchat", rocketChatToken != ""},
+		{"zulip", zulipAPIKey != ""},
+		{"discord", discordToken != ""},
+	} {
+		if b.set {
+			configured = append(configured, b.name)
+		}
+	}
+	if len(configured) > 1 {
+		return nil, "", fmt.Errorf("only one chat backend can be configured, got %s", strings.Join(configured, ", "))
+	}
+
 	if slackToken != "" {
-		n = &slacker.Notifier{
-			Token:     slackToken,
-			ChannelID: slackChannelID,
-			Logger:    logger,
+		if slackSlashResponse != slacker.ResponseEphemeral && slackSlashResponse != slacker.ResponseInChannel {
+			return nil, "", fmt.Errorf("invalid slack slash response type %q, must be either %s or %s",
+				slackSlashResponse, slacker.ResponseEphemeral, slacker.ResponseInChannel)
+		}
+		s := &slacker.Notifier{
+			Token:         slackToken,
+			AppToken:      slackAppToken,
+			SigningSecret: slackSigningSecret,
+			ChannelID:     slackChannelID,
+			Retention:     notificationRetention,
+			Reconnect:     backoffFromFlags(),
+			Logger:        logger,
+
+			SlashResponseType: slackSlashResponse,
 		}
+		if slackSigningSecret != "" {
+			mux.Handle("/slack/events", s.EventsHandler())
+			mux.Handle("/slack/commands", s.SlashCommandHandler())
+		}
+		n, backend = s, "slack"
 	}
 	if mattermostToken != "" {
-		n = &mattermost.Notifier{
-			API:       mattermostURL,
-			Token:     mattermostToken,
-			ChannelID: mattermostChannelID,
+		if mattermostSlashResp != model.COMMAND_RESPONSE_TYPE_EPHEMERAL && mattermostSlashResp != model.COMMAND_RESPONSE_TYPE_IN_CHANNEL {
+			return nil, "", fmt.Errorf("invalid mattermost slash response type %q, must be either %s or %s",
+				mattermostSlashResp, model.COMMAND_RESPONSE_TYPE_EPHEMERAL, model.COMMAND_RESPONSE_TYPE_IN_CHANNEL)
+		}
+		mm := &mattermost.Notifier{
+			API:          mattermostURL,
+			Token:        mattermostToken,
+			ChannelID:    mattermostChannelID,
+			Retention:    notificationRetention,
+			Reconnect:    backoffFromFlags(),
+			Logger:       logger,
+			CommandToken: mattermostCmdToken,
+
+			SlashResponseType: mattermostSlashResp,
+		}
+		if mattermostCmdToken != "" {
+			mux.Handle("/mattermost/commands", mm.SlashCommandHandler())
+		}
+		n, backend = mm, "mattermost"
+	}
+	if matrixAccessToken != "" {
+		n = &matrix.Notifier{
+			Homeserver:  matrixHomeserver,
+			AccessToken: matrixAccessToken,
+			RoomID:      matrixRoomID,
+			Retention:   notificationRetention,
+			Reconnect:   backoffFromFlags(),
+			Logger:      logger,
+		}
+		backend = "matrix"
+	}
+	if telegramToken != "" {
+		n = &telegram.Notifier{
+			Token:     telegramToken,
+			ChatID:    telegramChatID,
+			Retention: notificationRetention,
+			Reconnect: backoffFromFlags(),
+			Logger:    logger,
+		}
+		backend = "telegram"
+	}
+	if rocketChatToken != "" {
+		n = &rocketchat.Notifier{
+			API:       rocketChatURL,
+			UserID:    rocketChatUserID,
+			Token:     rocketChatToken,
+			Channel:   rocketChatChannel,
+			Retention: notificationRetention,
+			Reconnect: backoffFromFlags(),
+			Logger:    logger,
+		}
+		backend = "rocketchat"
+	}
+	if zulipAPIKey != "" {
+		n = &zulip.Notifier{
+			Site:      zulipSite,
+			Email:     zulipEmail,
+			APIKey:    zulipAPIKey,
+			Stream:    zulipStream,
+			Retention: notificationRetention,
+			Reconnect: backoffFromFlags(),
+			Logger:    logger,
+		}
+		backend = "zulip"
+	}
+	if discordToken != "" {
+		n = &discord.Notifier{
+			Token:     discordToken,
+			ChannelID: discordChannelID,
+			Retention: notificationRetention,
+			Reconnect: backoffFromFlags(),
 			Logger:    logger,
 		}
+		backend = "discord"
+	}
+	return n, backend, nil
+}
+
+func emailFromFlags(logger lager.Logger) (*email.Notifier, error) {
+	if smtpAddr == "" {
+		return nil, nil
+	}
+	pipelines, err := email.Recipients(emailPipelineRecipients)
+	if err != nil {
+		return nil, err
+	}
+	e := &email.Notifier{
+		Addr:         smtpAddr,
+		From:         emailFrom,
+		Pipelines:    pipelines,
+		DigestWindow: emailDigestWindow,
+		Logger:       logger,
+	}
+	if emailTo != "" {
+		e.To = strings.Split(emailTo, ",")
+	}
+	if smtpUsername != "" {
+		host, _, err := net.SplitHostPort(smtpAddr)
+		if err != nil {
+			return nil, err
+		}
+		e.Auth = smtp.PlainAuth("", smtpUsername, smtpPassword, host)
+	}
+	return e, nil
+}
+
+func escalatorFromFlags() flyontime.Escalator {
+	if pagerDutyRoutingKey != "" {
+		return &escalation.PagerDuty{RoutingKey: pagerDutyRoutingKey}
+	}
+	if opsgenieAPIKey != "" {
+		return &escalation.Opsgenie{APIKey: opsgenieAPIKey, URL: opsgenieURL}
+	}
+	return nil
+}
+
+func webhookFromFlags(logger lager.Logger) (*webhook.Notifier, error) {
+	if webhookURL == "" {
+		return nil, nil
+	}
+	w := &webhook.Notifier{
+		URL:     webhookURL,
+		Secret:  webhookSecret,
+		Retries: webhookRetries,
+		Timeout: webhookTimeout,
+		Headers: webhookHeaders,
+		Logger:  logger,
+	}
+	if webhookTemplateFile != "" {
+		tmpl, err := ioutil.ReadFile(webhookTemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		w.Template = string(tmpl)
+	}
+	return w, nil
+}
+
+// headerFlags collects the headers of a repeatable flag in the form
+// "Name=value". Values are taken as they are, as they may contain commas.
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	var s []string
+	for k, v := range h {
+		s = append(s, k+"="+v)
+	}
+	return strings.Join(s, ", ")
+}
+
+func (h headerFlags) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+		return fmt.Errorf("invalid header %q, must be Name=value", value)
+	}
+	h[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	return nil
+}
+
+// noCommands is used when no chat is configured, thus there is nowhere to
+// receive commands from.
+type noCommands struct{}
+
+func (noCommands) Commands() <-chan *flyontime.Command {
+	return nil
+}
+
+func aliasesFromFlags(m *flyontime.Monitor) error {
+	if commandAliases == "" {
+		return nil
+	}
+	for _, a := range strings.Split(commandAliases, ",") {
+		kv := strings.SplitN(a, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid command alias %q", a)
+		}
+		if err := m.Alias(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])); err != nil {
+			return err
+		}
 	}
-	return n
+	return nil
 }
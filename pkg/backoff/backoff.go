@@ -0,0 +1,88 @@
+// Package backoff implements exponential backoff with jitter, used when
+// retrying failed requests and reconnecting to chat services.
+package backoff
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultPolicy is used in place of the zero Policy.
+var DefaultPolicy = Policy{
+	Min:    time.Second,
+	Max:    time.Minute,
+	Jitter: 0.2,
+}
+
+// Policy describes how the delay between retries grows. The delay after the
+// first failure is Min and it doubles after each consecutive failure, up to
+// Max. A non-positive Min is replaced with the one of DefaultPolicy, so that
+// retries never spin.
+type Policy struct {
+	Min time.Duration
+	Max time.Duration
+	// Jitter is the fraction of each delay, between 0 and 1, which is
+	// randomized, so that clients do not retry in lockstep. Values above 1
+	// are treated as 1, so that delays never become negative.
+	Jitter float64
+}
+
+// Backoff keeps track of consecutive failures. It is safe for concurrent
+// use. The zero value uses DefaultPolicy.
+type Backoff struct {
+	Policy Policy
+
+	mu       sync.Mutex
+	failures int
+}
+
+// Next records a failure and returns for how long to wait before retrying.
+func (b *Backoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	return b.Policy.Delay(b.failures)
+}
+
+// Reset records a success, so that the next failure waits the least.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// Failures returns the number of consecutive failures.
+func (b *Backoff) Failures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures
+}
+
+// Delay returns for how long to wait after the given number of consecutive
+// failures.
+func (p Policy) Delay(failures int) time.Duration {
+	if p == (Policy{}) {
+		p = DefaultPolicy
+	}
+	if p.Min <= 0 {
+		p.Min = DefaultPolicy.Min
+	}
+	if p.Max < p.Min {
+		p.Max = p.Min
+	}
+	d := p.Min
+	for i := 1; i < failures && d < p.Max; i++ {
+		d *= 2
+	}
+	if d > p.Max {
+		d = p.Max
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
@@ -0,0 +1,69 @@
+package backoff_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/backoff"
+)
+
+var _ = Describe("Policy", func() {
+	policy := Policy{Min: time.Second, Max: 10 * time.Second}
+
+	DescribeTable("Delay",
+		func(failures int, delay time.Duration) {
+			Ω(policy.Delay(failures)).Should(Equal(delay))
+		},
+		Entry("first failure", 1, time.Second),
+		Entry("second failure", 2, 2*time.Second),
+		Entry("fourth failure", 4, 8*time.Second),
+		Entry("fifth failure", 5, 10*time.Second),
+		Entry("many failures", 1000, 10*time.Second),
+	)
+
+	It("should randomize a fraction of the delay", func() {
+		policy := Policy{Min: time.Second, Max: 10 * time.Second, Jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			d := policy.Delay(2)
+			Ω(d).Should(BeNumerically(">", time.Second))
+			Ω(d).Should(BeNumerically("<=", 2*time.Second))
+		}
+	})
+
+	It("should not return negative delays when Jitter is above 1", func() {
+		policy := Policy{Min: time.Second, Max: 10 * time.Second, Jitter: 5}
+		for i := 0; i < 100; i++ {
+			d := policy.Delay(2)
+			Ω(d).Should(BeNumerically(">", 0))
+			Ω(d).Should(BeNumerically("<=", 2*time.Second))
+		}
+	})
+
+	It("should not return zero delays when Min is not set", func() {
+		policy := Policy{Max: 10 * time.Second}
+		Ω(policy.Delay(1)).Should(Equal(DefaultPolicy.Min))
+		Ω(policy.Delay(2)).Should(Equal(2 * DefaultPolicy.Min))
+	})
+
+	It("should use the default policy when zero", func() {
+		d := Policy{}.Delay(100)
+		Ω(d).Should(BeNumerically("<=", DefaultPolicy.Max))
+		Ω(d).Should(BeNumerically(">=", time.Duration(float64(DefaultPolicy.Max)*(1-DefaultPolicy.Jitter))))
+	})
+})
+
+var _ = Describe("Backoff", func() {
+	It("should grow the delay with consecutive failures until reset", func() {
+		b := &Backoff{Policy: Policy{Min: time.Millisecond, Max: time.Second}}
+		Ω(b.Next()).Should(Equal(time.Millisecond))
+		Ω(b.Next()).Should(Equal(2 * time.Millisecond))
+		Ω(b.Failures()).Should(Equal(2))
+
+		b.Reset()
+		Ω(b.Failures()).Should(Equal(0))
+		Ω(b.Next()).Should(Equal(time.Millisecond))
+	})
+})
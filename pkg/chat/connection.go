@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/pkg/errors"
+)
+
+// Connection tracks the state of the connection of an adapter to its chat
+// service, for the purpose of health checks and reconnecting. It is safe for
+// concurrent use. The zero value is a connection that is not established yet.
+type Connection struct {
+	// Reconnect is the policy of delays between attempts to reconnect.
+	// Defaults to backoff.DefaultPolicy.
+	Reconnect backoff.Policy
+
+	mu      sync.Mutex
+	up      bool
+	since   time.Time
+	lastErr error
+	retry   backoff.Backoff
+	retryAt time.Time
+}
+
+// Up records that the connection has been established.
+func (c *Connection) Up() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.up {
+		c.up, c.since = true, time.Now()
+	}
+	c.retry.Reset()
+	c.retryAt = time.Time{}
+}
+
+// Down records that the connection has failed with err. It returns for how
+// long to wait before reconnecting, which grows with each consecutive
+// failure.
+func (c *Connection) Down(err error) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.up || c.since.IsZero() {
+		c.up, c.since = false, time.Now()
+	}
+	c.lastErr = err
+	c.retry.Policy = c.Reconnect
+	delay := c.retry.Next()
+	c.retryAt = time.Now().Add(delay)
+	return delay
+}
+
+// Check reports the state of the connection. It returns an error unless the
+// connection is up.
+func (c *Connection) Check() (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	details := map[string]interface{}{"connected": c.up}
+	if !c.since.IsZero() {
+		details["since"] = c.since
+	}
+	if c.lastErr != nil {
+		details["last_error"] = c.lastErr.Error()
+	}
+	if !c.up && !c.retryAt.IsZero() {
+		details["failures"] = c.retry.Failures()
+		details["retry_at"] = c.retryAt
+	}
+	switch {
+	case c.up:
+		return details, nil
+	case c.lastErr != nil:
+		return details, c.lastErr
+	}
+	return details, errors.New("not connected yet")
+}
+
+// Run calls connect until it returns nil, waiting before each retry for the
+// delay returned by Down. connect is expected to initialize the adapter if it
+// has not been yet, connect to the chat service, call Up once connected and
+// handle the received messages until the connection fails.
+func (c *Connection) Run(logger lager.Logger, connect func() error) {
+	for {
+		err := connect()
+		if err == nil {
+			return
+		}
+		delay := c.Down(err)
+		logger.Error("fail-will-retry", err, lager.Data{"retry-in": delay.String()})
+		time.Sleep(delay)
+	}
+}
+
+// Health calls init, the initialization of an adapter, and reports the state
+// of the connection. It returns an error unless the adapter is initialized
+// and the connection is up.
+func (c *Connection) Health(init func() error) (map[string]interface{}, error) {
+	if err := init(); err != nil {
+		return map[string]interface{}{"initialized": false}, errors.Wrap(err, "init failed")
+	}
+	return c.Check()
+}
+
+// Init runs the initialization of an adapter, such as obtaining the identity
+// of the bot, until it succeeds. Unlike sync.Once, failures are not cached, so
+// that an adapter recovers from its chat service being down at startup. It is
+// safe for concurrent use.
+type Init struct {
+	mu   sync.Mutex
+	done bool
+}
+
+// Do calls f, unless a previous call has succeeded, and returns its error.
+// Concurrent calls wait for each other.
+func (i *Init) Do(f func() error) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.done {
+		return nil
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	i.done = true
+	return nil
+}
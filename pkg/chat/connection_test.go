@@ -0,0 +1,112 @@
+package chat_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/chat"
+)
+
+var _ = Describe("Connection", func() {
+	var conn *Connection
+
+	BeforeEach(func() {
+		conn = &Connection{}
+	})
+
+	It("should not be healthy before it is established", func() {
+		details, err := conn.Check()
+		Ω(err).Should(MatchError("not connected yet"))
+		Ω(details).Should(HaveKeyWithValue("connected", false))
+	})
+
+	It("should be healthy once it is up", func() {
+		conn.Up()
+		details, err := conn.Check()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(details).Should(HaveKeyWithValue("connected", true))
+		Ω(details).Should(HaveKey("since"))
+	})
+
+	It("should report the last error once it is down", func() {
+		conn.Up()
+		conn.Down(errors.New("boom"))
+		details, err := conn.Check()
+		Ω(err).Should(MatchError("boom"))
+		Ω(details).Should(HaveKeyWithValue("connected", false))
+		Ω(details).Should(HaveKeyWithValue("last_error", "boom"))
+
+		conn.Up()
+		_, err = conn.Check()
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Run", func() {
+		It("should reconnect until connect returns nil", func() {
+			conn.Reconnect = backoff.Policy{Min: time.Millisecond, Max: time.Millisecond}
+			var errs []error
+			conn.Run(lager.NewLogger(""), func() error {
+				if len(errs) < 2 {
+					_, err := conn.Check()
+					errs = append(errs, err)
+					return errors.New("boom")
+				}
+				conn.Up()
+				return nil
+			})
+			Ω(errs).Should(HaveLen(2))
+			Ω(errs[1]).Should(MatchError("boom"))
+			_, err := conn.Check()
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("Health", func() {
+		It("should not be healthy when the initialization fails", func() {
+			details, err := conn.Health(func() error { return errors.New("boom") })
+			Ω(err).Should(MatchError("init failed: boom"))
+			Ω(details).Should(HaveKeyWithValue("initialized", false))
+		})
+
+		It("should report the state of the connection once initialized", func() {
+			conn.Up()
+			details, err := conn.Health(func() error { return nil })
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(details).Should(HaveKeyWithValue("connected", true))
+		})
+	})
+})
+
+var _ = Describe("Init", func() {
+	var (
+		in    *Init
+		calls int
+	)
+
+	BeforeEach(func() {
+		in = &Init{}
+		calls = 0
+	})
+
+	It("should retry until initialization succeeds", func() {
+		fail := func() error {
+			calls++
+			return errors.New("boom")
+		}
+		succeed := func() error {
+			calls++
+			return nil
+		}
+
+		Ω(in.Do(fail)).Should(MatchError("boom"))
+		Ω(in.Do(fail)).Should(MatchError("boom"))
+		Ω(in.Do(succeed)).Should(Succeed())
+		Ω(in.Do(fail)).Should(Succeed())
+		Ω(calls).Should(Equal(3))
+	})
+})
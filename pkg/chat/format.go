@@ -0,0 +1,66 @@
+package chat
+
+import (
+	"fmt"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/lunixbochs/vtclean"
+)
+
+// FormatCode formats job output as a Markdown code block, keeping at most its
+// last max characters (or all of them if max is zero). Terminal escape
+// sequences are removed.
+func FormatCode(output string, max int) string {
+	if output == "" {
+		return ""
+	}
+	output = vtclean.Clean(output, false)
+	if max > 0 {
+		output = Truncate(output, max)
+	}
+	return fmt.Sprintf("```\n%s\n```", output)
+}
+
+// Truncate returns the last max characters of s, prefixed with an ellipsis
+// if anything was cut. It returns an empty string if max is not positive.
+func Truncate(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return "…" + string(r[len(r)-max+1:])
+}
+
+// Color returns the name of the attachment color used by Slack compatible
+// APIs for severity.
+func Color(severity flyontime.Severity) string {
+	switch severity {
+	case flyontime.SeverityInfo:
+		return "good"
+	case flyontime.SeverityWarn:
+		return "warning"
+	default:
+		return "danger"
+	}
+}
+
+// RGB returns the color of severity as a 24-bit RGB value. The values match
+// the ones used by Color.
+func RGB(severity flyontime.Severity) int {
+	switch severity {
+	case flyontime.SeverityInfo:
+		return 0x2eb886
+	case flyontime.SeverityWarn:
+		return 0xdaa038
+	default:
+		return 0xa30200
+	}
+}
+
+// HexColor returns the color of severity in #rrggbb notation.
+func HexColor(severity flyontime.Severity) string {
+	return fmt.Sprintf("#%06x", RGB(severity))
+}
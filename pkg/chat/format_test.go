@@ -0,0 +1,48 @@
+package chat_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+var _ = Describe("Format", func() {
+	Describe("FormatCode", func() {
+		It("should format the output as a code block", func() {
+			Ω(FormatCode("\x1b[31mboom\x1b[0m", 0)).Should(Equal("```\nboom\n```"))
+		})
+
+		It("should keep the end of long output", func() {
+			Ω(FormatCode("first line\nlast line", 9)).Should(Equal("```\n…ast line\n```"))
+		})
+
+		It("should return nothing for empty output", func() {
+			Ω(FormatCode("", 0)).Should(BeEmpty())
+		})
+	})
+
+	Describe("Truncate", func() {
+		It("should leave short text as is", func() {
+			Ω(Truncate("абв", 3)).Should(Equal("абв"))
+		})
+
+		It("should keep the last characters of long text", func() {
+			Ω(Truncate("абвгд", 3)).Should(Equal("…гд"))
+		})
+
+		It("should return nothing when no characters are to be kept", func() {
+			Ω(Truncate("абв", 0)).Should(BeEmpty())
+			Ω(Truncate("абв", -1)).Should(BeEmpty())
+		})
+	})
+
+	Describe("HexColor", func() {
+		It("should return the color of each severity", func() {
+			Ω(HexColor(flyontime.SeverityInfo)).Should(Equal("#2eb886"))
+			Ω(HexColor(flyontime.SeverityWarn)).Should(Equal("#daa038"))
+			Ω(HexColor(flyontime.SeverityError)).Should(Equal("#a30200"))
+		})
+	})
+})
@@ -1,6 +1,7 @@
-package mattermost
+package chat
 
-var concourseLogoPNG = [...]byte{0x89, 0x50, 0x4e, 0x47, 0xd, 0xa, 0x1a, 0xa,
+// LogoPNG is the Concourse logo, which is used as avatar of the bot users.
+var LogoPNG = []byte{0x89, 0x50, 0x4e, 0x47, 0xd, 0xa, 0x1a, 0xa,
 	0x0, 0x0, 0x0, 0xd, 0x49, 0x48, 0x44, 0x52, 0x0, 0x0, 0x1, 0x13, 0x0, 0x0, 0x1,
 	0x13, 0x8, 0x6, 0x0, 0x0, 0x0, 0x15, 0xa9, 0xe2, 0xb8, 0x0, 0x0, 0x23, 0xbd,
 	0x49, 0x44, 0x41, 0x54, 0x78, 0xda, 0xec, 0x9d, 0x4f, 0x52, 0x1b, 0xcb, 0xf2,
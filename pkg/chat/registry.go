@@ -0,0 +1,33 @@
+package chat
+
+import (
+	"time"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// DefaultTTL is the default duration for which posted notifications are
+// remembered.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// Registry keeps track of posted notifications by the id of the message (or
+// thread, topic, etc.) they were posted as, so that replies could be resolved
+// to the notified job. It is a Store, thus only a bounded number of recent
+// notifications are remembered. The zero value is ready to use.
+type Registry struct {
+	Store
+}
+
+// Put remembers that n was posted as id.
+func (r *Registry) Put(id string, n *flyontime.Notification) {
+	r.Store.Put(id, n)
+}
+
+// Get returns the notification posted as id, if it is still remembered.
+func (r *Registry) Get(id string) (*flyontime.Notification, bool) {
+	v, ok := r.Store.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*flyontime.Notification), true
+}
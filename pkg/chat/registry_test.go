@@ -0,0 +1,58 @@
+package chat_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *Registry
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		registry = &Registry{}
+		notification = &flyontime.Notification{Title: "Job j1 from p1 has failed."}
+	})
+
+	It("should resolve ids of posted notifications", func() {
+		registry.Put("m1", notification)
+
+		n, ok := registry.Get("m1")
+		Ω(ok).Should(BeTrue())
+		Ω(n).Should(Equal(notification))
+	})
+
+	It("should not resolve unknown ids", func() {
+		_, ok := registry.Get("m1")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should replace notifications posted with the same id", func() {
+		other := &flyontime.Notification{Title: "Job j1 from p1 has succeeded."}
+		registry.Put("m1", notification)
+		registry.Put("m1", other)
+
+		n, _ := registry.Get("m1")
+		Ω(n).Should(Equal(other))
+	})
+
+	Context("when the TTL elapses", func() {
+		BeforeEach(func() {
+			registry.TTL = 10 * time.Millisecond
+		})
+
+		It("should forget the notification", func() {
+			registry.Put("m1", notification)
+
+			Eventually(func() bool {
+				_, ok := registry.Get("m1")
+				return ok
+			}).Should(BeFalse())
+		})
+	})
+})
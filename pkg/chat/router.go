@@ -0,0 +1,89 @@
+// Package chat implements the parts shared by the chat adapters, so that each
+// of them only has to deal with its transport: routing of messages to
+// commands, keeping track of posted notifications and formatting.
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/command"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// Reply posts text as a reply to the message a command originates from.
+type Reply func(text string) error
+
+// Router turns chat messages into commands. The zero value is ready to use.
+type Router struct {
+	initOnce sync.Once
+	commands chan *flyontime.Command
+}
+
+func (r *Router) init() {
+	r.initOnce.Do(func() {
+		r.commands = make(chan *flyontime.Command)
+	})
+}
+
+// Commands returns the channel on which routed commands are sent.
+func (r *Router) Commands() <-chan *flyontime.Command {
+	r.init()
+	return r.commands
+}
+
+// Route parses text as a command for job (if any) and sends it for execution.
+// Each response to the command is posted using reply. Invalid commands are
+// answered with the reason, while messages with no command are ignored.
+func (r *Router) Route(logger lager.Logger, text string, job *flyontime.Job, reply Reply) {
+	r.init()
+	c, err := flyontime.ParseCommand(text)
+	if err == command.ErrEmpty {
+		logger.Info("missing-command")
+		return
+	}
+	if err != nil {
+		if err := reply(fmt.Sprintf("Invalid command: %v.", err)); err != nil {
+			logger.Error("reply.fail", err)
+		}
+		return
+	}
+	c.Job = job
+
+	go func() {
+		responses := make(chan string)
+		c.Responses = responses
+
+		// Send the command.
+		r.commands <- c
+
+		// And post each response as a message.
+		for resp := range responses {
+			if err := reply(resp); err != nil {
+				logger.Error("reply.fail", err)
+			}
+		}
+	}()
+}
+
+// StripMention returns the text following mention, if text starts with it.
+// Mentions are matched regardless of case and must be followed by the end of
+// the text, a space or a colon, so that e.g. "@bot" does not match "@bot2".
+// A colon following the mention is stripped as well.
+func StripMention(text, mention string) (string, bool) {
+	if len(text) < len(mention) || !strings.EqualFold(text[:len(mention)], mention) {
+		return "", false
+	}
+	rest := text[len(mention):]
+	if strings.HasSuffix(mention, ":") || rest == "" {
+		return rest, true
+	}
+	if r, _ := utf8.DecodeRuneInString(rest); r != ':' && !unicode.IsSpace(r) {
+		return "", false
+	}
+	return strings.TrimPrefix(rest, ":"), true
+}
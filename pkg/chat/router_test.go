@@ -0,0 +1,131 @@
+package chat_test
+
+import (
+	"errors"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// replies records the replies posted through it.
+type replies struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (r *replies) reply(text string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, text)
+	return r.err
+}
+
+func (r *replies) Sent() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sent...)
+}
+
+var _ = Describe("Router", func() {
+	var router *Router
+	var logger *lagertest.TestLogger
+	var r *replies
+
+	BeforeEach(func() {
+		router = &Router{}
+		logger = lagertest.NewTestLogger("router")
+		r = &replies{}
+	})
+
+	It("should send the parsed command for the job", func() {
+		job := &flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"}
+		router.Route(logger, " Mute 1h", job, r.reply)
+
+		var c *flyontime.Command
+		Eventually(router.Commands()).Should(Receive(&c))
+		Ω(c.Name).Should(Equal("mute"))
+		Ω(c.Args).Should(Equal([]string{"1h"}))
+		Ω(c.Job).Should(Equal(job))
+	})
+
+	It("should post each response as a reply", func() {
+		router.Route(logger, "pipelines", nil, r.reply)
+
+		var c *flyontime.Command
+		Eventually(router.Commands()).Should(Receive(&c))
+		c.Responses <- "p1"
+		c.Responses <- "p2"
+		close(c.Responses)
+
+		Eventually(r.Sent).Should(Equal([]string{"p1", "p2"}))
+	})
+
+	It("should log failed replies", func() {
+		r.err = errors.New("boom")
+		router.Route(logger, "pipelines", nil, r.reply)
+
+		var c *flyontime.Command
+		Eventually(router.Commands()).Should(Receive(&c))
+		c.Responses <- "p1"
+		close(c.Responses)
+
+		Eventually(logger).Should(gbytes.Say("reply.fail"))
+	})
+
+	Context("when the command is invalid", func() {
+		It("should reply with the reason", func() {
+			router.Route(logger, `pause "p1`, nil, r.reply)
+
+			Ω(r.Sent()).Should(Equal([]string{"Invalid command: unterminated quote."}))
+			Consistently(router.Commands()).ShouldNot(Receive())
+		})
+	})
+
+	Context("when there is no command", func() {
+		It("should ignore the message", func() {
+			router.Route(logger, "  ", nil, r.reply)
+
+			Ω(r.Sent()).Should(BeEmpty())
+			Consistently(router.Commands()).ShouldNot(Receive())
+		})
+	})
+})
+
+var _ = Describe("StripMention", func() {
+	It("should return the text following the mention", func() {
+		text, ok := StripMention("@Bot pause p", "@bot")
+		Ω(ok).Should(BeTrue())
+		Ω(text).Should(Equal(" pause p"))
+	})
+
+	It("should strip a colon following the mention", func() {
+		text, ok := StripMention("@bot: pause p", "@bot")
+		Ω(ok).Should(BeTrue())
+		Ω(text).Should(Equal(" pause p"))
+	})
+
+	It("should match a mention with nothing following it", func() {
+		text, ok := StripMention("@bot", "@bot")
+		Ω(ok).Should(BeTrue())
+		Ω(text).Should(BeEmpty())
+	})
+
+	It("should not match usernames starting with the mentioned one", func() {
+		_, ok := StripMention("@bot2 pause p", "@bot")
+		Ω(ok).Should(BeFalse())
+		_, ok = StripMention("@botany", "@bot")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should not match text not starting with the mention", func() {
+		_, ok := StripMention("hey @bot pause p", "@bot")
+		Ω(ok).Should(BeFalse())
+	})
+})
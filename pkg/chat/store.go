@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultCapacity is the default maximum number of entries in a Store.
+const DefaultCapacity = 10000
+
+var (
+	storeEntries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "flyontime_chat_store_entries",
+		Help: "Number of entries in chat adapter stores.",
+	}, []string{"store"})
+	storeEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyontime_chat_store_evictions_total",
+		Help: "Number of entries evicted from chat adapter stores, by reason (expired or capacity).",
+	}, []string{"store", "reason"})
+)
+
+// Store is a bounded key-value store whose entries expire. When full, the
+// least recently used entry is evicted to make room for new ones. It is safe
+// for concurrent use. The zero value is ready to use.
+type Store struct {
+	Name     string        // Used to label the metrics of the store.
+	Capacity int           // Defaults to DefaultCapacity.
+	TTL      time.Duration // Defaults to DefaultTTL.
+
+	mu    sync.Mutex
+	lru   *list.List // front is most recently used
+	items map[string]*list.Element
+}
+
+type storeEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func (s *Store) init() {
+	if s.items != nil {
+		return
+	}
+	if s.Capacity == 0 {
+		s.Capacity = DefaultCapacity
+	}
+	if s.TTL == 0 {
+		s.TTL = DefaultTTL
+	}
+	s.lru = list.New()
+	s.items = make(map[string]*list.Element)
+}
+
+// Put stores value under key, replacing any previous value. The entry
+// expires TTL after being put.
+func (s *Store) Put(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+
+	now := time.Now()
+	s.expire(now)
+	if el, ok := s.items[key]; ok {
+		s.lru.Remove(el)
+	}
+	s.items[key] = s.lru.PushFront(&storeEntry{key: key, value: value, expires: now.Add(s.TTL)})
+	storeEntries.WithLabelValues(s.Name).Set(float64(s.lru.Len()))
+	for s.lru.Len() > s.Capacity {
+		s.remove(s.lru.Back())
+		storeEvictions.WithLabelValues(s.Name, "capacity").Inc()
+	}
+}
+
+// Get returns the value stored under key, unless it has expired or has been
+// evicted.
+func (s *Store) Get(key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*storeEntry)
+	if time.Now().After(e.expires) {
+		s.remove(el)
+		storeEvictions.WithLabelValues(s.Name, "expired").Inc()
+		return nil, false
+	}
+	s.lru.MoveToFront(el)
+	return e.value, true
+}
+
+// Len returns the number of entries in the store, including the expired ones
+// that are not evicted yet. Expired entries are evicted when putting new ones
+// or getting them.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	return s.lru.Len()
+}
+
+// expire evicts the entries that have expired by now. Since entries are
+// moved to the front when used, expired ones are not necessarily at the back,
+// thus all of them are checked.
+func (s *Store) expire(now time.Time) {
+	for el := s.lru.Back(); el != nil; {
+		prev := el.Prev()
+		if now.After(el.Value.(*storeEntry).expires) {
+			s.remove(el)
+			storeEvictions.WithLabelValues(s.Name, "expired").Inc()
+		}
+		el = prev
+	}
+}
+
+func (s *Store) remove(el *list.Element) {
+	s.lru.Remove(el)
+	delete(s.items, el.Value.(*storeEntry).key)
+	storeEntries.WithLabelValues(s.Name).Set(float64(s.lru.Len()))
+}
@@ -0,0 +1,98 @@
+package chat_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+
+	. "github.com/Bo0mer/flyontime/pkg/chat"
+)
+
+var _ = Describe("Store", func() {
+	var store *Store
+
+	BeforeEach(func() {
+		store = &Store{Name: "test", Capacity: 2}
+	})
+
+	get := func(key string) interface{} {
+		v, _ := store.Get(key)
+		return v
+	}
+
+	It("should return stored values", func() {
+		store.Put("a", 1)
+		store.Put("b", 2)
+		store.Put("a", 3)
+
+		Ω(get("a")).Should(Equal(3))
+		Ω(get("b")).Should(Equal(2))
+		Ω(store.Len()).Should(Equal(2))
+	})
+
+	Context("when the capacity is exceeded", func() {
+		It("should evict the least recently used entry", func() {
+			store.Put("a", 1)
+			store.Put("b", 2)
+			store.Get("a")
+			store.Put("c", 3)
+
+			Ω(get("a")).Should(Equal(1))
+			Ω(get("b")).Should(BeNil())
+			Ω(get("c")).Should(Equal(3))
+			Ω(store.Len()).Should(Equal(2))
+		})
+	})
+
+	Context("when entries expire", func() {
+		BeforeEach(func() {
+			store.TTL = 10 * time.Millisecond
+		})
+
+		It("should not return them", func() {
+			store.Put("a", 1)
+			Eventually(func() interface{} { return get("a") }).Should(BeNil())
+		})
+
+		It("should evict them when putting new entries", func() {
+			store.Put("a", 1)
+			time.Sleep(20 * time.Millisecond)
+			store.Put("b", 2)
+
+			Ω(store.Len()).Should(Equal(1))
+		})
+	})
+
+	metrics := func() string {
+		mfs, err := prometheus.DefaultGatherer.Gather()
+		Ω(err).ShouldNot(HaveOccurred())
+		var b bytes.Buffer
+		for _, mf := range mfs {
+			_, err := expfmt.MetricFamilyToText(&b, mf)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		return b.String()
+	}
+
+	It("should expose its size as a metric", func() {
+		store.Put("a", 1)
+
+		Ω(metrics()).Should(ContainSubstring(`flyontime_chat_store_entries{store="test"} 1`))
+	})
+
+	It("should keep the size metric up to date when entries are evicted", func() {
+		store.TTL = 10 * time.Millisecond
+		store.Put("a", 1)
+		store.Put("b", 2)
+		store.Put("c", 3)
+		Ω(metrics()).Should(ContainSubstring(`flyontime_chat_store_entries{store="test"} 2`))
+
+		time.Sleep(20 * time.Millisecond)
+		Ω(get("c")).Should(BeNil())
+		Ω(metrics()).Should(ContainSubstring(`flyontime_chat_store_entries{store="test"} 1`))
+	})
+})
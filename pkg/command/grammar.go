@@ -0,0 +1,147 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Spec describes a single command.
+type Spec struct {
+	Name    string
+	Aliases []string
+	Args    string   // Synopsis of the arguments, e.g. "<pipeline> [duration]".
+	Flags   []string // Synopses of the accepted flags, e.g. "--for=<duration>".
+	Help    string
+}
+
+// CheckFlags returns an error if any of flags is not accepted by the command.
+func (s *Spec) CheckFlags(flags map[string]string) error {
+	accepted := make(map[string]bool)
+	for _, f := range s.Flags {
+		name := strings.TrimPrefix(f, "--")
+		if i := strings.IndexByte(name, '='); i >= 0 {
+			name = name[:i]
+		}
+		accepted[name] = true
+	}
+	var unknown []string
+	for name := range flags {
+		if !accepted[name] {
+			unknown = append(unknown, "--"+name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown flag %s", strings.Join(unknown, ", "))
+}
+
+// Usage returns a human readable description of the command.
+func (s *Spec) Usage() string {
+	var b strings.Builder
+	for j, n := range append([]string{s.Name}, s.Aliases...) {
+		if j > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "*%s*", n)
+	}
+	if s.Args != "" {
+		fmt.Fprintf(&b, " %s", s.Args)
+	}
+	for _, f := range s.Flags {
+		fmt.Fprintf(&b, " [%s]", f)
+	}
+	fmt.Fprintf(&b, "\n\t%s", s.Help)
+	return b.String()
+}
+
+// Grammar is a set of commands that could be looked up by name or alias.
+// Names and aliases may consist of multiple words, e.g. "try again".
+// The zero value is an empty grammar ready to use.
+type Grammar struct {
+	specs    []*Spec
+	index    map[string]*Spec
+	maxWords int
+}
+
+// Add adds the command described by s to the grammar. It is an error to add
+// a command whose name or aliases are already known.
+func (g *Grammar) Add(s Spec) error {
+	s.Aliases = append([]string(nil), s.Aliases...)
+	s.Flags = append([]string(nil), s.Flags...)
+	sp := &s
+	keys := append([]string{s.Name}, s.Aliases...)
+	for _, k := range keys {
+		if _, ok := g.index[normalize(k)]; ok {
+			return fmt.Errorf("command %q already defined", k)
+		}
+	}
+	for _, k := range keys {
+		g.put(k, sp)
+	}
+	g.specs = append(g.specs, sp)
+	return nil
+}
+
+// Alias makes alias an alternative name for the command called name.
+func (g *Grammar) Alias(alias, name string) error {
+	sp, ok := g.index[normalize(name)]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if other, ok := g.index[normalize(alias)]; ok {
+		if other == sp {
+			return nil
+		}
+		return fmt.Errorf("alias %q already used by command %q", alias, other.Name)
+	}
+	g.put(alias, sp)
+	sp.Aliases = append(sp.Aliases, alias)
+	return nil
+}
+
+// Lookup finds the command called name. Since names could consist of
+// multiple words, the leading arguments are considered part of the name if
+// that results in a longer match. The returned args are the remaining ones.
+func (g *Grammar) Lookup(name string, args []string) (s *Spec, rest []string, ok bool) {
+	n := len(args)
+	if n > g.maxWords-1 {
+		n = g.maxWords - 1
+	}
+	for ; n >= 0; n-- {
+		key := normalize(strings.Join(append([]string{name}, args[:n]...), " "))
+		if s, ok := g.index[key]; ok {
+			return s, args[n:], true
+		}
+	}
+	return nil, args, false
+}
+
+// Usage returns a human readable description of all commands in the grammar.
+func (g *Grammar) Usage() string {
+	var b strings.Builder
+	for i, s := range g.specs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(s.Usage())
+	}
+	return b.String()
+}
+
+func (g *Grammar) put(key string, s *Spec) {
+	if g.index == nil {
+		g.index = make(map[string]*Spec)
+	}
+	key = normalize(key)
+	g.index[key] = s
+	if w := len(strings.Fields(key)); w > g.maxWords {
+		g.maxWords = w
+	}
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,102 @@
+package command_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/command"
+)
+
+var _ = Describe("Grammar", func() {
+	var g *Grammar
+
+	BeforeEach(func() {
+		g = new(Grammar)
+		Ω(g.Add(Spec{Name: "rerun", Aliases: []string{"retry", "try again"}, Help: "Rerun the job."})).Should(Succeed())
+		Ω(g.Add(Spec{Name: "mute", Args: "[duration]", Help: "Mute the job."})).Should(Succeed())
+	})
+
+	Describe("Add", func() {
+		It("should reject already defined names", func() {
+			Ω(g.Add(Spec{Name: "mute"})).ShouldNot(Succeed())
+		})
+
+		It("should reject already defined aliases", func() {
+			Ω(g.Add(Spec{Name: "again", Aliases: []string{"Try  Again"}})).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("Lookup", func() {
+		It("should find commands by name", func() {
+			s, args, ok := g.Lookup("mute", []string{"1h"})
+			Ω(ok).Should(BeTrue())
+			Ω(s.Name).Should(Equal("mute"))
+			Ω(args).Should(Equal([]string{"1h"}))
+		})
+
+		It("should find commands by alias", func() {
+			s, _, ok := g.Lookup("retry", nil)
+			Ω(ok).Should(BeTrue())
+			Ω(s.Name).Should(Equal("rerun"))
+		})
+
+		It("should find commands by multi-word alias", func() {
+			s, args, ok := g.Lookup("try", []string{"again", "please"})
+			Ω(ok).Should(BeTrue())
+			Ω(s.Name).Should(Equal("rerun"))
+			Ω(args).Should(Equal([]string{"please"}))
+		})
+
+		It("should not find unknown commands", func() {
+			_, args, ok := g.Lookup("try", []string{"harder"})
+			Ω(ok).Should(BeFalse())
+			Ω(args).Should(Equal([]string{"harder"}))
+		})
+	})
+
+	Describe("Alias", func() {
+		It("should add an alias for the command", func() {
+			Ω(g.Alias("shh", "mute")).Should(Succeed())
+			s, _, ok := g.Lookup("shh", nil)
+			Ω(ok).Should(BeTrue())
+			Ω(s.Name).Should(Equal("mute"))
+		})
+
+		It("should reject aliases for unknown commands", func() {
+			Ω(g.Alias("shh", "silence")).ShouldNot(Succeed())
+		})
+
+		It("should reject aliases used by another command", func() {
+			Ω(g.Alias("retry", "mute")).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("Usage", func() {
+		It("should describe all commands", func() {
+			Ω(g.Alias("shh", "mute")).Should(Succeed())
+			Ω(g.Usage()).Should(Equal("*rerun*, *retry*, *try again*\n\tRerun the job.\n*mute*, *shh* [duration]\n\tMute the job."))
+		})
+
+		It("should describe the accepted flags", func() {
+			s := Spec{Name: "mute", Args: "[duration]", Flags: []string{"--for=<duration>"}, Help: "Mute the job."}
+			Ω(s.Usage()).Should(Equal("*mute* [duration] [--for=<duration>]\n\tMute the job."))
+		})
+	})
+
+	Describe("CheckFlags", func() {
+		var s Spec
+
+		BeforeEach(func() {
+			s = Spec{Name: "mute", Flags: []string{"--for=<duration>", "--quiet"}}
+		})
+
+		It("should accept the declared flags", func() {
+			Ω(s.CheckFlags(nil)).Should(Succeed())
+			Ω(s.CheckFlags(map[string]string{"for": "1h", "quiet": "true"})).Should(Succeed())
+		})
+
+		It("should reject unknown flags", func() {
+			Ω(s.CheckFlags(map[string]string{"for": "1h", "until": "5pm", "all": "true"})).Should(MatchError("unknown flag --all, --until"))
+		})
+	})
+})
@@ -0,0 +1,141 @@
+// Package command implements the grammar of the commands accepted by the bot.
+//
+// A command line consists of a command name followed by arguments and flags,
+// separated by any amount of white space (including new lines). Arguments
+// can be quoted with double or single quotes in order to contain white space.
+// Flags have the form --name=value or --name (which is equivalent to
+// --name=true). A bare -- stops flag parsing, so that all following words are
+// treated as arguments.
+package command
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrEmpty is returned when parsing a line that contains no command.
+	ErrEmpty = errors.New("empty command")
+	// ErrUnterminatedQuote is returned when a quoted argument is not closed.
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// Line is a parsed command line.
+type Line struct {
+	Name  string
+	Args  []string
+	Flags map[string]string
+}
+
+// Parse parses text into a command line. The command name is always lower
+// cased, while arguments and flag values are preserved as-is.
+func Parse(text string) (*Line, error) {
+	words, err := split(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 || words[0].quoted || words[0].text == "" {
+		return nil, ErrEmpty
+	}
+
+	l := &Line{Name: strings.ToLower(words[0].text)}
+	flags := true
+	for _, w := range words[1:] {
+		if !flags || w.quoted || !strings.HasPrefix(w.text, "--") {
+			l.Args = append(l.Args, w.text)
+			continue
+		}
+		if w.text == "--" {
+			flags = false
+			continue
+		}
+		name, value := w.text[2:], "true"
+		if i := strings.IndexByte(name, '='); i >= 0 {
+			name, value = name[:i], name[i+1:]
+		}
+		if l.Flags == nil {
+			l.Flags = make(map[string]string)
+		}
+		l.Flags[name] = value
+	}
+	return l, nil
+}
+
+type word struct {
+	text   string
+	quoted bool // whether the word starts with a quote
+}
+
+// split splits text into words, honouring quotes. Double quoted strings
+// support backslash escapes, while single quoted ones are taken literally.
+// Typographic quotes, which many chat clients substitute automatically, are
+// treated as their plain counterparts.
+func split(text string) ([]word, error) {
+	var (
+		words   []word
+		cur     strings.Builder
+		inWord  bool
+		quoted  bool
+		quote   rune // current closing quote, or 0 if not in quotes
+		escaped bool
+	)
+	flush := func() {
+		if inWord {
+			words = append(words, word{text: cur.String(), quoted: quoted})
+		}
+		cur.Reset()
+		inWord, quoted = false, false
+	}
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			switch {
+			case r == quote:
+				quote = 0
+			case r == '\\' && quote == '"':
+				escaped = true
+			default:
+				cur.WriteRune(r)
+			}
+		case unicode.IsSpace(r):
+			flush()
+		case closingQuote(r) != 0:
+			quote = closingQuote(r)
+			quoted = quoted || !inWord
+			inWord = true
+		case r == '\\':
+			escaped, inWord = true, true
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, ErrUnterminatedQuote
+	}
+	if escaped {
+		// A trailing backslash has nothing to escape.
+		cur.WriteRune('\\')
+	}
+	flush()
+	return words, nil
+}
+
+func closingQuote(open rune) rune {
+	switch open {
+	case '"':
+		return '"'
+	case '\'':
+		return '\''
+	case '“':
+		return '”'
+	case '‘':
+		return '’'
+	}
+	return 0
+}
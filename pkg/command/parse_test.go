@@ -0,0 +1,54 @@
+package command_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/command"
+)
+
+var _ = Describe("Parse", func() {
+	DescribeTable("valid command lines",
+		func(text string, expected *Line) {
+			l, err := Parse(text)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(l).Should(Equal(expected))
+		},
+		Entry("name only", "pipelines", &Line{Name: "pipelines"}),
+		Entry("upper case name", "Rerun", &Line{Name: "rerun"}),
+		Entry("arguments", "pause my-pipeline", &Line{Name: "pause", Args: []string{"my-pipeline"}}),
+		Entry("repeated spaces", "  mute   30m ", &Line{Name: "mute", Args: []string{"30m"}}),
+		Entry("new lines", "pause\npipeline\r\n", &Line{Name: "pause", Args: []string{"pipeline"}}),
+		Entry("double quotes", `deploy "my app" now`, &Line{Name: "deploy", Args: []string{"my app", "now"}}),
+		Entry("escaped quotes", `say "a \"b\" c"`, &Line{Name: "say", Args: []string{`a "b" c`}}),
+		Entry("single quotes", `say 'a \ b'`, &Line{Name: "say", Args: []string{`a \ b`}}),
+		Entry("typographic quotes", "say “a b” ‘c d’", &Line{Name: "say", Args: []string{"a b", "c d"}}),
+		Entry("multi-line quotes", "say \"a\nb\"", &Line{Name: "say", Args: []string{"a\nb"}}),
+		Entry("empty quotes", `say ""`, &Line{Name: "say", Args: []string{""}}),
+		Entry("adjacent quotes", `say a"b c"d`, &Line{Name: "say", Args: []string{"ab cd"}}),
+		Entry("boolean flags", "pause --pipeline p", &Line{
+			Name:  "pause",
+			Args:  []string{"p"},
+			Flags: map[string]string{"pipeline": "true"},
+		}),
+		Entry("flags with values", "mute --for=1h --reason='on fire'", &Line{
+			Name:  "mute",
+			Flags: map[string]string{"for": "1h", "reason": "on fire"},
+		}),
+		Entry("quoted flags", `say "--not-a-flag"`, &Line{Name: "say", Args: []string{"--not-a-flag"}}),
+		Entry("end of flags", "say -- --not-a-flag", &Line{Name: "say", Args: []string{"--not-a-flag"}}),
+	)
+
+	DescribeTable("invalid command lines",
+		func(text string, expected error) {
+			_, err := Parse(text)
+			Ω(err).Should(Equal(expected))
+		},
+		Entry("empty", "", ErrEmpty),
+		Entry("white space", " \n\t", ErrEmpty),
+		Entry("quoted name", `"" rerun`, ErrEmpty),
+		Entry("unterminated double quote", `say "hello`, ErrUnterminatedQuote),
+		Entry("unterminated single quote", `say 'hello`, ErrUnterminatedQuote),
+	)
+})
@@ -0,0 +1,171 @@
+package discord
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+// Gateway opcodes, see
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+	opHeartbeatAck   = 11
+)
+
+// Gateway intents required for receiving messages.
+const (
+	intentGuildMessages  = 1 << 9
+	intentDirectMessages = 1 << 12
+	intentMessageContent = 1 << 15
+)
+
+type payload struct {
+	Op   int             `json:"op"`
+	Data json.RawMessage `json:"d,omitempty"`
+	Seq  *int            `json:"s,omitempty"`
+	Type string          `json:"t,omitempty"`
+}
+
+type hello struct {
+	HeartbeatInterval int `json:"heartbeat_interval"`
+}
+
+type identify struct {
+	Token      string            `json:"token"`
+	Intents    int               `json:"intents"`
+	Properties map[string]string `json:"properties"`
+}
+
+type ready struct {
+	User user `json:"user"`
+}
+
+// gateway is a single connection to the Discord gateway.
+type gateway struct {
+	conn   *websocket.Conn
+	logger lager.Logger
+
+	wmu sync.Mutex // guards writes to conn
+
+	smu sync.Mutex
+	seq *int
+}
+
+// listen connects to the gateway at url and calls dispatch for each received
+// event until the connection fails or the gateway asks for a reconnect.
+func listen(url, token string, logger lager.Logger, dispatch func(event string, data json.RawMessage)) error {
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?v=10&encoding=json", nil)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to gateway")
+	}
+	g := &gateway{conn: conn, logger: logger}
+	defer conn.Close()
+
+	var h hello
+	if err := g.expect(opHello, &h); err != nil {
+		return err
+	}
+	if h.HeartbeatInterval <= 0 {
+		return errors.Errorf("invalid heartbeat interval %d", h.HeartbeatInterval)
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go g.heartbeat(time.Duration(h.HeartbeatInterval)*time.Millisecond, stop)
+
+	err = g.send(opIdentify, identify{
+		Token:   token,
+		Intents: intentGuildMessages | intentDirectMessages | intentMessageContent,
+		Properties: map[string]string{
+			"os":      "linux",
+			"browser": "flyontime",
+			"device":  "flyontime",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		var p payload
+		if err := conn.ReadJSON(&p); err != nil {
+			return errors.Wrap(err, "error reading from gateway")
+		}
+		if p.Seq != nil {
+			g.smu.Lock()
+			g.seq = p.Seq
+			g.smu.Unlock()
+		}
+
+		switch p.Op {
+		case opDispatch:
+			dispatch(p.Type, p.Data)
+		case opHeartbeat:
+			if err := g.sendHeartbeat(); err != nil {
+				return err
+			}
+		case opReconnect:
+			return errors.New("gateway requested reconnect")
+		case opInvalidSession:
+			return errors.New("invalid gateway session")
+		case opHeartbeatAck:
+		default:
+			logger.Debug("skip-payload", lager.Data{"op": p.Op})
+		}
+	}
+}
+
+func (g *gateway) expect(op int, v interface{}) error {
+	var p payload
+	if err := g.conn.ReadJSON(&p); err != nil {
+		return errors.Wrap(err, "error reading from gateway")
+	}
+	if p.Op != op {
+		return errors.Errorf("unexpected gateway opcode %d, expected %d", p.Op, op)
+	}
+	return json.Unmarshal(p.Data, v)
+}
+
+func (g *gateway) heartbeat(interval time.Duration, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := g.sendHeartbeat(); err != nil {
+				g.logger.Error("heartbeat.fail", err)
+				// Unblock the reader, so that the connection is reestablished.
+				g.conn.Close()
+				return
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (g *gateway) sendHeartbeat() error {
+	g.smu.Lock()
+	seq := g.seq
+	g.smu.Unlock()
+	return g.send(opHeartbeat, seq)
+}
+
+func (g *gateway) send(op int, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	g.wmu.Lock()
+	defer g.wmu.Unlock()
+	return errors.Wrap(g.conn.WriteJSON(payload{Op: op, Data: data}), "error writing to gateway")
+}
@@ -0,0 +1,67 @@
+package discord_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	. "github.com/Bo0mer/flyontime/pkg/discord"
+)
+
+var _ = Describe("Gateway", func() {
+	var ds *discordServer
+	var notifier *Notifier
+
+	BeforeEach(func() {
+		ds = newDiscordServer()
+		notifier = &Notifier{
+			Token:     "t0k3n",
+			ChannelID: "C1",
+			API:       ds.URL,
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+		}
+	})
+
+	JustBeforeEach(func() {
+		notifier.Commands()
+	})
+
+	AfterEach(func() {
+		ds.Close()
+	})
+
+	It("should identify with the token and the intents for receiving messages", func() {
+		Eventually(ds.identified).Should(HaveLen(1))
+		identify := ds.identified()[0]
+		Ω(identify).Should(HaveKeyWithValue("token", "t0k3n"))
+		// GUILD_MESSAGES, DIRECT_MESSAGES and MESSAGE_CONTENT.
+		Ω(identify).Should(HaveKeyWithValue("intents", float64(1<<9|1<<12|1<<15)))
+	})
+
+	It("should send heartbeats with the last sequence number", func() {
+		Eventually(ds.sentHeartbeats).Should(ContainElement(float64(1)))
+		ds.dispatch("TYPING_START", map[string]interface{}{})
+		Eventually(ds.sentHeartbeats).Should(ContainElement(float64(2)))
+	})
+
+	Context("when the hello lacks the heartbeat interval", func() {
+		BeforeEach(func() {
+			ds.badHellos = 1
+		})
+
+		It("should connect and identify again", func() {
+			Eventually(ds.identified).Should(HaveLen(1))
+			Eventually(notifier.Health).Should(HaveKeyWithValue("connected", true))
+		})
+	})
+
+	Context("when the gateway requests a reconnect", func() {
+		It("should connect and identify again", func() {
+			Eventually(ds.identified).Should(HaveLen(1))
+			ds.events <- map[string]interface{}{"op": 7}
+			Eventually(ds.identified).Should(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,254 @@
+// Package discord implements a Discord bot that sends notifications to a
+// channel and accepts commands as replies to them, mentions or direct
+// messages.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/pkg/errors"
+)
+
+// DefaultAPI is the default URL of the Discord REST API.
+const DefaultAPI = "https://discord.com/api/v10"
+
+// maxDescription is the maximum length of embed descriptions.
+const maxDescription = 4096
+
+// maxOutput is the maximum length of job output included in notifications,
+// leaving room for the code block delimiters.
+const maxOutput = maxDescription - 16
+
+type Notifier struct {
+	Token     string // Bot token.
+	ChannelID string
+	API       string         // Defaults to DefaultAPI.
+	Retention time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
+	Logger    lager.Logger
+
+	initOnce sync.Once
+	login    chat.Init // obtains the bot user
+	conn     chat.Connection
+	client   *http.Client
+	self     user
+
+	router   chat.Router
+	messages chat.Registry // maps message id to notification
+}
+
+// setup applies the defaults.
+func (d *Notifier) setup() {
+	d.initOnce.Do(func() {
+		d.conn.Reconnect = d.Reconnect
+		d.messages.Name = "discord_messages"
+		d.messages.TTL = d.Retention
+		if d.API == "" {
+			d.API = DefaultAPI
+		}
+		if d.Logger == nil {
+			d.Logger = lager.NewLogger("")
+		}
+		d.client = &http.Client{Timeout: 30 * time.Second}
+	})
+}
+
+func (d *Notifier) init() error {
+	d.setup()
+	return d.login.Do(func() error {
+		if err := d.do(context.Background(), http.MethodGet, "/users/@me", nil, &d.self); err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		return nil
+	})
+}
+
+func (d *Notifier) Commands() <-chan *flyontime.Command {
+	d.setup()
+	logger := d.Logger.Session("commands")
+
+	go d.conn.Run(logger, func() error {
+		if err := d.init(); err != nil {
+			return err
+		}
+		var gw struct {
+			URL string `json:"url"`
+		}
+		if err := d.do(context.Background(), http.MethodGet, "/gateway/bot", nil, &gw); err != nil {
+			return errors.Wrap(err, "error obtaining gateway")
+		}
+		return listen(gw.URL, d.Token, logger.Session("gateway"), func(event string, data json.RawMessage) {
+			d.handleEvent(logger, event, data)
+		})
+	})
+
+	return d.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Discord.
+func (d *Notifier) Health() (map[string]interface{}, error) {
+	return d.conn.Health(d.init)
+}
+
+func (d *Notifier) handleEvent(logger lager.Logger, event string, data json.RawMessage) {
+	switch event {
+	case "READY":
+		var r ready
+		if err := json.Unmarshal(data, &r); err != nil {
+			logger.Error("parse-ready.fail", err)
+			return
+		}
+		d.self = r.User
+		d.conn.Up()
+		logger.Info("ready", lager.Data{"user": r.User.Username})
+	case "MESSAGE_CREATE":
+		var m message
+		if err := json.Unmarshal(data, &m); err != nil {
+			logger.Error("parse-message.fail", err)
+			return
+		}
+		if m.Author == nil || m.Author.ID == d.self.ID || m.Author.Bot {
+			// Do not reply to self or other bots.
+			return
+		}
+		d.handleMessage(logger, &m)
+	}
+}
+
+func (d *Notifier) handleMessage(logger lager.Logger, m *message) {
+	if m.GuildID == "" {
+		d.router.Route(logger.Session("handle-dm"), m.Content, nil, d.replyTo(m))
+		return
+	}
+	if m.ChannelID != d.ChannelID {
+		return
+	}
+	if ref := m.Reference; ref != nil {
+		if n, ok := d.messages.Get(ref.MessageID); ok {
+			d.router.Route(logger.Session("handle-reply"), m.Content, &n.Job, d.replyTo(m))
+			return
+		}
+	}
+	for _, mention := range []string{fmt.Sprintf("<@%s>", d.self.ID), fmt.Sprintf("<@!%s>", d.self.ID)} {
+		if strings.HasPrefix(m.Content, mention) {
+			d.router.Route(logger.Session("handle-mention"), m.Content[len(mention):], nil, d.replyTo(m))
+			return
+		}
+	}
+}
+
+// replyTo replies to m in the same channel.
+func (d *Notifier) replyTo(m *message) chat.Reply {
+	return func(reply string) error {
+		return d.post(context.Background(), m.ChannelID, &message{
+			Content:   reply,
+			Reference: &reference{MessageID: m.ID},
+		}, nil)
+	}
+}
+
+func (d *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	var posted message
+	err := d.post(ctx, d.ChannelID, &message{
+		Embeds: []embed{{
+			Author:      &author{Name: "Concourse", IconURL: "https://concourse.ci/favicon.ico"},
+			Title:       n.Title,
+			URL:         n.DashboardLink,
+			Description: chat.FormatCode(n.JobOutput, maxOutput),
+			Color:       chat.RGB(n.Severity),
+		}},
+	}, &posted)
+	if err != nil {
+		return err
+	}
+
+	if n.HasJob() {
+		d.messages.Put(posted.ID, n)
+	}
+	return nil
+}
+
+func (d *Notifier) post(ctx context.Context, channelID string, m *message, posted *message) error {
+	return d.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/messages", channelID), m, posted)
+}
+
+// do performs a request to the REST API, encoding in and decoding the
+// response into out, unless they are nil.
+func (d *Notifier) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, d.API+path, &body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bot "+d.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s %s: %s: %s", method, path, resp.Status, msg)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type user struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+type message struct {
+	ID        string     `json:"id,omitempty"`
+	ChannelID string     `json:"channel_id,omitempty"`
+	GuildID   string     `json:"guild_id,omitempty"`
+	Author    *user      `json:"author,omitempty"`
+	Content   string     `json:"content,omitempty"`
+	Embeds    []embed    `json:"embeds,omitempty"`
+	Reference *reference `json:"message_reference,omitempty"`
+}
+
+type reference struct {
+	MessageID string `json:"message_id"`
+}
+
+type embed struct {
+	Author      *author `json:"author,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	URL         string  `json:"url,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Color       int     `json:"color,omitempty"`
+}
+
+type author struct {
+	Name    string `json:"name"`
+	IconURL string `json:"icon_url,omitempty"`
+}
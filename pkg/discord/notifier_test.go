@@ -0,0 +1,322 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	. "github.com/Bo0mer/flyontime/pkg/discord"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// discordServer is a minimal stub of the Discord REST API and gateway.
+type discordServer struct {
+	*httptest.Server
+	events chan map[string]interface{} // sent to the connected gateway client
+
+	mu         sync.Mutex
+	posted     []map[string]interface{}
+	identifies []map[string]interface{}
+	heartbeats []interface{}
+	seq        int
+	badHellos  int // number of hellos to send without a heartbeat interval
+}
+
+func newDiscordServer() *discordServer {
+	ds := &discordServer{events: make(chan map[string]interface{}, 10)}
+	ds.Server = httptest.NewServer(http.HandlerFunc(ds.serve))
+	return ds
+}
+
+func (ds *discordServer) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	if r.URL.Path == "/gateway" {
+		ds.serveGateway(w, r)
+		return
+	}
+	Ω(r.Header.Get("Authorization")).Should(Equal("Bot t0k3n"))
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/users/@me":
+		fmt.Fprint(w, `{"id": "B1", "username": "flyontime", "bot": true}`)
+	case r.Method == "GET" && r.URL.Path == "/gateway/bot":
+		fmt.Fprintf(w, `{"url": "ws%s/gateway"}`, strings.TrimPrefix(ds.URL, "http"))
+	case r.Method == "POST" && r.URL.Path == "/channels/C1/messages":
+		var m map[string]interface{}
+		Ω(json.NewDecoder(r.Body).Decode(&m)).Should(Succeed())
+		ds.mu.Lock()
+		ds.posted = append(ds.posted, m)
+		id := len(ds.posted)
+		ds.mu.Unlock()
+		fmt.Fprintf(w, `{"id": "M%d", "channel_id": "C1"}`, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Unknown Channel"}`)
+	}
+}
+
+func (ds *discordServer) serveGateway(w http.ResponseWriter, r *http.Request) {
+	Ω(r.URL.Query().Get("v")).Should(Equal("10"))
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer conn.Close()
+
+	hello := map[string]interface{}{"heartbeat_interval": 10}
+	ds.mu.Lock()
+	if ds.badHellos > 0 {
+		ds.badHellos--
+		hello = map[string]interface{}{}
+	}
+	ds.mu.Unlock()
+	Ω(conn.WriteJSON(map[string]interface{}{"op": 10, "d": hello})).Should(Succeed())
+
+	var identify struct {
+		Op   int                    `json:"op"`
+		Data map[string]interface{} `json:"d"`
+	}
+	if err := conn.ReadJSON(&identify); err != nil {
+		return
+	}
+	Ω(identify.Op).Should(Equal(2))
+	ds.mu.Lock()
+	ds.identifies = append(ds.identifies, identify.Data)
+	ds.mu.Unlock()
+	ds.dispatch("READY", map[string]interface{}{
+		"user": map[string]interface{}{"id": "B1", "username": "flyontime", "bot": true},
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var p struct {
+				Op   int         `json:"op"`
+				Data interface{} `json:"d"`
+			}
+			if err := conn.ReadJSON(&p); err != nil {
+				return
+			}
+			if p.Op == 1 {
+				ds.mu.Lock()
+				ds.heartbeats = append(ds.heartbeats, p.Data)
+				ds.mu.Unlock()
+			}
+		}
+	}()
+
+	for {
+		select {
+		case p := <-ds.events:
+			if err := conn.WriteJSON(p); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// dispatch sends an event to the connected client, with the next sequence
+// number.
+func (ds *discordServer) dispatch(event string, data interface{}) {
+	ds.mu.Lock()
+	ds.seq++
+	seq := ds.seq
+	ds.mu.Unlock()
+	ds.events <- map[string]interface{}{"op": 0, "t": event, "s": seq, "d": data}
+}
+
+// message dispatches the creation of a message by the user authorID, which
+// replies to the message replyTo, if any.
+func (ds *discordServer) message(id, authorID, guildID, channelID, content, replyTo string) {
+	m := map[string]interface{}{
+		"id":         id,
+		"channel_id": channelID,
+		"author":     map[string]interface{}{"id": authorID, "username": authorID},
+		"content":    content,
+	}
+	if guildID != "" {
+		m["guild_id"] = guildID
+	}
+	if replyTo != "" {
+		m["message_reference"] = map[string]interface{}{"message_id": replyTo}
+	}
+	ds.dispatch("MESSAGE_CREATE", m)
+}
+
+func (ds *discordServer) postedMessages() []map[string]interface{} {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return append([]map[string]interface{}(nil), ds.posted...)
+}
+
+func (ds *discordServer) identified() []map[string]interface{} {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return append([]map[string]interface{}(nil), ds.identifies...)
+}
+
+func (ds *discordServer) sentHeartbeats() []interface{} {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return append([]interface{}(nil), ds.heartbeats...)
+}
+
+var _ = Describe("Notifier", func() {
+	var ds *discordServer
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		ds = newDiscordServer()
+		notifier = &Notifier{
+			Token:     "t0k3n",
+			ChannelID: "C1",
+			API:       ds.URL,
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "boom",
+		}
+	})
+
+	AfterEach(func() {
+		ds.Close()
+	})
+
+	Describe("Notify", func() {
+		It("should post an embed to the channel", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			posted := ds.postedMessages()
+			Ω(posted).Should(HaveLen(1))
+			Ω(posted[0]["embeds"]).Should(ConsistOf(map[string]interface{}{
+				"author":      map[string]interface{}{"name": "Concourse", "icon_url": "https://concourse.ci/favicon.ico"},
+				"title":       "Job j1 from p1 has failed.",
+				"url":         "http://concourse/builds/1",
+				"description": "```\nboom\n```",
+				"color":       float64(0xa30200),
+			}))
+		})
+
+		Context("when the channel does not exist", func() {
+			BeforeEach(func() {
+				notifier.ChannelID = "missing"
+			})
+
+			It("should return an error", func() {
+				err := notifier.Notify(context.Background(), notification)
+				Ω(err).Should(MatchError(ContainSubstring("404 Not Found")))
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("should return an error", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				Ω(notifier.Notify(ctx, notification)).Should(MatchError(ContainSubstring("context canceled")))
+				Ω(ds.postedMessages()).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Commands", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			commands = notifier.Commands()
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			Eventually(ds.identified).Should(HaveLen(1))
+		})
+
+		It("should be connected", func() {
+			Eventually(func() error {
+				_, err := notifier.Health()
+				return err
+			}).Should(Succeed())
+		})
+
+		Context("when a notification is replied to", func() {
+			BeforeEach(func() {
+				ds.message("R1", "U1", "G1", "C1", "mute 1h", "M1")
+			})
+
+			It("should send a command for its job and post the responses as replies", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("mute"))
+				Ω(c.Args).Should(Equal([]string{"1h"}))
+				Ω(c.Job).Should(Equal(&notification.Job))
+
+				c.Responses <- "Muted."
+				close(c.Responses)
+
+				Eventually(ds.postedMessages).Should(HaveLen(2))
+				reply := ds.postedMessages()[1]
+				Ω(reply).Should(HaveKeyWithValue("content", "Muted."))
+				Ω(reply).Should(HaveKeyWithValue("message_reference", map[string]interface{}{"message_id": "R1"}))
+			})
+		})
+
+		Context("when the bot is mentioned", func() {
+			BeforeEach(func() {
+				ds.message("R1", "U1", "G1", "C1", "<@B1> pause my-pipeline", "")
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when a direct message is received", func() {
+			BeforeEach(func() {
+				ds.message("R1", "U1", "", "D1", "pause my-pipeline", "")
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when the bot is mentioned in another channel", func() {
+			BeforeEach(func() {
+				ds.message("R1", "U1", "G1", "C2", "<@B1> pause my-pipeline", "")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the message is from the bot itself", func() {
+			BeforeEach(func() {
+				ds.message("R1", "B1", "", "D1", "pause my-pipeline", "")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+})
@@ -0,0 +1,365 @@
+// Package email implements a notifier that sends notifications as emails,
+// either one by one or batched into digests.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/lunixbochs/vtclean"
+)
+
+// DefaultTimeout is the default maximum time spent sending an email.
+const DefaultTimeout = 30 * time.Second
+
+// Notifier sends notifications via the SMTP server at Addr.
+//
+// Notifications are sent to the recipients configured for the pipeline of
+// the notified job in Pipelines, or to To if there are none.
+//
+// If DigestWindow is positive, notifications are not sent immediately.
+// Instead, all notifications for the same recipients within the window are
+// batched and sent as a single email once the window passes.
+type Notifier struct {
+	Addr         string // Address of the SMTP server, e.g. smtp.example.com:587.
+	Auth         smtp.Auth
+	From         string
+	To           []string
+	Pipelines    map[string][]string // Recipients per pipeline.
+	DigestWindow time.Duration
+	Timeout      time.Duration // Maximum time spent sending an email. Defaults to DefaultTimeout.
+	Logger       lager.Logger
+
+	initOnce sync.Once
+
+	mu      sync.Mutex
+	digests map[string]*digest // maps comma separated recipients to digest
+	sending sync.WaitGroup     // digests being sent
+}
+
+type digest struct {
+	to            []string
+	notifications []*flyontime.Notification
+	timer         *time.Timer
+}
+
+func (e *Notifier) init() {
+	e.initOnce.Do(func() {
+		if e.Logger == nil {
+			e.Logger = lager.NewLogger("")
+		}
+		if e.Timeout == 0 {
+			e.Timeout = DefaultTimeout
+		}
+		e.digests = make(map[string]*digest)
+	})
+}
+
+func (e *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	e.init()
+
+	to := e.recipientsFor(n.Job)
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients for pipeline %s", n.Job.Pipeline)
+	}
+	if e.DigestWindow <= 0 {
+		return e.send(to, []*flyontime.Notification{n})
+	}
+
+	key := strings.Join(to, ",")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d, ok := e.digests[key]
+	if !ok {
+		d = &digest{to: to}
+		d.timer = time.AfterFunc(e.DigestWindow, func() { e.flush(key) })
+		e.digests[key] = d
+	}
+	d.notifications = append(d.notifications, n)
+	return nil
+}
+
+// Close sends all pending digests immediately and waits for the ones which
+// are already being sent.
+func (e *Notifier) Close() error {
+	e.init()
+
+	e.mu.Lock()
+	var keys []string
+	for k, d := range e.digests {
+		d.timer.Stop()
+		keys = append(keys, k)
+	}
+	e.mu.Unlock()
+
+	// Digests whose timers have fired meanwhile are sent by whichever flush
+	// takes them first.
+	for _, k := range keys {
+		e.flush(k)
+	}
+	e.sending.Wait()
+	return nil
+}
+
+func (e *Notifier) flush(key string) {
+	e.mu.Lock()
+	d, ok := e.digests[key]
+	delete(e.digests, key)
+	if ok {
+		e.sending.Add(1)
+	}
+	e.mu.Unlock()
+	if !ok {
+		return
+	}
+	defer e.sending.Done()
+
+	logger := e.Logger.Session("send-digest", lager.Data{"notifications": len(d.notifications)})
+	if err := e.send(d.to, d.notifications); err != nil {
+		logger.Error("fail", err)
+		return
+	}
+	logger.Info("done")
+}
+
+func (e *Notifier) recipientsFor(j flyontime.Job) []string {
+	if to, ok := e.Pipelines[j.Pipeline]; ok && len(to) > 0 {
+		return to
+	}
+	return e.To
+}
+
+func (e *Notifier) send(to []string, ns []*flyontime.Notification) error {
+	msg, err := e.compose(to, ns)
+	if err != nil {
+		return err
+	}
+	return e.sendMail(to, msg)
+}
+
+// sendMail is like smtp.SendMail, except that it gives up once Timeout
+// elapses, rather than hanging along with the server.
+func (e *Notifier) sendMail(to []string, msg []byte) error {
+	conn, err := net.DialTimeout("tcp", e.Addr, e.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(e.Timeout)); err != nil {
+		return err
+	}
+	host, _, err := net.SplitHostPort(e.Addr)
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if e.Auth != nil {
+		if err := c.Auth(e.Auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(e.From); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (e *Notifier) compose(to []string, ns []*flyontime.Notification) ([]byte, error) {
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+
+	subject := ns[0].Title
+	if len(ns) > 1 {
+		subject = fmt.Sprintf("Concourse digest: %d notifications", len(ns))
+	}
+	headers := [][2]string{
+		{"From", e.From},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/mixed; boundary=%q", mixed.Boundary())},
+	}
+	var msg bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+	}
+	msg.WriteString("\r\n")
+
+	// The body, as both plain text and HTML.
+	var alternatives bytes.Buffer
+	alt := multipart.NewWriter(&alternatives)
+	if err := writePart(alt, "text/plain; charset=utf-8", []byte(plainText(ns))); err != nil {
+		return nil, err
+	}
+	htmlBody, err := htmlText(ns)
+	if err != nil {
+		return nil, err
+	}
+	if err := writePart(alt, "text/html; charset=utf-8", htmlBody); err != nil {
+		return nil, err
+	}
+	if err := alt.Close(); err != nil {
+		return nil, err
+	}
+	body, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", alt.Boundary())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := body.Write(alternatives.Bytes()); err != nil {
+		return nil, err
+	}
+
+	// The job output of each notification, as attachments.
+	for _, n := range ns {
+		if n.JobOutput == "" {
+			continue
+		}
+		att, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"text/plain; charset=utf-8"},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachmentName(n))},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(att, []byte(vtclean.Clean(n.JobOutput, false))); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	msg.Write(buf.Bytes())
+	return msg.Bytes(), nil
+}
+
+func writePart(w *multipart.Writer, contentType string, content []byte) error {
+	p, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	return writeBase64(p, content)
+}
+
+// writeBase64 writes content base64 encoded, with lines no longer than 76
+// characters as required by RFC 2045.
+func writeBase64(w io.Writer, content []byte) error {
+	enc := base64.StdEncoding.EncodeToString(content)
+	for len(enc) > 0 {
+		n := 76
+		if len(enc) < n {
+			n = len(enc)
+		}
+		if _, err := fmt.Fprintf(w, "%s\r\n", enc[:n]); err != nil {
+			return err
+		}
+		enc = enc[n:]
+	}
+	return nil
+}
+
+func attachmentName(n *flyontime.Notification) string {
+	return fmt.Sprintf("%s-%s.log", n.Job.Pipeline, n.Job.Name)
+}
+
+func plainText(ns []*flyontime.Notification) string {
+	var b strings.Builder
+	for i, n := range ns {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s\n", n.Title)
+		if n.DashboardLink != "" {
+			fmt.Fprintf(&b, "%s\n", n.DashboardLink)
+		}
+		if n.JobOutput != "" {
+			fmt.Fprintf(&b, "The job output is attached as %s.\n", attachmentName(n))
+		}
+	}
+	return b.String()
+}
+
+var htmlTemplate = template.Must(template.New("email").Funcs(template.FuncMap{
+	"color":      chat.HexColor,
+	"attachment": attachmentName,
+}).Parse(`<html><body>
+{{range .}}<p style="border-left: 4px solid {{color .Severity}}; padding-left: 8px;">
+{{if .DashboardLink}}<a href="{{.DashboardLink}}"><strong>{{.Title}}</strong></a>{{else}}<strong>{{.Title}}</strong>{{end}}<br>
+{{if .HasJob}}Team: {{.Job.Team}}, pipeline: {{.Job.Pipeline}}, job: {{.Job.Name}}{{end}}
+{{if .JobOutput}}<br><small>The job output is attached as {{attachment .}}.</small>{{end}}
+</p>
+{{end}}</body></html>
+`))
+
+func htmlText(ns []*flyontime.Notification) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, ns); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Recipients parses a list of recipients per pipeline in the form
+// "pipeline1=a@example.com;b@example.com,pipeline2=c@example.com".
+func Recipients(s string) (map[string][]string, error) {
+	r := make(map[string][]string)
+	if s == "" {
+		return r, nil
+	}
+	for _, p := range strings.Split(s, ",") {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("invalid pipeline recipients %q", p)
+		}
+		r[strings.TrimSpace(kv[0])] = strings.Split(kv[1], ";")
+	}
+	return r, nil
+}
@@ -0,0 +1,260 @@
+package email_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/email"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// envelope is an email received by the SMTP sink.
+type envelope struct {
+	From string
+	To   []string
+	Data string
+}
+
+// smtpSink is a minimal SMTP server that accepts all emails.
+type smtpSink struct {
+	l      net.Listener
+	emails chan envelope
+	delay  int64 // nanoseconds before greeting clients
+}
+
+func newSMTPSink() *smtpSink {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).ShouldNot(HaveOccurred())
+	s := &smtpSink{l: l, emails: make(chan envelope, 10)}
+	go s.serve()
+	return s
+}
+
+func (s *smtpSink) Addr() string { return s.l.Addr().String() }
+
+func (s *smtpSink) Close() { s.l.Close() }
+
+// slowDown makes the sink wait for d before greeting each client.
+func (s *smtpSink) slowDown(d time.Duration) { atomic.StoreInt64(&s.delay, int64(d)) }
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(textproto.NewConn(conn))
+	}
+}
+
+func (s *smtpSink) handle(c *textproto.Conn) {
+	defer c.Close()
+	var e envelope
+	time.Sleep(time.Duration(atomic.LoadInt64(&s.delay)))
+	c.PrintfLine("220 localhost ESMTP sink")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			c.PrintfLine("250 localhost")
+		case "MAIL":
+			e.From = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			c.PrintfLine("250 OK")
+		case "RCPT":
+			e.To = append(e.To, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			c.PrintfLine("250 OK")
+		case "DATA":
+			c.PrintfLine("354 Go ahead")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			e.Data = string(data)
+			s.emails <- e
+			e = envelope{}
+			c.PrintfLine("250 OK")
+		case "QUIT":
+			c.PrintfLine("221 Bye")
+			return
+		default:
+			c.PrintfLine("250 OK")
+		}
+	}
+}
+
+// part is a leaf part of a received email.
+type part struct {
+	ContentType string
+	Filename    string
+	Content     string
+}
+
+func parts(data string) (*mail.Message, []part) {
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	Ω(err).ShouldNot(HaveOccurred())
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	Ω(err).ShouldNot(HaveOccurred())
+	return msg, readParts(multipart.NewReader(msg.Body, params["boundary"]))
+}
+
+func readParts(r *multipart.Reader) []part {
+	var ps []part
+	for {
+		p, err := r.NextPart()
+		if err != nil {
+			return ps
+		}
+		mediaType, params, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		Ω(err).ShouldNot(HaveOccurred())
+		if strings.HasPrefix(mediaType, "multipart/") {
+			ps = append(ps, readParts(multipart.NewReader(p, params["boundary"]))...)
+			continue
+		}
+		content, err := ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+		Ω(err).ShouldNot(HaveOccurred())
+		ps = append(ps, part{ContentType: mediaType, Filename: p.FileName(), Content: string(content)})
+	}
+}
+
+var _ = Describe("Notifier", func() {
+	var sink *smtpSink
+	var notifier *Notifier
+	var failed, recovered *flyontime.Notification
+
+	BeforeEach(func() {
+		sink = newSMTPSink()
+		notifier = &Notifier{
+			Addr: sink.Addr(),
+			From: "flyontime@example.com",
+			To:   []string{"ci@example.com"},
+		}
+		failed = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "boom",
+		}
+		recovered = &flyontime.Notification{
+			Severity: flyontime.SeverityInfo,
+			Title:    "Job j2 from p1 has recovered after 1 failure(s).",
+			Job:      flyontime.Job{Name: "j2", Pipeline: "p1", Team: "t1"},
+		}
+	})
+
+	AfterEach(func() {
+		sink.Close()
+	})
+
+	It("should send an email for the notification", func() {
+		Ω(notifier.Notify(context.Background(), failed)).Should(Succeed())
+
+		var e envelope
+		Eventually(sink.emails).Should(Receive(&e))
+		Ω(e.From).Should(Equal("flyontime@example.com"))
+		Ω(e.To).Should(Equal([]string{"ci@example.com"}))
+
+		msg, ps := parts(e.Data)
+		Ω(msg.Header.Get("Subject")).Should(Equal("Job j1 from p1 has failed."))
+		Ω(ps).Should(HaveLen(3))
+		Ω(ps[0].ContentType).Should(Equal("text/plain"))
+		Ω(ps[0].Content).Should(ContainSubstring("http://concourse/builds/1"))
+		Ω(ps[1].ContentType).Should(Equal("text/html"))
+		Ω(ps[1].Content).Should(ContainSubstring(`<a href="http://concourse/builds/1">`))
+		Ω(ps[2].Filename).Should(Equal("p1-j1.log"))
+		Ω(ps[2].Content).Should(Equal("boom"))
+	})
+
+	Context("when the server does not respond in time", func() {
+		BeforeEach(func() {
+			sink.slowDown(time.Second)
+			notifier.Timeout = 50 * time.Millisecond
+		})
+
+		It("should give up", func() {
+			start := time.Now()
+			Ω(notifier.Notify(context.Background(), failed)).ShouldNot(Succeed())
+			Ω(time.Since(start)).Should(BeNumerically("<", time.Second))
+		})
+	})
+
+	Context("when there are recipients for the pipeline", func() {
+		BeforeEach(func() {
+			notifier.Pipelines = map[string][]string{"p1": {"p1@example.com", "lead@example.com"}}
+		})
+
+		It("should send the email to them", func() {
+			Ω(notifier.Notify(context.Background(), failed)).Should(Succeed())
+			var e envelope
+			Eventually(sink.emails).Should(Receive(&e))
+			Ω(e.To).Should(Equal([]string{"p1@example.com", "lead@example.com"}))
+		})
+	})
+
+	Context("when digest mode is enabled", func() {
+		BeforeEach(func() {
+			notifier.DigestWindow = 50 * time.Millisecond
+		})
+
+		It("should batch the notifications in a single email", func() {
+			Ω(notifier.Notify(context.Background(), failed)).Should(Succeed())
+			Ω(notifier.Notify(context.Background(), recovered)).Should(Succeed())
+			Consistently(sink.emails, 20*time.Millisecond).ShouldNot(Receive())
+
+			var e envelope
+			Eventually(sink.emails).Should(Receive(&e))
+			msg, ps := parts(e.Data)
+			Ω(msg.Header.Get("Subject")).Should(Equal("Concourse digest: 2 notifications"))
+			Ω(ps[0].Content).Should(ContainSubstring("Job j1 from p1 has failed."))
+			Ω(ps[0].Content).Should(ContainSubstring("Job j2 from p1 has recovered"))
+			Consistently(sink.emails).ShouldNot(Receive())
+		})
+
+		It("should send pending digests on close", func() {
+			Ω(notifier.Notify(context.Background(), failed)).Should(Succeed())
+			Ω(notifier.Close()).Should(Succeed())
+			Ω(sink.emails).Should(Receive())
+		})
+
+		It("should wait for digests being sent on close", func() {
+			sink.slowDown(200 * time.Millisecond)
+			Ω(notifier.Notify(context.Background(), failed)).Should(Succeed())
+			// Let the window pass, so that the digest is being sent.
+			time.Sleep(100 * time.Millisecond)
+			Ω(notifier.Close()).Should(Succeed())
+			Ω(sink.emails).Should(Receive())
+		})
+	})
+
+	Describe("Recipients", func() {
+		It("should parse recipients per pipeline", func() {
+			r, err := Recipients("p1=a@example.com;b@example.com,p2=c@example.com")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r).Should(Equal(map[string][]string{
+				"p1": {"a@example.com", "b@example.com"},
+				"p2": {"c@example.com"},
+			}))
+		})
+
+		It("should reject malformed lists", func() {
+			_, err := Recipients("p1")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
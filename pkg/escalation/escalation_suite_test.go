@@ -0,0 +1,13 @@
+package escalation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEscalation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Escalation Suite")
+}
@@ -0,0 +1,84 @@
+package escalation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// DefaultOpsgenieURL is the default URL of the Opsgenie API.
+const DefaultOpsgenieURL = "https://api.opsgenie.com"
+
+// maxOpsgenieMessage is the maximum length of Opsgenie alert messages.
+const maxOpsgenieMessage = 130
+
+// Opsgenie creates and closes alerts via the Opsgenie Alert API.
+type Opsgenie struct {
+	APIKey string
+	URL    string // Defaults to DefaultOpsgenieURL.
+	Client *http.Client
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Source      string            `json:"source"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Priority    string            `json:"priority"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+func (og *Opsgenie) Trigger(ctx context.Context, i *flyontime.Incident) error {
+	description := i.Summary
+	if i.DashboardLink != "" {
+		description = fmt.Sprintf("%s\n%s", description, i.DashboardLink)
+	}
+	return og.post(ctx, "/v2/alerts", &opsgenieAlert{
+		Message:     truncate(i.Summary, maxOpsgenieMessage),
+		Alias:       i.DedupKey,
+		Description: description,
+		Source:      "flyontime",
+		Tags:        []string{"concourse", i.Job.Pipeline},
+		Details: map[string]string{
+			"team":     i.Job.Team,
+			"pipeline": i.Job.Pipeline,
+			"job":      i.Job.Name,
+		},
+		Priority: "P1",
+	})
+}
+
+func (og *Opsgenie) Resolve(ctx context.Context, i *flyontime.Incident) error {
+	path := fmt.Sprintf("/v2/alerts/%s/close?identifierType=alias", url.PathEscape(i.DedupKey))
+	return og.post(ctx, path, &opsgenieClose{
+		Source: "flyontime",
+		Note:   i.Summary,
+	})
+}
+
+func (og *Opsgenie) post(ctx context.Context, path string, v interface{}) error {
+	base := og.URL
+	if base == "" {
+		base = DefaultOpsgenieURL
+	}
+	header := http.Header{"Authorization": {"GenieKey " + og.APIKey}}
+	return post(ctx, og.Client, strings.TrimSuffix(base, "/")+path, header, v)
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,107 @@
+package escalation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/escalation"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+var _ = Describe("Opsgenie", func() {
+	var server *httptest.Server
+	var requests chan request
+	var status int32
+
+	var og *Opsgenie
+	var incident *flyontime.Incident
+
+	BeforeEach(func() {
+		requests = make(chan request, 10)
+		atomic.StoreInt32(&status, http.StatusAccepted)
+		server = newAPI(requests, &status)
+		og = &Opsgenie{
+			APIKey: "k3y",
+			URL:    server.URL + "/",
+		}
+		incident = newIncident()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Trigger", func() {
+		It("should create an alert aliased with the dedup key of the incident", func() {
+			Ω(og.Trigger(context.Background(), incident)).Should(Succeed())
+
+			var r request
+			Eventually(requests).Should(Receive(&r))
+			Ω(r.Method).Should(Equal("POST"))
+			Ω(r.URL.Path).Should(Equal("/v2/alerts"))
+			Ω(r.Header.Get("Authorization")).Should(Equal("GenieKey k3y"))
+			Ω(r.Header.Get("Content-Type")).Should(Equal("application/json"))
+			Ω(r.Body).Should(Equal(map[string]interface{}{
+				"message":     "Job j1 from prod-deploy has failed 3 times in a row.",
+				"alias":       "flyontime/t1/prod-deploy/j1",
+				"description": "Job j1 from prod-deploy has failed 3 times in a row.\nhttp://concourse/builds/1",
+				"source":      "flyontime",
+				"tags":        []interface{}{"concourse", "prod-deploy"},
+				"details": map[string]interface{}{
+					"team":     "t1",
+					"pipeline": "prod-deploy",
+					"job":      "j1",
+				},
+				"priority": "P1",
+			}))
+		})
+
+		Context("when the summary is too long for a message", func() {
+			BeforeEach(func() {
+				incident.Summary = strings.Repeat("x", 200)
+			})
+
+			It("should truncate the message, but not the description", func() {
+				Ω(og.Trigger(context.Background(), incident)).Should(Succeed())
+
+				var r request
+				Eventually(requests).Should(Receive(&r))
+				Ω(r.Body).Should(HaveKeyWithValue("message", strings.Repeat("x", 129)+"…"))
+				Ω(r.Body["description"]).Should(HavePrefix(strings.Repeat("x", 200)))
+			})
+		})
+
+		Context("when Opsgenie rejects the alert", func() {
+			BeforeEach(func() {
+				atomic.StoreInt32(&status, http.StatusUnauthorized)
+			})
+
+			It("should return an error", func() {
+				err := og.Trigger(context.Background(), incident)
+				Ω(err).Should(MatchError(ContainSubstring("401 Unauthorized")))
+			})
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should close the alert by the dedup key of the incident", func() {
+			Ω(og.Resolve(context.Background(), incident)).Should(Succeed())
+
+			var r request
+			Eventually(requests).Should(Receive(&r))
+			Ω(r.URL.EscapedPath()).Should(Equal("/v2/alerts/flyontime%2Ft1%2Fprod-deploy%2Fj1/close"))
+			Ω(r.URL.Query().Get("identifierType")).Should(Equal("alias"))
+			Ω(r.Header.Get("Authorization")).Should(Equal("GenieKey k3y"))
+			Ω(r.Body).Should(Equal(map[string]interface{}{
+				"source": "flyontime",
+				"note":   "Job j1 from prod-deploy has failed 3 times in a row.",
+			}))
+		})
+	})
+})
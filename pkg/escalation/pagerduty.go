@@ -0,0 +1,125 @@
+// Package escalation implements escalators that open incidents in PagerDuty
+// and Opsgenie.
+package escalation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/pkg/errors"
+)
+
+// DefaultPagerDutyURL is the default URL of the PagerDuty Events API v2.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// defaultClient is used when no client is configured. The monitor waits for
+// incidents to be triggered, hence requests must not hang forever.
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// PagerDuty triggers and resolves incidents via the PagerDuty Events API v2.
+type PagerDuty struct {
+	RoutingKey string // Integration key of the service.
+	URL        string // Defaults to DefaultPagerDutyURL.
+	Client     *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+func (pd *PagerDuty) Trigger(ctx context.Context, i *flyontime.Incident) error {
+	e := &pagerDutyEvent{
+		RoutingKey:  pd.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    i.DedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:   i.Summary,
+			Source:    "flyontime",
+			Severity:  "critical",
+			Component: i.Job.Name,
+			Group:     i.Job.Pipeline,
+			CustomDetails: map[string]string{
+				"team":     i.Job.Team,
+				"pipeline": i.Job.Pipeline,
+				"job":      i.Job.Name,
+			},
+		},
+	}
+	if !i.FailingSince.IsZero() {
+		e.Payload.Timestamp = i.FailingSince.Format(time.RFC3339)
+	}
+	if i.DashboardLink != "" {
+		e.Links = []pagerDutyLink{{Href: i.DashboardLink, Text: "Concourse build"}}
+	}
+	return pd.send(ctx, e)
+}
+
+func (pd *PagerDuty) Resolve(ctx context.Context, i *flyontime.Incident) error {
+	return pd.send(ctx, &pagerDutyEvent{
+		RoutingKey:  pd.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    i.DedupKey,
+	})
+}
+
+func (pd *PagerDuty) send(ctx context.Context, e *pagerDutyEvent) error {
+	url := pd.URL
+	if url == "" {
+		url = DefaultPagerDutyURL
+	}
+	return post(ctx, pd.Client, url, nil, e)
+}
+
+// post posts v as JSON to url.
+func post(ctx context.Context, client *http.Client, url string, header http.Header, v interface{}) error {
+	if client == nil {
+		client = defaultClient
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	msg, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response status: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
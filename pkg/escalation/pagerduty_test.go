@@ -0,0 +1,132 @@
+package escalation_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/escalation"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// request is a request received by a stub API.
+type request struct {
+	*http.Request
+	Body map[string]interface{}
+}
+
+// newAPI returns a stub API which records the received requests and
+// responds with status.
+func newAPI(requests chan<- request, status *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		data, err := ioutil.ReadAll(r.Body)
+		Ω(err).ShouldNot(HaveOccurred())
+		var body map[string]interface{}
+		Ω(json.Unmarshal(data, &body)).Should(Succeed())
+		requests <- request{Request: r, Body: body}
+		w.WriteHeader(int(atomic.LoadInt32(status)))
+		w.Write([]byte(`{"message": "boom"}`))
+	}))
+}
+
+// newIncident returns an incident about a failing job.
+func newIncident() *flyontime.Incident {
+	return &flyontime.Incident{
+		DedupKey:      "flyontime/t1/prod-deploy/j1",
+		Summary:       "Job j1 from prod-deploy has failed 3 times in a row.",
+		Job:           flyontime.Job{Name: "j1", Pipeline: "prod-deploy", Team: "t1"},
+		DashboardLink: "http://concourse/builds/1",
+		Failures:      3,
+		FailingSince:  time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC),
+	}
+}
+
+var _ = Describe("PagerDuty", func() {
+	var server *httptest.Server
+	var requests chan request
+	var status int32
+
+	var pd *PagerDuty
+	var incident *flyontime.Incident
+
+	BeforeEach(func() {
+		requests = make(chan request, 10)
+		atomic.StoreInt32(&status, http.StatusAccepted)
+		server = newAPI(requests, &status)
+		pd = &PagerDuty{
+			RoutingKey: "r0ut1ng",
+			URL:        server.URL + "/v2/enqueue",
+		}
+		incident = newIncident()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Trigger", func() {
+		It("should send a trigger event with the dedup key of the incident", func() {
+			Ω(pd.Trigger(context.Background(), incident)).Should(Succeed())
+
+			var r request
+			Eventually(requests).Should(Receive(&r))
+			Ω(r.Method).Should(Equal("POST"))
+			Ω(r.URL.Path).Should(Equal("/v2/enqueue"))
+			Ω(r.Header.Get("Content-Type")).Should(Equal("application/json"))
+			Ω(r.Body).Should(HaveKeyWithValue("routing_key", "r0ut1ng"))
+			Ω(r.Body).Should(HaveKeyWithValue("event_action", "trigger"))
+			Ω(r.Body).Should(HaveKeyWithValue("dedup_key", "flyontime/t1/prod-deploy/j1"))
+			Ω(r.Body).Should(HaveKeyWithValue("payload", map[string]interface{}{
+				"summary":   "Job j1 from prod-deploy has failed 3 times in a row.",
+				"source":    "flyontime",
+				"severity":  "critical",
+				"timestamp": "2026-10-19T08:30:00Z",
+				"component": "j1",
+				"group":     "prod-deploy",
+				"custom_details": map[string]interface{}{
+					"team":     "t1",
+					"pipeline": "prod-deploy",
+					"job":      "j1",
+				},
+			}))
+			Ω(r.Body).Should(HaveKeyWithValue("links", ConsistOf(map[string]interface{}{
+				"href": "http://concourse/builds/1",
+				"text": "Concourse build",
+			})))
+		})
+
+		Context("when PagerDuty rejects the event", func() {
+			BeforeEach(func() {
+				atomic.StoreInt32(&status, http.StatusBadRequest)
+			})
+
+			It("should return an error", func() {
+				err := pd.Trigger(context.Background(), incident)
+				Ω(err).Should(MatchError(ContainSubstring("400 Bad Request")))
+			})
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should send a resolve event with the dedup key of the incident", func() {
+			Ω(pd.Resolve(context.Background(), incident)).Should(Succeed())
+
+			var r request
+			Eventually(requests).Should(Receive(&r))
+			Ω(r.URL.Path).Should(Equal("/v2/enqueue"))
+			Ω(r.Body).Should(Equal(map[string]interface{}{
+				"routing_key":  "r0ut1ng",
+				"event_action": "resolve",
+				"dedup_key":    "flyontime/t1/prod-deploy/j1",
+			}))
+		})
+	})
+})
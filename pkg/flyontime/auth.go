@@ -0,0 +1,178 @@
+package flyontime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Modes of authenticating to Concourse.
+const (
+	// AuthBasic exchanges the username and password of a team for a token
+	// of the team, which is supported by Concourse before 4.0.
+	AuthBasic = "basic"
+	// AuthToken uses a static bearer token, e.g. one copied from ~/.flyrc.
+	AuthToken = "token"
+	// AuthPassword obtains tokens from /sky/token with the username and
+	// password of a local user, which is supported by Concourse 4.0 and
+	// later.
+	AuthPassword = "password"
+)
+
+// DefaultRefreshBefore is for how long before they expire tokens are
+// refreshed by default.
+const DefaultRefreshBefore = time.Minute
+
+// ConcourseAuth configures how to authenticate to Concourse.
+type ConcourseAuth struct {
+	// Mode is one of AuthBasic, AuthToken or AuthPassword. Defaults to
+	// AuthBasic.
+	Mode     string
+	Username string
+	Password string
+	// Token is the bearer token used with AuthToken, unless TokenFile is
+	// set, in which case the token is read from the file whenever it
+	// changes.
+	Token     string
+	TokenFile string
+	// FlyTarget, if set, is the target of fly whose token is used with
+	// AuthToken, read from the flyrc file at Flyrc whenever it changes.
+	FlyTarget string
+	Flyrc     string
+	// RefreshBefore is for how long before they expire tokens obtained
+	// with AuthPassword are refreshed. Defaults to DefaultRefreshBefore.
+	RefreshBefore time.Duration
+}
+
+// tokenSource returns the source of tokens for authenticating to the team of
+// the Concourse at url.
+func (a ConcourseAuth) tokenSource(url, team string) (oauth2.TokenSource, error) {
+	switch a.Mode {
+	case "", AuthBasic:
+		c := newClient(url, authenticatedClient(a.Username, a.Password))
+		return &teamTokenSource{c.Team(team)}, nil
+	case AuthToken:
+		if a.FlyTarget != "" {
+			flyrc := a.Flyrc
+			if flyrc == "" {
+				flyrc = DefaultFlyrc()
+			}
+			return &fileTokenSource{path: flyrc, parse: flyTargetToken(a.FlyTarget)}, nil
+		}
+		if a.TokenFile != "" {
+			return &fileTokenSource{path: a.TokenFile, parse: parseToken}, nil
+		}
+		if a.Token == "" {
+			return nil, errors.New("no token provided")
+		}
+		return oauth2.StaticTokenSource(bearerToken(a.Token)), nil
+	case AuthPassword:
+		if a.Username == "" {
+			return nil, errors.New("no username provided")
+		}
+		refreshBefore := a.RefreshBefore
+		if refreshBefore == 0 {
+			refreshBefore = DefaultRefreshBefore
+		}
+		return oauth2.ReuseTokenSource(nil, &passwordTokenSource{
+			config: &oauth2.Config{
+				// The client of fly, which is allowed to use the
+				// password grant.
+				ClientID:     "fly",
+				ClientSecret: "Zmx5",
+				Endpoint:     oauth2.Endpoint{TokenURL: strings.TrimSuffix(url, "/") + "/sky/token"},
+				Scopes:       []string{"openid", "profile", "email", "federated:id", "groups"},
+			},
+			username:      a.Username,
+			password:      a.Password,
+			refreshBefore: refreshBefore,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown auth mode %q", a.Mode)
+}
+
+// passwordTokenSource obtains tokens with the password grant. The tokens
+// expire refreshBefore earlier than they actually do, so that they are
+// refreshed in advance when reused.
+type passwordTokenSource struct {
+	config        *oauth2.Config
+	username      string
+	password      string
+	refreshBefore time.Duration
+}
+
+func (ts *passwordTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: baseTransport(),
+		Timeout:   30 * time.Second,
+	})
+	token, err := ts.config.PasswordCredentialsToken(ctx, ts.username, ts.password)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Expiry.IsZero() {
+		token.Expiry = token.Expiry.Add(-ts.refreshBefore)
+	}
+	return token, nil
+}
+
+// fileTokenSource reads tokens from a file, which is read again whenever it
+// is modified.
+type fileTokenSource struct {
+	path  string
+	parse func([]byte) (*oauth2.Token, error)
+
+	mu      sync.Mutex
+	modTime time.Time
+	token   *oauth2.Token
+}
+
+func (ts *fileTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	fi, err := os.Stat(ts.path)
+	if err != nil {
+		return nil, err
+	}
+	if ts.token != nil && fi.ModTime().Equal(ts.modTime) {
+		return ts.token, nil
+	}
+	data, err := ioutil.ReadFile(ts.path)
+	if err != nil {
+		return nil, err
+	}
+	token, err := ts.parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", ts.path, err)
+	}
+	ts.token, ts.modTime = token, fi.ModTime()
+	return token, nil
+}
+
+// parseToken parses a bearer token, optionally prefixed with its type as in
+// an Authorization header.
+func parseToken(data []byte) (*oauth2.Token, error) {
+	s := strings.TrimSpace(string(data))
+	if i := strings.IndexByte(s, ' '); i >= 0 && strings.EqualFold(s[:i], "bearer") {
+		s = strings.TrimSpace(s[i+1:])
+	}
+	if s == "" {
+		return nil, errors.New("no token found")
+	}
+	return bearerToken(s), nil
+}
+
+func bearerToken(value string) *oauth2.Token {
+	return &oauth2.Token{
+		TokenType:   "Bearer",
+		AccessToken: value,
+	}
+}
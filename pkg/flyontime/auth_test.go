@@ -0,0 +1,189 @@
+package flyontime_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+var _ = Describe("ConcourseAuth", func() {
+	var server *httptest.Server
+	var auth ConcourseAuth
+
+	var mu sync.Mutex
+	var authorizations []string
+	var tokenRequests []*http.Request
+	var expiresIn int
+
+	BeforeEach(func() {
+		authorizations, tokenRequests = nil, nil
+		expiresIn = 3600
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if r.URL.Path == "/sky/token" {
+				r.ParseForm()
+				tokenRequests = append(tokenRequests, r)
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"access_token": "sky-token",
+					"token_type":   "bearer",
+					"expires_in":   expiresIn,
+				})
+				return
+			}
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+			w.Write([]byte("[]"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	listPipelines := func() error {
+		pilot, err := NewAutoPilot(server.URL, "main", auth, lager.NewLogger("test"))
+		if err != nil {
+			return err
+		}
+		_, err = pilot.ListPipelines()
+		return err
+	}
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), authorizations...)
+	}
+
+	Context("when using a static token", func() {
+		BeforeEach(func() {
+			auth = ConcourseAuth{Mode: AuthToken, Token: "static-token"}
+		})
+
+		It("should send it as a bearer token", func() {
+			Ω(listPipelines()).Should(Succeed())
+			Ω(received()).Should(Equal([]string{"Bearer static-token"}))
+		})
+
+		Context("but no token is provided", func() {
+			BeforeEach(func() {
+				auth.Token = ""
+			})
+
+			It("should fail", func() {
+				Ω(listPipelines()).Should(MatchError("no token provided"))
+			})
+		})
+
+		Context("read from a file", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "flyontime")
+				Ω(err).ShouldNot(HaveOccurred())
+				auth.TokenFile = filepath.Join(dir, "token")
+				Ω(ioutil.WriteFile(auth.TokenFile, []byte("Bearer file-token\n"), 0600)).Should(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should read the token again once the file changes", func() {
+				pilot, err := NewAutoPilot(server.URL, "main", auth, lager.NewLogger("test"))
+				Ω(err).ShouldNot(HaveOccurred())
+				_, err = pilot.ListPipelines()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(ioutil.WriteFile(auth.TokenFile, []byte("new-token"), 0600)).Should(Succeed())
+				later := time.Now().Add(time.Second)
+				Ω(os.Chtimes(auth.TokenFile, later, later)).Should(Succeed())
+				_, err = pilot.ListPipelines()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(received()).Should(Equal([]string{"Bearer file-token", "Bearer new-token"}))
+			})
+		})
+	})
+
+	Context("when using the password of a local user", func() {
+		BeforeEach(func() {
+			auth = ConcourseAuth{Mode: AuthPassword, Username: "bob", Password: "s3cr3t"}
+		})
+
+		It("should obtain a token with the password grant of fly", func() {
+			Ω(listPipelines()).Should(Succeed())
+			Ω(received()).Should(Equal([]string{"Bearer sky-token"}))
+
+			Ω(tokenRequests).Should(HaveLen(1))
+			r := tokenRequests[0]
+			Ω(r.PostForm.Get("grant_type")).Should(Equal("password"))
+			Ω(r.PostForm.Get("username")).Should(Equal("bob"))
+			Ω(r.PostForm.Get("password")).Should(Equal("s3cr3t"))
+			Ω(r.PostForm.Get("scope")).Should(ContainSubstring("openid"))
+			id, secret, ok := r.BasicAuth()
+			Ω(ok).Should(BeTrue())
+			Ω(id).Should(Equal("fly"))
+			Ω(secret).Should(Equal("Zmx5"))
+		})
+
+		It("should reuse the token until it is about to expire", func() {
+			pilot, err := NewAutoPilot(server.URL, "main", auth, lager.NewLogger("test"))
+			Ω(err).ShouldNot(HaveOccurred())
+			for i := 0; i < 3; i++ {
+				_, err = pilot.ListPipelines()
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+			Ω(tokenRequests).Should(HaveLen(1))
+		})
+
+		Context("and the token expires soon", func() {
+			BeforeEach(func() {
+				expiresIn = 30
+			})
+
+			It("should refresh the token before it expires", func() {
+				pilot, err := NewAutoPilot(server.URL, "main", auth, lager.NewLogger("test"))
+				Ω(err).ShouldNot(HaveOccurred())
+				for i := 0; i < 2; i++ {
+					_, err = pilot.ListPipelines()
+					Ω(err).ShouldNot(HaveOccurred())
+				}
+				Ω(tokenRequests).Should(HaveLen(2))
+			})
+		})
+
+		Context("but no username is provided", func() {
+			BeforeEach(func() {
+				auth.Username = ""
+			})
+
+			It("should fail", func() {
+				Ω(listPipelines()).Should(MatchError("no username provided"))
+			})
+		})
+	})
+
+	Context("when the mode is unknown", func() {
+		BeforeEach(func() {
+			auth = ConcourseAuth{Mode: "kerberos"}
+		})
+
+		It("should fail", func() {
+			Ω(listPipelines()).Should(MatchError(`unknown auth mode "kerberos"`))
+		})
+	})
+})
@@ -1,12 +1,64 @@
 package flyontime
 
+import "github.com/Bo0mer/flyontime/pkg/command"
+
 type Command struct {
 	Name      string
 	Args      []string
+	Flags     map[string]string
 	Job       *Job // Job which the command is targeted for (if any).
 	Responses chan<- string
 }
 
+// ParseCommand parses text as a command line. See package command for
+// details about the supported syntax.
+func ParseCommand(text string) (*Command, error) {
+	l, err := command.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Command{Name: l.Name, Args: l.Args, Flags: l.Flags}, nil
+}
+
+// CommandScope determines where a command could be used.
+type CommandScope int
+
+const (
+	// GlobalScope commands are sent directly to the bot, e.g. as direct
+	// messages or mentions.
+	GlobalScope CommandScope = 1 << iota
+	// ReplyScope commands are sent as replies to notifications and are
+	// targeted for the job that the notification is about.
+	ReplyScope
+)
+
+func (s CommandScope) scopes() []CommandScope {
+	var scopes []CommandScope
+	for _, sc := range []CommandScope{GlobalScope, ReplyScope} {
+		if s&sc != 0 {
+			scopes = append(scopes, sc)
+		}
+	}
+	return scopes
+}
+
+// CommandFunc executes a command. It must send its responses to
+// c.Responses and close it once done, which could happen asynchronously.
+// It returns an error if the command failed, which is reported in metrics;
+// the error should be explained in the responses as well.
+type CommandFunc func(p Pilot, c *Command) error
+
+// CommandHandler describes a command that could be registered with Monitor.
+type CommandHandler struct {
+	Name    string
+	Aliases []string
+	Scope   CommandScope
+	Args    string   // Synopsis of the arguments, e.g. "<pipeline>".
+	Flags   []string // Synopses of the accepted flags, e.g. "--for=<duration>".
+	Help    string
+	Handle  CommandFunc
+}
+
 //go:generate counterfeiter . Commander
 
 type Commander interface {
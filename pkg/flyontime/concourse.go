@@ -3,11 +3,16 @@ package flyontime
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
 	"github.com/concourse/atc"
 	"github.com/concourse/go-concourse/concourse"
 	"golang.org/x/oauth2"
@@ -18,10 +23,34 @@ type AutoPilot struct {
 	concourse.Team
 	Logger       lager.Logger
 	PollInterval time.Duration
+	// StaleAfter is the time after the last successful poll for finished
+	// builds, or after polling has started if none has succeeded yet, after
+	// which polling is reported as failing by Health.
+	StaleAfter time.Duration
+	// Backoff is the policy of delays between retries of failed polls,
+	// which are never shorter than PollInterval.
+	Backoff backoff.Policy
+	// StreamEvents makes FinishedBuilds follow the events of running builds,
+	// so that they are sent as soon as they finish, rather than once they
+	// are polled again. Polling is still used for discovering new builds
+	// and for builds whose events could not be followed.
+	StreamEvents bool
+	// OnEvent, if set, is called with each event of the builds followed
+	// when StreamEvents is set, e.g. SendStarted in order to notify when
+	// builds start. It is called concurrently for different builds.
+	OnEvent func(b atc.Build, e atc.Event)
+
+	mu       sync.Mutex
+	started  time.Time // when polling has started
+	lastPoll time.Time // of the last successful poll
+	pollErr  error     // of the last failed poll
+	failures int       // number of consecutive failed polls
 }
 
-func NewAutoPilot(concourseURL, team, username, password string, logger lager.Logger) (*AutoPilot, error) {
-	c, err := newConcourseClient(concourseURL, team, username, password)
+// NewAutoPilot returns a pilot of the team of the Concourse at concourseURL,
+// authenticated according to auth.
+func NewAutoPilot(concourseURL, team string, auth ConcourseAuth, logger lager.Logger) (*AutoPilot, error) {
+	c, err := newConcourseClient(concourseURL, team, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -30,66 +59,210 @@ func NewAutoPilot(concourseURL, team, username, password string, logger lager.Lo
 		Client:       c,
 		Team:         c.Team(team),
 		PollInterval: 4 * time.Second,
+		StaleAfter:   time.Minute,
 		Logger:       logger,
 	}, nil
 }
 
+// FinishedBuilds sends the builds of the team which finish from now on, each
+// exactly once, until ctx is done. New builds are found by paging through the
+// builds newer than the newest one seen, while the builds seen running are
+// retrieved one by one until they finish.
 func (p *AutoPilot) FinishedBuilds(ctx context.Context) <-chan atc.Build {
 	c := make(chan atc.Build)
+	p.mu.Lock()
+	p.started = time.Now()
+	p.mu.Unlock()
 	go func() {
 		logger := p.Logger.Session("finished-builds")
-		t := time.NewTicker(p.PollInterval)
-		defer func() {
-			t.Stop()
-			close(c)
-		}()
-		_, pg, err := p.Builds(concourse.Page{Limit: 1})
-		if err != nil {
-			logger.Session("init").Error("fail", err)
-			return
-		}
-		lastSeen := pg.Next.Since
-		var builds []atc.Build
-		for {
+		defer close(c)
+		defer logger.Info("exit")
+		// The builds followed are sent to finished until ctx is done, and
+		// c is closed only once they have stopped.
+		var following sync.WaitGroup
+		defer following.Wait()
+
+		var cursor *buildCursor
+		finished := make(chan atc.Build)
+
+		// send sends b unless it has already been sent. It returns false if
+		// ctx is done before b could be sent.
+		send := func(b atc.Build) bool {
+			since, ok := cursor.finish(b)
+			if !ok {
+				return true
+			}
+			if !since.IsZero() {
+				observeQueueTime(b, since)
+			}
 			select {
-			case <-t.C:
-				logger := logger.Session("get-latest")
-				builds, pg, err = p.Builds(concourse.Page{Until: lastSeen, Limit: 100})
+			case c <- b:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// start finds the latest build, as only newer builds are sent.
+		start := func() error {
+			builds, _, err := p.Builds(concourse.Page{Limit: 1})
+			if err != nil {
+				return err
+			}
+			var lastSeen int
+			if len(builds) > 0 {
+				lastSeen = builds[0].ID
+			}
+			cursor = newBuildCursor(p.Team.Name(), lastSeen)
+			return nil
+		}
+
+		poll := func() error {
+			start := time.Now()
+			defer func() { pollDuration.Observe(time.Since(start).Seconds()) }()
+
+			for {
+				// The oldest builds newer than the newest one seen, newest
+				// first.
+				lastSeen := cursor.lastSeen
+				builds, pg, err := p.Builds(concourse.Page{Until: lastSeen, Limit: 100})
 				if err != nil {
-					logger.Error("fail", err)
-					continue
+					return err
+				}
+				done, running := cursor.add(builds)
+				if p.StreamEvents {
+					for _, b := range running {
+						following.Add(1)
+						go func(b atc.Build) {
+							defer following.Done()
+							p.follow(ctx, logger.Session("follow", lager.Data{"build": b.ID}), b, finished)
+						}(b)
+					}
+				}
+				for _, b := range done {
+					if !send(b) {
+						return ctx.Err()
+					}
 				}
-				if len(builds) == 0 || pg.Next == nil {
-					// No new builds.
+				if pg.Previous == nil || cursor.lastSeen == lastSeen {
+					// No newer builds.
+					break
+				}
+			}
+
+			for _, id := range cursor.running() {
+				b, found, err := p.Build(strconv.Itoa(id))
+				if err != nil {
+					return err
+				}
+				if !found {
+					logger.Info("build-not-found", lager.Data{"build": id})
+					cursor.forget(id)
 					continue
 				}
-				// If there are more than 100 new builds.
-				if last := pg.Next.Since; last-lastSeen > 100 {
-					lastSeen = lastSeen + 100
-				} else {
-					lastSeen = pg.Next.Since
+				if !b.IsRunning() && !send(b) {
+					return ctx.Err()
 				}
+			}
+			return nil
+		}
 
-				for _, b := range builds {
-					if b.TeamName != p.Team.Name() {
-						continue
-					}
-					if b.IsRunning() {
-						// In order to resend the build once it has finished.
-						lastSeen = min(b.ID, lastSeen) - 1
-						continue
-					}
-					c <- b
+		retry := backoff.Backoff{Policy: p.Backoff}
+		var next time.Time // of the next poll, right away at first
+		for {
+			select {
+			case <-time.After(time.Until(next)):
+			case b := <-finished:
+				// Unless it has already been polled.
+				if !send(b) {
+					return
 				}
+				continue
 			case <-ctx.Done():
-				logger.Info("exit")
 				return
 			}
+
+			var err error
+			var action string
+			if cursor != nil {
+				action, err = "get-latest", poll()
+			} else {
+				action, err = "init", start()
+			}
+			if ctx.Err() != nil {
+				// The poll was interrupted.
+				return
+			}
+			p.recordPoll(err)
+			if err != nil {
+				pollErrors.Inc()
+				delay := retry.Next()
+				if delay < p.PollInterval {
+					delay = p.PollInterval
+				}
+				next = time.Now().Add(delay)
+				logger.Session(action).Error("fail-will-retry", err, lager.Data{
+					"failures": retry.Failures(),
+					"retry-in": delay.String(),
+				})
+				continue
+			}
+			retry.Reset()
+			next = time.Now().Add(p.PollInterval)
 		}
 	}()
 	return c
 }
 
+// Health reports whether polling for finished builds succeeds, i.e. whether
+// the last successful poll, or the start of polling if none has succeeded
+// yet, was less than StaleAfter ago. Rejected credentials are reported right
+// away.
+func (p *AutoPilot) Health() (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	details := make(map[string]interface{})
+	if p.pollErr != nil {
+		details["last_error"] = p.pollErr.Error()
+		details["failures"] = p.failures
+	}
+	if !p.lastPoll.IsZero() {
+		details["last_success"] = p.lastPoll
+		details["since_last_success"] = time.Since(p.lastPoll).Round(time.Second).String()
+	}
+	switch {
+	case p.pollErr == concourse.ErrUnauthorized:
+		return details, errors.New("not authorized, the credentials may have expired")
+	case p.started.IsZero():
+		return details, errors.New("not polling yet")
+	case p.lastPoll.IsZero():
+		// Polls may fail for a while after the start as well, e.g. while
+		// Concourse itself is starting.
+		if since := time.Since(p.started); since > p.StaleAfter {
+			return details, fmt.Errorf("no successful poll in %s since the start: %v", since.Round(time.Second), p.pollErr)
+		}
+		return details, nil
+	}
+	if since := time.Since(p.lastPoll); since > p.StaleAfter {
+		return details, fmt.Errorf("no successful poll for %s: %v", since.Round(time.Second), p.pollErr)
+	}
+	return details, nil
+}
+
+// recordPoll records the outcome of a poll. The error of the last failed poll
+// is only kept until a poll succeeds.
+func (p *AutoPilot) recordPoll(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pollErr = err
+	if err != nil {
+		p.failures++
+		return
+	}
+	p.failures = 0
+	p.lastPoll = time.Now()
+}
+
 func (p *AutoPilot) ListPipelines() ([]atc.Pipeline, error) {
 	if p.Team != nil {
 		return p.Team.ListPipelines()
@@ -97,15 +270,22 @@ func (p *AutoPilot) ListPipelines() ([]atc.Pipeline, error) {
 	return p.Client.ListPipelines()
 }
 
-func newConcourseClient(url, team, username, password string) (concourse.Client, error) {
-	c := concourse.NewClient(url, authenticatedClient(username, password), false)
+func newConcourseClient(url, team string, auth ConcourseAuth) (concourse.Client, error) {
+	source, err := auth.tokenSource(url, team)
+	if err != nil {
+		return nil, err
+	}
 
 	transport := &oauth2.Transport{
-		Source: &teamTokenSource{c.Team(team)},
+		Source: source,
 		Base:   baseTransport(),
 	}
 
-	return concourse.NewClient(url, &http.Client{Transport: transport}, false), nil
+	return newClient(url, &http.Client{Transport: transport}), nil
+}
+
+func newClient(url string, httpClient *http.Client) concourse.Client {
+	return concourse.NewClient(url, httpClient, false)
 }
 
 type teamTokenSource struct {
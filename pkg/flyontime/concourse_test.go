@@ -3,14 +3,22 @@ package flyontime_test
 import (
 	"context"
 	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing/quick"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/go-concourse/concourse"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/Bo0mer/flyontime/pkg/backoff"
 	. "github.com/Bo0mer/flyontime/pkg/flyontime"
 	"github.com/Bo0mer/flyontime/pkg/flyontime/flyontimefakes"
 )
@@ -23,10 +31,16 @@ var _ = Describe("Pilot", func() {
 		var pilot *AutoPilot
 		var builds <-chan atc.Build
 		var stop context.CancelFunc
+		var staleAfter time.Duration
+		var streamEvents bool
+		var onEvent func(atc.Build, atc.Event)
 
 		BeforeEach(func() {
 			client = new(flyontimefakes.FakeConcourseClient)
 			team = new(flyontimefakes.FakeTeam)
+			staleAfter = time.Minute
+			streamEvents = false
+			onEvent = nil
 		})
 
 		AfterEach(func() {
@@ -41,6 +55,10 @@ var _ = Describe("Pilot", func() {
 				Team:         team,
 				Logger:       lager.NewLogger("test"),
 				PollInterval: 5 * time.Millisecond,
+				StaleAfter:   staleAfter,
+				Backoff:      backoff.Policy{Min: time.Millisecond, Max: 5 * time.Millisecond},
+				StreamEvents: streamEvents,
+				OnEvent:      onEvent,
 			}
 			ctx, cancel := context.WithCancel(context.Background())
 			stop = cancel
@@ -56,8 +74,57 @@ var _ = Describe("Pilot", func() {
 				Consistently(builds).ShouldNot(Receive())
 			})
 
-			It("should close the returned channel", func() {
-				Eventually(builds).Should(BeClosed())
+			It("should keep retrying", func() {
+				Eventually(client.BuildsCallCount).Should(BeNumerically(">", 2))
+				Consistently(builds).ShouldNot(BeClosed())
+			})
+
+			It("should not report polling as failing right away", func() {
+				Eventually(client.BuildsCallCount).Should(BeNumerically(">", 0))
+				details, err := pilot.Health()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(details).Should(HaveKeyWithValue("last_error", "hoho"))
+			})
+
+			Context("for too long since the start", func() {
+				BeforeEach(func() {
+					staleAfter = 10 * time.Millisecond
+				})
+
+				It("should report polling as failing", func() {
+					Eventually(func() error {
+						_, err := pilot.Health()
+						return err
+					}).Should(MatchError(MatchRegexp(`no successful poll in .* since the start: hoho`)))
+				})
+			})
+
+			Context("because the credentials are rejected", func() {
+				BeforeEach(func() {
+					client.BuildsReturns(nil, concourse.Pagination{}, concourse.ErrUnauthorized)
+				})
+
+				It("should report that it is not authorized", func() {
+					Eventually(func() error {
+						_, err := pilot.Health()
+						return err
+					}).Should(MatchError(ContainSubstring("not authorized")))
+				})
+			})
+
+			Context("and then succeeds", func() {
+				BeforeEach(func() {
+					pg := concourse.Pagination{Next: &concourse.Page{Since: 41}}
+					client.BuildsReturnsOnCall(2, []atc.Build{{ID: 41, Status: "succeeded"}}, pg, nil)
+					client.BuildsReturnsOnCall(3, []atc.Build{{ID: 42, Status: "failed"}}, pg, nil)
+					team.NameReturns("")
+				})
+
+				It("should start sending builds", func() {
+					var b atc.Build
+					Eventually(builds).Should(Receive(&b))
+					Ω(b.ID).Should(Equal(42))
+				})
 			})
 		})
 
@@ -67,7 +134,28 @@ var _ = Describe("Pilot", func() {
 			BeforeEach(func() {
 				lastBuild = 41
 				pg := concourse.Pagination{Next: &concourse.Page{Since: lastBuild}}
-				client.BuildsReturnsOnCall(0, nil, pg, nil)
+				client.BuildsReturnsOnCall(0, []atc.Build{{ID: lastBuild, Status: "succeeded"}}, pg, nil)
+			})
+
+			It("should report polling as healthy", func() {
+				Eventually(func() error {
+					_, err := pilot.Health()
+					return err
+				}).ShouldNot(HaveOccurred())
+			})
+
+			Context("but no poll succeeds for too long", func() {
+				BeforeEach(func() {
+					client.BuildsReturns(nil, concourse.Pagination{}, errors.New("hoho"))
+					staleAfter = 10 * time.Millisecond
+				})
+
+				It("should report polling as failing", func() {
+					Eventually(func() error {
+						_, err := pilot.Health()
+						return err
+					}).Should(MatchError(ContainSubstring("no successful poll for")))
+				})
 			})
 
 			Context("and canceling the provided context", func() {
@@ -80,43 +168,87 @@ var _ = Describe("Pilot", func() {
 			Context("but all builds are currently running", func() {
 				BeforeEach(func() {
 					builds := []atc.Build{
-						atc.Build{Status: "pending"},
-						atc.Build{Status: "started"},
+						atc.Build{ID: 43, Status: "started"},
+						atc.Build{ID: 42, Status: "pending"},
 					}
 					client.BuildsReturnsOnCall(1, builds, concourse.Pagination{}, nil)
+					client.BuildStub = func(id string) (atc.Build, bool, error) {
+						return atc.Build{ID: atoi(id), Status: "started"}, true, nil
+					}
 				})
 
 				It("should retrieve builds", func() {
 					Eventually(client.BuildsCallCount).Should(BeNumerically(">=", 2))
 				})
 
+				It("should retrieve the running builds, oldest first", func() {
+					Eventually(client.BuildCallCount).Should(BeNumerically(">=", 2))
+					Ω(client.BuildArgsForCall(0)).Should(Equal("42"))
+					Ω(client.BuildArgsForCall(1)).Should(Equal("43"))
+				})
+
 				It("should not send any builds", func() {
 					Consistently(builds).ShouldNot(Receive())
 				})
+
+				Context("and then they finish", func() {
+					BeforeEach(func() {
+						client.BuildStub = func(id string) (atc.Build, bool, error) {
+							return atc.Build{ID: atoi(id), Status: "failed"}, true, nil
+						}
+					})
+
+					It("should send each of them once", func() {
+						var b atc.Build
+						Eventually(builds).Should(Receive(&b))
+						Ω(b.ID).Should(Equal(42))
+						Eventually(builds).Should(Receive(&b))
+						Ω(b.ID).Should(Equal(43))
+						Consistently(builds).ShouldNot(Receive())
+					})
+				})
+
+				Context("and then they are deleted", func() {
+					BeforeEach(func() {
+						client.BuildReturns(atc.Build{}, false, nil)
+					})
+
+					It("should stop retrieving them", func() {
+						Eventually(client.BuildsCallCount).Should(BeNumerically(">", 3))
+						Ω(client.BuildCallCount()).Should(Equal(2))
+					})
+				})
 			})
 
 			Context("and there are finished builds", func() {
 				var b1, b2 atc.Build
 				BeforeEach(func() {
-					b1, b2 = atc.Build{Status: "errored"}, atc.Build{Status: "succeeded"}
+					b1, b2 = atc.Build{ID: 42, Status: "errored"}, atc.Build{ID: 44, Status: "succeeded"}
 					builds := []atc.Build{
-						b1,
-						atc.Build{Status: "pending"},
+						atc.Build{ID: 45, Status: "started"},
 						b2,
-						atc.Build{Status: "started"},
+						atc.Build{ID: 43, Status: "pending"},
+						b1,
+					}
+					client.BuildsReturnsOnCall(1, builds, concourse.Pagination{}, nil)
+					client.BuildStub = func(id string) (atc.Build, bool, error) {
+						return atc.Build{ID: atoi(id), Status: "started"}, true, nil
 					}
-					pg := concourse.Pagination{Next: &concourse.Page{Since: lastBuild + 4}}
-					client.BuildsReturnsOnCall(1, builds, pg, nil)
 				})
 
 				It("should retrieve builds", func() {
-					Eventually(client.BuildsCallCount).Should(BeNumerically(">=", 2))
+					Eventually(builds).Should(Receive())
+					Eventually(builds).Should(Receive())
+					Eventually(client.BuildsCallCount).Should(BeNumerically(">=", 3))
 					argPage := client.BuildsArgsForCall(0)
 					Ω(argPage.Limit).Should(Equal(1))
 
 					argPage = client.BuildsArgsForCall(1)
 					Ω(argPage.Until).Should(Equal(lastBuild))
 					Ω(argPage.Limit).Should(Equal(100))
+
+					argPage = client.BuildsArgsForCall(2)
+					Ω(argPage.Until).Should(Equal(45))
 				})
 
 				It("should send all finished builds in order", func() {
@@ -126,7 +258,463 @@ var _ = Describe("Pilot", func() {
 					Eventually(builds).Should(Receive(&b))
 					Ω(b).Should(Equal(b2))
 				})
+
+				Context("and canceling the provided context before they are received", func() {
+					It("should close the returned channel without sending them", func() {
+						Eventually(client.BuildsCallCount).Should(BeNumerically(">=", 2))
+						stop()
+
+						var sent []atc.Build
+						Eventually(func() bool {
+							select {
+							case b, ok := <-builds:
+								if ok {
+									sent = append(sent, b)
+								}
+								return !ok
+							default:
+								return false
+							}
+						}).Should(BeTrue())
+						Ω(sent).Should(BeEmpty())
+					})
+				})
+			})
+
+			Context("and there are more than 100 new builds", func() {
+				BeforeEach(func() {
+					var page1, page2 []atc.Build
+					for id := 141; id > 41; id-- {
+						page1 = append(page1, atc.Build{ID: id, Status: "failed"})
+					}
+					for id := 150; id > 141; id-- {
+						page2 = append(page2, atc.Build{ID: id, Status: "failed"})
+					}
+					client.BuildsReturnsOnCall(1, page1, concourse.Pagination{
+						Previous: &concourse.Page{Until: 141, Limit: 100},
+					}, nil)
+					client.BuildsReturnsOnCall(2, page2, concourse.Pagination{
+						Next: &concourse.Page{Since: 142, Limit: 100},
+					}, nil)
+				})
+
+				It("should page through them and send each of them once, in order", func() {
+					for id := 42; id <= 150; id++ {
+						var b atc.Build
+						Eventually(builds).Should(Receive(&b))
+						Ω(b.ID).Should(Equal(id))
+					}
+					Consistently(builds).ShouldNot(Receive())
+					Ω(client.BuildsArgsForCall(2).Until).Should(Equal(141))
+					Ω(client.BuildsArgsForCall(3).Until).Should(Equal(150))
+				})
+			})
+
+			Context("and the events of running builds are streamed", func() {
+				var events *flyontimefakes.FakeConcourseEvents
+				var running atc.Build
+
+				BeforeEach(func() {
+					streamEvents = true
+					running = atc.Build{ID: 42, Status: "started"}
+					pg := concourse.Pagination{Next: &concourse.Page{Since: 42}}
+					client.BuildsReturnsOnCall(1, []atc.Build{running}, pg, nil)
+					// The build keeps being retrieved as running.
+					client.BuildReturns(running, true, nil)
+
+					events = new(flyontimefakes.FakeConcourseEvents)
+					events.NextEventReturnsOnCall(0, event.Status{Status: atc.StatusStarted, Time: 90}, nil)
+					events.NextEventReturnsOnCall(1, event.Status{Status: atc.StatusFailed, Time: 100}, nil)
+					events.NextEventReturnsOnCall(2, nil, io.EOF)
+					client.BuildEventsReturns(events, nil)
+				})
+
+				It("should follow the events of the running build only once", func() {
+					Eventually(builds).Should(Receive())
+					Eventually(client.BuildsCallCount).Should(BeNumerically(">", 3))
+					Consistently(client.BuildEventsCallCount).Should(Equal(1))
+					Ω(client.BuildEventsArgsForCall(0)).Should(Equal("42"))
+				})
+
+				It("should send the build once it finishes", func() {
+					var b atc.Build
+					Eventually(builds).Should(Receive(&b))
+					Ω(b).Should(Equal(atc.Build{ID: 42, Status: "failed", EndTime: 100}))
+					Consistently(builds).ShouldNot(Receive())
+				})
+
+				Context("and started builds are sent", func() {
+					var started chan atc.Build
+
+					BeforeEach(func() {
+						started = make(chan atc.Build, 1)
+						onEvent = SendStarted(started)
+					})
+
+					It("should send the build once it starts", func() {
+						var b atc.Build
+						Eventually(started).Should(Receive(&b))
+						Ω(b).Should(Equal(atc.Build{ID: 42, Status: "started", StartTime: 90}))
+						Consistently(started).ShouldNot(Receive())
+					})
+				})
+
+				Context("and the finished build is retrieved as well", func() {
+					BeforeEach(func() {
+						client.BuildReturns(atc.Build{ID: 42, Status: "failed", EndTime: 100}, true, nil)
+					})
+
+					It("should send the build only once", func() {
+						Eventually(builds).Should(Receive())
+						Consistently(builds).ShouldNot(Receive())
+					})
+				})
+
+				Context("but the events cannot be followed", func() {
+					BeforeEach(func() {
+						client.BuildEventsReturns(nil, errors.New("boom"))
+						client.BuildReturnsOnCall(2, atc.Build{ID: 42, Status: "failed"}, true, nil)
+					})
+
+					It("should send the build once retrieved as finished", func() {
+						var b atc.Build
+						Eventually(builds).Should(Receive(&b))
+						Ω(b.Status).Should(Equal("failed"))
+					})
+				})
+			})
+		})
+
+		Context("against a simulated Concourse", func() {
+			BeforeEach(func() {
+				team.NameReturns("main")
+			})
+
+			// The fakes are reconfigured for each feed, hence the pilots
+			// polling them must have stopped.
+			stopPilot := func(stop context.CancelFunc, builds <-chan atc.Build) {
+				stop()
+				for range builds {
+				}
+			}
+
+			// sendsFinishedOnce checks that each build of the team which
+			// finishes after polling has started is sent exactly once.
+			sendsFinishedOnce := func(existing int, steps []feedStep, seed int64) bool {
+				sim := newSimConcourse(existing, steps, seed)
+				client.BuildsStub = sim.Builds
+				client.BuildStub = sim.Build
+				client.BuildEventsStub = sim.BuildEvents
+
+				pilot = &AutoPilot{
+					Client:       client,
+					Team:         team,
+					Logger:       lager.NewLogger("test"),
+					PollInterval: time.Millisecond,
+					Backoff:      backoff.Policy{Min: time.Millisecond, Max: time.Millisecond},
+					StreamEvents: streamEvents,
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				builds := pilot.FinishedBuilds(ctx)
+				defer stopPilot(cancel, builds)
+
+				// Once all builds have finished, wait for all of them to be
+				// sent, and then for a few more polls, which must not send
+				// any of them again.
+				sent := make(map[int]int)
+				var expected []int
+				var deadline <-chan time.Time
+				// The timeout only catches builds which are never sent, as
+				// bursts of builds take a while with the race detector.
+				timeout := time.After(10 * time.Second)
+			receive:
+				for {
+					select {
+					case b := <-builds:
+						sent[b.ID]++
+					case <-time.After(5 * time.Millisecond):
+					case <-deadline:
+						break receive
+					case <-timeout:
+						return false
+					}
+					if deadline == nil && sim.Done() {
+						expected = sim.FinishedBuilds("main")
+						if len(sent) >= len(expected) {
+							deadline = time.After(20 * time.Millisecond)
+						}
+					}
+				}
+
+				if len(sent) != len(expected) {
+					return false
+				}
+				for _, id := range expected {
+					if sent[id] != 1 {
+						return false
+					}
+				}
+				return true
+			}
+
+			// The property is checked for random feeds of builds and numbers
+			// of builds which exist before polling starts. At least one has to
+			// exist, as otherwise the first poll could only retrieve the
+			// newest builds.
+			property := func(existing uint8, steps feed, seed int64) bool {
+				return sendsFinishedOnce(int(existing%8)+1, steps, seed)
+			}
+
+			It("should send each finished build exactly once", func() {
+				stopPilot(stop, builds)
+				Ω(quick.Check(property, &quick.Config{MaxCount: 25})).Should(Succeed())
+			})
+
+			Context("when exactly one build exists before polling starts", func() {
+				It("should send only the builds which finish afterwards", func() {
+					stopPilot(stop, builds)
+					steps := []feedStep{{New: 3, Finish: 2}, {New: 1, OtherTeam: true}, {Finish: 1}}
+					Ω(sendsFinishedOnce(1, steps, 1)).Should(BeTrue())
+				})
+			})
+
+			Context("when the events of running builds are streamed", func() {
+				BeforeEach(func() {
+					streamEvents = true
+				})
+
+				It("should send each finished build exactly once", func() {
+					stopPilot(stop, builds)
+					Ω(quick.Check(property, &quick.Config{MaxCount: 25})).Should(Succeed())
+				})
 			})
 		})
 	})
 })
+
+func atoi(s string) int {
+	i, err := strconv.Atoi(s)
+	Ω(err).ShouldNot(HaveOccurred())
+	return i
+}
+
+// feed is a random feed of steps. Its generated values are bounded, so that
+// checking properties against it stays fast, while they still include bursts
+// of builds over the page size of 100 every now and then.
+type feed []feedStep
+
+const maxFeedSteps = 10
+
+func (feed) Generate(r *rand.Rand, size int) reflect.Value {
+	steps := make(feed, r.Intn(maxFeedSteps+1))
+	for i := range steps {
+		n := r.Intn(16)
+		if r.Intn(8) == 0 {
+			n = 100 + r.Intn(50)
+		}
+		steps[i] = feedStep{
+			New:       uint8(n),
+			OtherTeam: r.Intn(4) == 0,
+			Finish:    uint8(r.Intn(64)),
+			Fail:      r.Intn(8) == 0,
+		}
+	}
+	return reflect.ValueOf(steps)
+}
+
+// feedStep is a random change to the builds of a simulated Concourse.
+type feedStep struct {
+	// New is the number of new builds, of the team unless OtherTeam is set.
+	New       uint8
+	OtherTeam bool
+	// Finish is the maximum number of running builds which finish.
+	Finish uint8
+	// Fail makes the next request fail.
+	Fail bool
+}
+
+// simConcourse simulates the builds of Concourse, which change according to
+// a feed of steps, one on each request for new builds. Once the feed is over,
+// all running builds finish.
+type simConcourse struct {
+	mu     sync.Mutex
+	rand   *rand.Rand
+	steps  []feedStep
+	builds []atc.Build // by ID - 1
+	// start is the ID of the newest build when polling starts.
+	start   int
+	started bool
+	// changed is closed, and replaced, whenever builds finish.
+	changed chan struct{}
+}
+
+// newSimConcourse returns a simulated Concourse with existing builds, which
+// are never sent, even if they finish after polling has started.
+func newSimConcourse(existing int, steps []feedStep, seed int64) *simConcourse {
+	sim := &simConcourse{
+		rand:    rand.New(rand.NewSource(seed)),
+		steps:   steps,
+		changed: make(chan struct{}),
+	}
+	sim.create(existing, "main")
+	return sim
+}
+
+func (s *simConcourse) create(n int, team string) {
+	statuses := []atc.BuildStatus{atc.StatusPending, atc.StatusStarted, atc.StatusSucceeded, atc.StatusFailed}
+	for i := 0; i < n; i++ {
+		status := statuses[s.rand.Intn(len(statuses))]
+		s.builds = append(s.builds, atc.Build{
+			ID:       len(s.builds) + 1,
+			TeamName: team,
+			Status:   string(status),
+		})
+	}
+}
+
+func (s *simConcourse) finish(n int) {
+	defer func() {
+		close(s.changed)
+		s.changed = make(chan struct{})
+	}()
+	statuses := []atc.BuildStatus{atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored, atc.StatusAborted}
+	for _, i := range s.rand.Perm(len(s.builds)) {
+		if n == 0 {
+			return
+		}
+		if s.builds[i].IsRunning() {
+			s.builds[i].Status = string(statuses[s.rand.Intn(len(statuses))])
+			s.builds[i].EndTime = int64(s.rand.Intn(1000))
+			n--
+		}
+	}
+}
+
+// step applies the next step of the feed. It returns whether the request
+// should fail.
+func (s *simConcourse) step() bool {
+	if len(s.steps) == 0 {
+		s.finish(len(s.builds))
+		return false
+	}
+	st := s.steps[0]
+	s.steps = s.steps[1:]
+	if st.OtherTeam {
+		s.create(int(st.New), "other")
+	} else {
+		s.create(int(st.New), "main")
+	}
+	s.finish(int(st.Finish))
+	return st.Fail
+}
+
+func (s *simConcourse) Builds(page concourse.Page) ([]atc.Build, concourse.Pagination, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started {
+		s.started, s.start = true, len(s.builds)
+	} else if s.step() {
+		return nil, concourse.Pagination{}, errors.New("simulated failure")
+	}
+
+	var builds []atc.Build
+	if page.Until == 0 {
+		for id := len(s.builds); id > 0 && len(builds) < page.Limit; id-- {
+			builds = append(builds, s.builds[id-1])
+		}
+	} else {
+		for id := page.Until + 1; id <= len(s.builds) && len(builds) < page.Limit; id++ {
+			builds = append([]atc.Build{s.builds[id-1]}, builds...)
+		}
+	}
+	if len(builds) == 0 {
+		return builds, concourse.Pagination{}, nil
+	}
+	var pg concourse.Pagination
+	if first := builds[0]; first.ID < len(s.builds) {
+		pg.Previous = &concourse.Page{Until: first.ID, Limit: page.Limit}
+	}
+	if last := builds[len(builds)-1]; last.ID > 1 {
+		pg.Next = &concourse.Page{Since: last.ID, Limit: page.Limit}
+	}
+	return builds, pg, nil
+}
+
+func (s *simConcourse) Build(id string) (atc.Build, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := strconv.Atoi(id)
+	if err != nil || i < 1 || i > len(s.builds) {
+		return atc.Build{}, false, nil
+	}
+	return s.builds[i-1], true, nil
+}
+
+func (s *simConcourse) BuildEvents(id string) (concourse.Events, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rand.Intn(4) == 0 {
+		return nil, errors.New("simulated failure")
+	}
+	return &simEvents{sim: s, id: id, closed: make(chan struct{})}, nil
+}
+
+// Done returns whether the feed is over and all builds have finished.
+func (s *simConcourse) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.steps) > 0 {
+		return false
+	}
+	for _, b := range s.builds {
+		if b.IsRunning() {
+			return false
+		}
+	}
+	return true
+}
+
+// FinishedBuilds returns the IDs of the finished builds of team created after
+// polling has started.
+func (s *simConcourse) FinishedBuilds(team string) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []int
+	for _, b := range s.builds[s.start:] {
+		if b.TeamName == team && !b.IsRunning() {
+			ids = append(ids, b.ID)
+		}
+	}
+	return ids
+}
+
+// simEvents are the events of a simulated build, whose status is sent once
+// it finishes.
+type simEvents struct {
+	sim       *simConcourse
+	id        string
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (e *simEvents) NextEvent() (atc.Event, error) {
+	for {
+		e.sim.mu.Lock()
+		changed := e.sim.changed
+		e.sim.mu.Unlock()
+		b, _, _ := e.sim.Build(e.id)
+		if !b.IsRunning() {
+			return event.Status{Status: atc.BuildStatus(b.Status), Time: b.EndTime}, nil
+		}
+		select {
+		case <-e.closed:
+			return nil, io.EOF
+		case <-changed:
+		}
+	}
+}
+
+func (e *simEvents) Close() error {
+	e.closeOnce.Do(func() { close(e.closed) })
+	return nil
+}
@@ -0,0 +1,91 @@
+package flyontime
+
+import (
+	"sort"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// buildCursor keeps track of the builds of a team seen while paging through
+// the builds of Concourse, so that each finished build is sent exactly once.
+// Every build up to lastSeen has either been sent as finished, or it is in
+// flight until it is seen finished. It is not safe for concurrent use.
+type buildCursor struct {
+	team string
+	// lastSeen is the ID of the newest build seen, of any team.
+	lastSeen int
+	// inFlight holds the running builds of the team, mapped to the time at
+	// which they were first seen pending, in order to measure their queue
+	// time. The time is zero for builds first seen started.
+	inFlight map[int]time.Time
+}
+
+func newBuildCursor(team string, lastSeen int) *buildCursor {
+	return &buildCursor{
+		team:     team,
+		lastSeen: lastSeen,
+		inFlight: make(map[int]time.Time),
+	}
+}
+
+// add records a page of builds newer than lastSeen. It returns the finished
+// builds of the team, oldest first, and the running ones. All of them are now
+// in flight, until they are sent as finished.
+func (c *buildCursor) add(page []atc.Build) (finished, running []atc.Build) {
+	builds := make([]atc.Build, 0, len(page))
+	for _, b := range page {
+		if b.ID <= c.lastSeen {
+			// Already seen, e.g. if the page is not what was asked for.
+			continue
+		}
+		builds = append(builds, b)
+	}
+	sort.Slice(builds, func(i, j int) bool { return builds[i].ID < builds[j].ID })
+
+	for _, b := range builds {
+		if b.ID > c.lastSeen {
+			c.lastSeen = b.ID
+		}
+		if b.TeamName != c.team {
+			continue
+		}
+		if b.IsRunning() {
+			var pendingSince time.Time
+			if b.Status == string(atc.StatusPending) {
+				pendingSince = time.Now()
+			}
+			c.inFlight[b.ID] = pendingSince
+			running = append(running, b)
+			continue
+		}
+		c.inFlight[b.ID] = time.Time{}
+		finished = append(finished, b)
+	}
+	return finished, running
+}
+
+// finish records that the in flight build b has finished. It returns whether
+// b should be sent, i.e. whether it was in flight, and when it was first seen
+// pending, if at all.
+func (c *buildCursor) finish(b atc.Build) (pendingSince time.Time, ok bool) {
+	pendingSince, ok = c.inFlight[b.ID]
+	delete(c.inFlight, b.ID)
+	return pendingSince, ok
+}
+
+// forget stops tracking the in flight build with the given id, e.g. because
+// it has been deleted along with its pipeline.
+func (c *buildCursor) forget(id int) {
+	delete(c.inFlight, id)
+}
+
+// running returns the IDs of the builds in flight, oldest first.
+func (c *buildCursor) running() []int {
+	ids := make([]int, 0, len(c.inFlight))
+	for id := range c.inFlight {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,61 @@
+package flyontime
+
+import (
+	"context"
+	"path"
+	"time"
+)
+
+// Incident describes a job failure that is escalated to an incident
+// management system.
+type Incident struct {
+	// DedupKey identifies the incident, it is the same for all incidents
+	// about the same job.
+	DedupKey      string
+	Summary       string
+	Job           Job
+	DashboardLink string
+	Failures      int       // Number of consecutive failures.
+	FailingSince  time.Time // Time of the first failure.
+}
+
+//go:generate counterfeiter . Escalator
+
+// Escalator opens and resolves incidents in an incident management system.
+type Escalator interface {
+	Trigger(ctx context.Context, i *Incident) error
+	Resolve(ctx context.Context, i *Incident) error
+}
+
+// EscalationPolicy determines which job failures are escalated.
+//
+// Failures of jobs from critical pipelines are escalated once the job fails
+// ConsecutiveFailures times in a row, or once it has been failing for longer
+// than FailingFor, whichever comes first. Zero values disable the respective
+// condition. The incident is resolved once the job succeeds.
+type EscalationPolicy struct {
+	// Pipelines is a list of critical pipeline names. The names could be
+	// patterns, as supported by path.Match, e.g. "prod-*".
+	Pipelines           []string
+	ConsecutiveFailures int
+	FailingFor          time.Duration
+}
+
+func (p EscalationPolicy) critical(pipeline string) bool {
+	for _, pattern := range p.Pipelines {
+		if ok, _ := path.Match(pattern, pipeline); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p EscalationPolicy) shouldEscalate(h *jobHistory, now time.Time) bool {
+	if h.LastStatus != statusFailed || h.Escalated {
+		return false
+	}
+	if p.ConsecutiveFailures > 0 && h.ConsecutiveFailures >= p.ConsecutiveFailures {
+		return true
+	}
+	return p.FailingFor > 0 && !h.FailingSince.IsZero() && now.Sub(h.FailingSince) >= p.FailingFor
+}
@@ -11,46 +11,73 @@ import (
 )
 
 type FakePilot struct {
-	URLStub        func() string
-	uRLMutex       sync.RWMutex
-	uRLArgsForCall []struct{}
-	uRLReturns     struct {
-		result1 string
+	BuildEventsStub        func(string) (concourse.Events, error)
+	buildEventsMutex       sync.RWMutex
+	buildEventsArgsForCall []struct {
+		arg1 string
 	}
-	uRLReturnsOnCall map[int]struct {
-		result1 string
+	buildEventsReturns struct {
+		result1 concourse.Events
+		result2 error
 	}
-	PausePipelineStub        func(pipeline string) (bool, error)
-	pausePipelineMutex       sync.RWMutex
-	pausePipelineArgsForCall []struct {
-		pipeline string
+	buildEventsReturnsOnCall map[int]struct {
+		result1 concourse.Events
+		result2 error
 	}
-	pausePipelineReturns struct {
-		result1 bool
+	CreateJobBuildStub        func(string, string) (atc.Build, error)
+	createJobBuildMutex       sync.RWMutex
+	createJobBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	createJobBuildReturns struct {
+		result1 atc.Build
 		result2 error
 	}
-	pausePipelineReturnsOnCall map[int]struct {
-		result1 bool
+	createJobBuildReturnsOnCall map[int]struct {
+		result1 atc.Build
 		result2 error
 	}
-	UnpausePipelineStub        func(pipeline string) (bool, error)
-	unpausePipelineMutex       sync.RWMutex
-	unpausePipelineArgsForCall []struct {
-		pipeline string
+	FinishedBuildsStub        func(context.Context) <-chan atc.Build
+	finishedBuildsMutex       sync.RWMutex
+	finishedBuildsArgsForCall []struct {
+		arg1 context.Context
 	}
-	unpausePipelineReturns struct {
-		result1 bool
+	finishedBuildsReturns struct {
+		result1 <-chan atc.Build
+	}
+	finishedBuildsReturnsOnCall map[int]struct {
+		result1 <-chan atc.Build
+	}
+	HealthStub        func() (map[string]interface{}, error)
+	healthMutex       sync.RWMutex
+	healthArgsForCall []struct {
+	}
+	healthReturns struct {
+		result1 map[string]interface{}
 		result2 error
 	}
-	unpausePipelineReturnsOnCall map[int]struct {
-		result1 bool
+	healthReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	ListPipelinesStub        func() ([]atc.Pipeline, error)
+	listPipelinesMutex       sync.RWMutex
+	listPipelinesArgsForCall []struct {
+	}
+	listPipelinesReturns struct {
+		result1 []atc.Pipeline
+		result2 error
+	}
+	listPipelinesReturnsOnCall map[int]struct {
+		result1 []atc.Pipeline
 		result2 error
 	}
-	PauseJobStub        func(pipeline, job string) (bool, error)
+	PauseJobStub        func(string, string) (bool, error)
 	pauseJobMutex       sync.RWMutex
 	pauseJobArgsForCall []struct {
-		pipeline string
-		job      string
+		arg1 string
+		arg2 string
 	}
 	pauseJobReturns struct {
 		result1 bool
@@ -60,509 +87,659 @@ type FakePilot struct {
 		result1 bool
 		result2 error
 	}
-	UnpauseJobStub        func(pipeline, job string) (bool, error)
-	unpauseJobMutex       sync.RWMutex
-	unpauseJobArgsForCall []struct {
-		pipeline string
-		job      string
+	PausePipelineStub        func(string) (bool, error)
+	pausePipelineMutex       sync.RWMutex
+	pausePipelineArgsForCall []struct {
+		arg1 string
 	}
-	unpauseJobReturns struct {
+	pausePipelineReturns struct {
 		result1 bool
 		result2 error
 	}
-	unpauseJobReturnsOnCall map[int]struct {
+	pausePipelineReturnsOnCall map[int]struct {
 		result1 bool
 		result2 error
 	}
-	CreateJobBuildStub        func(pipeline, job string) (atc.Build, error)
-	createJobBuildMutex       sync.RWMutex
-	createJobBuildArgsForCall []struct {
-		pipeline string
-		job      string
+	URLStub        func() string
+	uRLMutex       sync.RWMutex
+	uRLArgsForCall []struct {
 	}
-	createJobBuildReturns struct {
-		result1 atc.Build
-		result2 error
+	uRLReturns struct {
+		result1 string
 	}
-	createJobBuildReturnsOnCall map[int]struct {
-		result1 atc.Build
-		result2 error
+	uRLReturnsOnCall map[int]struct {
+		result1 string
 	}
-	BuildEventsStub        func(job string) (concourse.Events, error)
-	buildEventsMutex       sync.RWMutex
-	buildEventsArgsForCall []struct {
-		job string
+	UnpauseJobStub        func(string, string) (bool, error)
+	unpauseJobMutex       sync.RWMutex
+	unpauseJobArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-	buildEventsReturns struct {
-		result1 concourse.Events
+	unpauseJobReturns struct {
+		result1 bool
 		result2 error
 	}
-	buildEventsReturnsOnCall map[int]struct {
-		result1 concourse.Events
+	unpauseJobReturnsOnCall map[int]struct {
+		result1 bool
 		result2 error
 	}
-	FinishedBuildsStub        func(ctx context.Context) <-chan atc.Build
-	finishedBuildsMutex       sync.RWMutex
-	finishedBuildsArgsForCall []struct {
-		ctx context.Context
-	}
-	finishedBuildsReturns struct {
-		result1 <-chan atc.Build
-	}
-	finishedBuildsReturnsOnCall map[int]struct {
-		result1 <-chan atc.Build
+	UnpausePipelineStub        func(string) (bool, error)
+	unpausePipelineMutex       sync.RWMutex
+	unpausePipelineArgsForCall []struct {
+		arg1 string
 	}
-	ListPipelinesStub        func() ([]atc.Pipeline, error)
-	listPipelinesMutex       sync.RWMutex
-	listPipelinesArgsForCall []struct{}
-	listPipelinesReturns     struct {
-		result1 []atc.Pipeline
+	unpausePipelineReturns struct {
+		result1 bool
 		result2 error
 	}
-	listPipelinesReturnsOnCall map[int]struct {
-		result1 []atc.Pipeline
+	unpausePipelineReturnsOnCall map[int]struct {
+		result1 bool
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePilot) URL() string {
-	fake.uRLMutex.Lock()
-	ret, specificReturn := fake.uRLReturnsOnCall[len(fake.uRLArgsForCall)]
-	fake.uRLArgsForCall = append(fake.uRLArgsForCall, struct{}{})
-	fake.recordInvocation("URL", []interface{}{})
-	fake.uRLMutex.Unlock()
-	if fake.URLStub != nil {
-		return fake.URLStub()
+func (fake *FakePilot) BuildEvents(arg1 string) (concourse.Events, error) {
+	fake.buildEventsMutex.Lock()
+	ret, specificReturn := fake.buildEventsReturnsOnCall[len(fake.buildEventsArgsForCall)]
+	fake.buildEventsArgsForCall = append(fake.buildEventsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("BuildEvents", []interface{}{arg1})
+	fake.buildEventsMutex.Unlock()
+	if fake.BuildEventsStub != nil {
+		return fake.BuildEventsStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
-	}
-	return fake.uRLReturns.result1
-}
-
-func (fake *FakePilot) URLCallCount() int {
-	fake.uRLMutex.RLock()
-	defer fake.uRLMutex.RUnlock()
-	return len(fake.uRLArgsForCall)
-}
-
-func (fake *FakePilot) URLReturns(result1 string) {
-	fake.URLStub = nil
-	fake.uRLReturns = struct {
-		result1 string
-	}{result1}
-}
-
-func (fake *FakePilot) URLReturnsOnCall(i int, result1 string) {
-	fake.URLStub = nil
-	if fake.uRLReturnsOnCall == nil {
-		fake.uRLReturnsOnCall = make(map[int]struct {
-			result1 string
-		})
+		return ret.result1, ret.result2
 	}
-	fake.uRLReturnsOnCall[i] = struct {
-		result1 string
-	}{result1}
+	fakeReturns := fake.buildEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) PausePipeline(pipeline string) (bool, error) {
-	fake.pausePipelineMutex.Lock()
-	ret, specificReturn := fake.pausePipelineReturnsOnCall[len(fake.pausePipelineArgsForCall)]
-	fake.pausePipelineArgsForCall = append(fake.pausePipelineArgsForCall, struct {
-		pipeline string
-	}{pipeline})
-	fake.recordInvocation("PausePipeline", []interface{}{pipeline})
-	fake.pausePipelineMutex.Unlock()
-	if fake.PausePipelineStub != nil {
-		return fake.PausePipelineStub(pipeline)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.pausePipelineReturns.result1, fake.pausePipelineReturns.result2
+func (fake *FakePilot) BuildEventsCallCount() int {
+	fake.buildEventsMutex.RLock()
+	defer fake.buildEventsMutex.RUnlock()
+	return len(fake.buildEventsArgsForCall)
 }
 
-func (fake *FakePilot) PausePipelineCallCount() int {
-	fake.pausePipelineMutex.RLock()
-	defer fake.pausePipelineMutex.RUnlock()
-	return len(fake.pausePipelineArgsForCall)
+func (fake *FakePilot) BuildEventsCalls(stub func(string) (concourse.Events, error)) {
+	fake.buildEventsMutex.Lock()
+	defer fake.buildEventsMutex.Unlock()
+	fake.BuildEventsStub = stub
 }
 
-func (fake *FakePilot) PausePipelineArgsForCall(i int) string {
-	fake.pausePipelineMutex.RLock()
-	defer fake.pausePipelineMutex.RUnlock()
-	return fake.pausePipelineArgsForCall[i].pipeline
+func (fake *FakePilot) BuildEventsArgsForCall(i int) string {
+	fake.buildEventsMutex.RLock()
+	defer fake.buildEventsMutex.RUnlock()
+	argsForCall := fake.buildEventsArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakePilot) PausePipelineReturns(result1 bool, result2 error) {
-	fake.PausePipelineStub = nil
-	fake.pausePipelineReturns = struct {
-		result1 bool
+func (fake *FakePilot) BuildEventsReturns(result1 concourse.Events, result2 error) {
+	fake.buildEventsMutex.Lock()
+	defer fake.buildEventsMutex.Unlock()
+	fake.BuildEventsStub = nil
+	fake.buildEventsReturns = struct {
+		result1 concourse.Events
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) PausePipelineReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.PausePipelineStub = nil
-	if fake.pausePipelineReturnsOnCall == nil {
-		fake.pausePipelineReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakePilot) BuildEventsReturnsOnCall(i int, result1 concourse.Events, result2 error) {
+	fake.buildEventsMutex.Lock()
+	defer fake.buildEventsMutex.Unlock()
+	fake.BuildEventsStub = nil
+	if fake.buildEventsReturnsOnCall == nil {
+		fake.buildEventsReturnsOnCall = make(map[int]struct {
+			result1 concourse.Events
 			result2 error
 		})
 	}
-	fake.pausePipelineReturnsOnCall[i] = struct {
-		result1 bool
+	fake.buildEventsReturnsOnCall[i] = struct {
+		result1 concourse.Events
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) UnpausePipeline(pipeline string) (bool, error) {
-	fake.unpausePipelineMutex.Lock()
-	ret, specificReturn := fake.unpausePipelineReturnsOnCall[len(fake.unpausePipelineArgsForCall)]
-	fake.unpausePipelineArgsForCall = append(fake.unpausePipelineArgsForCall, struct {
-		pipeline string
-	}{pipeline})
-	fake.recordInvocation("UnpausePipeline", []interface{}{pipeline})
-	fake.unpausePipelineMutex.Unlock()
-	if fake.UnpausePipelineStub != nil {
-		return fake.UnpausePipelineStub(pipeline)
+func (fake *FakePilot) CreateJobBuild(arg1 string, arg2 string) (atc.Build, error) {
+	fake.createJobBuildMutex.Lock()
+	ret, specificReturn := fake.createJobBuildReturnsOnCall[len(fake.createJobBuildArgsForCall)]
+	fake.createJobBuildArgsForCall = append(fake.createJobBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateJobBuild", []interface{}{arg1, arg2})
+	fake.createJobBuildMutex.Unlock()
+	if fake.CreateJobBuildStub != nil {
+		return fake.CreateJobBuildStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.unpausePipelineReturns.result1, fake.unpausePipelineReturns.result2
+	fakeReturns := fake.createJobBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) UnpausePipelineCallCount() int {
-	fake.unpausePipelineMutex.RLock()
-	defer fake.unpausePipelineMutex.RUnlock()
-	return len(fake.unpausePipelineArgsForCall)
+func (fake *FakePilot) CreateJobBuildCallCount() int {
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
+	return len(fake.createJobBuildArgsForCall)
 }
 
-func (fake *FakePilot) UnpausePipelineArgsForCall(i int) string {
-	fake.unpausePipelineMutex.RLock()
-	defer fake.unpausePipelineMutex.RUnlock()
-	return fake.unpausePipelineArgsForCall[i].pipeline
+func (fake *FakePilot) CreateJobBuildCalls(stub func(string, string) (atc.Build, error)) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = stub
 }
 
-func (fake *FakePilot) UnpausePipelineReturns(result1 bool, result2 error) {
-	fake.UnpausePipelineStub = nil
-	fake.unpausePipelineReturns = struct {
-		result1 bool
+func (fake *FakePilot) CreateJobBuildArgsForCall(i int) (string, string) {
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
+	argsForCall := fake.createJobBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePilot) CreateJobBuildReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = nil
+	fake.createJobBuildReturns = struct {
+		result1 atc.Build
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) UnpausePipelineReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.UnpausePipelineStub = nil
-	if fake.unpausePipelineReturnsOnCall == nil {
-		fake.unpausePipelineReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakePilot) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = nil
+	if fake.createJobBuildReturnsOnCall == nil {
+		fake.createJobBuildReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
 			result2 error
 		})
 	}
-	fake.unpausePipelineReturnsOnCall[i] = struct {
-		result1 bool
+	fake.createJobBuildReturnsOnCall[i] = struct {
+		result1 atc.Build
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) PauseJob(pipeline string, job string) (bool, error) {
-	fake.pauseJobMutex.Lock()
-	ret, specificReturn := fake.pauseJobReturnsOnCall[len(fake.pauseJobArgsForCall)]
-	fake.pauseJobArgsForCall = append(fake.pauseJobArgsForCall, struct {
-		pipeline string
-		job      string
-	}{pipeline, job})
-	fake.recordInvocation("PauseJob", []interface{}{pipeline, job})
-	fake.pauseJobMutex.Unlock()
-	if fake.PauseJobStub != nil {
-		return fake.PauseJobStub(pipeline, job)
+func (fake *FakePilot) FinishedBuilds(arg1 context.Context) <-chan atc.Build {
+	fake.finishedBuildsMutex.Lock()
+	ret, specificReturn := fake.finishedBuildsReturnsOnCall[len(fake.finishedBuildsArgsForCall)]
+	fake.finishedBuildsArgsForCall = append(fake.finishedBuildsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("FinishedBuilds", []interface{}{arg1})
+	fake.finishedBuildsMutex.Unlock()
+	if fake.FinishedBuildsStub != nil {
+		return fake.FinishedBuildsStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.pauseJobReturns.result1, fake.pauseJobReturns.result2
+	fakeReturns := fake.finishedBuildsReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakePilot) PauseJobCallCount() int {
-	fake.pauseJobMutex.RLock()
-	defer fake.pauseJobMutex.RUnlock()
-	return len(fake.pauseJobArgsForCall)
+func (fake *FakePilot) FinishedBuildsCallCount() int {
+	fake.finishedBuildsMutex.RLock()
+	defer fake.finishedBuildsMutex.RUnlock()
+	return len(fake.finishedBuildsArgsForCall)
 }
 
-func (fake *FakePilot) PauseJobArgsForCall(i int) (string, string) {
-	fake.pauseJobMutex.RLock()
-	defer fake.pauseJobMutex.RUnlock()
-	return fake.pauseJobArgsForCall[i].pipeline, fake.pauseJobArgsForCall[i].job
+func (fake *FakePilot) FinishedBuildsCalls(stub func(context.Context) <-chan atc.Build) {
+	fake.finishedBuildsMutex.Lock()
+	defer fake.finishedBuildsMutex.Unlock()
+	fake.FinishedBuildsStub = stub
 }
 
-func (fake *FakePilot) PauseJobReturns(result1 bool, result2 error) {
-	fake.PauseJobStub = nil
-	fake.pauseJobReturns = struct {
-		result1 bool
+func (fake *FakePilot) FinishedBuildsArgsForCall(i int) context.Context {
+	fake.finishedBuildsMutex.RLock()
+	defer fake.finishedBuildsMutex.RUnlock()
+	argsForCall := fake.finishedBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePilot) FinishedBuildsReturns(result1 <-chan atc.Build) {
+	fake.finishedBuildsMutex.Lock()
+	defer fake.finishedBuildsMutex.Unlock()
+	fake.FinishedBuildsStub = nil
+	fake.finishedBuildsReturns = struct {
+		result1 <-chan atc.Build
+	}{result1}
+}
+
+func (fake *FakePilot) FinishedBuildsReturnsOnCall(i int, result1 <-chan atc.Build) {
+	fake.finishedBuildsMutex.Lock()
+	defer fake.finishedBuildsMutex.Unlock()
+	fake.FinishedBuildsStub = nil
+	if fake.finishedBuildsReturnsOnCall == nil {
+		fake.finishedBuildsReturnsOnCall = make(map[int]struct {
+			result1 <-chan atc.Build
+		})
+	}
+	fake.finishedBuildsReturnsOnCall[i] = struct {
+		result1 <-chan atc.Build
+	}{result1}
+}
+
+func (fake *FakePilot) Health() (map[string]interface{}, error) {
+	fake.healthMutex.Lock()
+	ret, specificReturn := fake.healthReturnsOnCall[len(fake.healthArgsForCall)]
+	fake.healthArgsForCall = append(fake.healthArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Health", []interface{}{})
+	fake.healthMutex.Unlock()
+	if fake.HealthStub != nil {
+		return fake.HealthStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.healthReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePilot) HealthCallCount() int {
+	fake.healthMutex.RLock()
+	defer fake.healthMutex.RUnlock()
+	return len(fake.healthArgsForCall)
+}
+
+func (fake *FakePilot) HealthCalls(stub func() (map[string]interface{}, error)) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = stub
+}
+
+func (fake *FakePilot) HealthReturns(result1 map[string]interface{}, result2 error) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = nil
+	fake.healthReturns = struct {
+		result1 map[string]interface{}
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) PauseJobReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.PauseJobStub = nil
-	if fake.pauseJobReturnsOnCall == nil {
-		fake.pauseJobReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakePilot) HealthReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = nil
+	if fake.healthReturnsOnCall == nil {
+		fake.healthReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
 			result2 error
 		})
 	}
-	fake.pauseJobReturnsOnCall[i] = struct {
-		result1 bool
+	fake.healthReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) UnpauseJob(pipeline string, job string) (bool, error) {
-	fake.unpauseJobMutex.Lock()
-	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
-	fake.unpauseJobArgsForCall = append(fake.unpauseJobArgsForCall, struct {
-		pipeline string
-		job      string
-	}{pipeline, job})
-	fake.recordInvocation("UnpauseJob", []interface{}{pipeline, job})
-	fake.unpauseJobMutex.Unlock()
-	if fake.UnpauseJobStub != nil {
-		return fake.UnpauseJobStub(pipeline, job)
+func (fake *FakePilot) ListPipelines() ([]atc.Pipeline, error) {
+	fake.listPipelinesMutex.Lock()
+	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
+	fake.listPipelinesArgsForCall = append(fake.listPipelinesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListPipelines", []interface{}{})
+	fake.listPipelinesMutex.Unlock()
+	if fake.ListPipelinesStub != nil {
+		return fake.ListPipelinesStub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.unpauseJobReturns.result1, fake.unpauseJobReturns.result2
+	fakeReturns := fake.listPipelinesReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) UnpauseJobCallCount() int {
-	fake.unpauseJobMutex.RLock()
-	defer fake.unpauseJobMutex.RUnlock()
-	return len(fake.unpauseJobArgsForCall)
+func (fake *FakePilot) ListPipelinesCallCount() int {
+	fake.listPipelinesMutex.RLock()
+	defer fake.listPipelinesMutex.RUnlock()
+	return len(fake.listPipelinesArgsForCall)
 }
 
-func (fake *FakePilot) UnpauseJobArgsForCall(i int) (string, string) {
-	fake.unpauseJobMutex.RLock()
-	defer fake.unpauseJobMutex.RUnlock()
-	return fake.unpauseJobArgsForCall[i].pipeline, fake.unpauseJobArgsForCall[i].job
+func (fake *FakePilot) ListPipelinesCalls(stub func() ([]atc.Pipeline, error)) {
+	fake.listPipelinesMutex.Lock()
+	defer fake.listPipelinesMutex.Unlock()
+	fake.ListPipelinesStub = stub
 }
 
-func (fake *FakePilot) UnpauseJobReturns(result1 bool, result2 error) {
-	fake.UnpauseJobStub = nil
-	fake.unpauseJobReturns = struct {
-		result1 bool
+func (fake *FakePilot) ListPipelinesReturns(result1 []atc.Pipeline, result2 error) {
+	fake.listPipelinesMutex.Lock()
+	defer fake.listPipelinesMutex.Unlock()
+	fake.ListPipelinesStub = nil
+	fake.listPipelinesReturns = struct {
+		result1 []atc.Pipeline
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) UnpauseJobReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.UnpauseJobStub = nil
-	if fake.unpauseJobReturnsOnCall == nil {
-		fake.unpauseJobReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakePilot) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline, result2 error) {
+	fake.listPipelinesMutex.Lock()
+	defer fake.listPipelinesMutex.Unlock()
+	fake.ListPipelinesStub = nil
+	if fake.listPipelinesReturnsOnCall == nil {
+		fake.listPipelinesReturnsOnCall = make(map[int]struct {
+			result1 []atc.Pipeline
 			result2 error
 		})
 	}
-	fake.unpauseJobReturnsOnCall[i] = struct {
-		result1 bool
+	fake.listPipelinesReturnsOnCall[i] = struct {
+		result1 []atc.Pipeline
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) CreateJobBuild(pipeline string, job string) (atc.Build, error) {
-	fake.createJobBuildMutex.Lock()
-	ret, specificReturn := fake.createJobBuildReturnsOnCall[len(fake.createJobBuildArgsForCall)]
-	fake.createJobBuildArgsForCall = append(fake.createJobBuildArgsForCall, struct {
-		pipeline string
-		job      string
-	}{pipeline, job})
-	fake.recordInvocation("CreateJobBuild", []interface{}{pipeline, job})
-	fake.createJobBuildMutex.Unlock()
-	if fake.CreateJobBuildStub != nil {
-		return fake.CreateJobBuildStub(pipeline, job)
+func (fake *FakePilot) PauseJob(arg1 string, arg2 string) (bool, error) {
+	fake.pauseJobMutex.Lock()
+	ret, specificReturn := fake.pauseJobReturnsOnCall[len(fake.pauseJobArgsForCall)]
+	fake.pauseJobArgsForCall = append(fake.pauseJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("PauseJob", []interface{}{arg1, arg2})
+	fake.pauseJobMutex.Unlock()
+	if fake.PauseJobStub != nil {
+		return fake.PauseJobStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createJobBuildReturns.result1, fake.createJobBuildReturns.result2
+	fakeReturns := fake.pauseJobReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) CreateJobBuildCallCount() int {
-	fake.createJobBuildMutex.RLock()
-	defer fake.createJobBuildMutex.RUnlock()
-	return len(fake.createJobBuildArgsForCall)
+func (fake *FakePilot) PauseJobCallCount() int {
+	fake.pauseJobMutex.RLock()
+	defer fake.pauseJobMutex.RUnlock()
+	return len(fake.pauseJobArgsForCall)
 }
 
-func (fake *FakePilot) CreateJobBuildArgsForCall(i int) (string, string) {
-	fake.createJobBuildMutex.RLock()
-	defer fake.createJobBuildMutex.RUnlock()
-	return fake.createJobBuildArgsForCall[i].pipeline, fake.createJobBuildArgsForCall[i].job
+func (fake *FakePilot) PauseJobCalls(stub func(string, string) (bool, error)) {
+	fake.pauseJobMutex.Lock()
+	defer fake.pauseJobMutex.Unlock()
+	fake.PauseJobStub = stub
 }
 
-func (fake *FakePilot) CreateJobBuildReturns(result1 atc.Build, result2 error) {
-	fake.CreateJobBuildStub = nil
-	fake.createJobBuildReturns = struct {
-		result1 atc.Build
+func (fake *FakePilot) PauseJobArgsForCall(i int) (string, string) {
+	fake.pauseJobMutex.RLock()
+	defer fake.pauseJobMutex.RUnlock()
+	argsForCall := fake.pauseJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePilot) PauseJobReturns(result1 bool, result2 error) {
+	fake.pauseJobMutex.Lock()
+	defer fake.pauseJobMutex.Unlock()
+	fake.PauseJobStub = nil
+	fake.pauseJobReturns = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, result2 error) {
-	fake.CreateJobBuildStub = nil
-	if fake.createJobBuildReturnsOnCall == nil {
-		fake.createJobBuildReturnsOnCall = make(map[int]struct {
-			result1 atc.Build
+func (fake *FakePilot) PauseJobReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pauseJobMutex.Lock()
+	defer fake.pauseJobMutex.Unlock()
+	fake.PauseJobStub = nil
+	if fake.pauseJobReturnsOnCall == nil {
+		fake.pauseJobReturnsOnCall = make(map[int]struct {
+			result1 bool
 			result2 error
 		})
 	}
-	fake.createJobBuildReturnsOnCall[i] = struct {
-		result1 atc.Build
+	fake.pauseJobReturnsOnCall[i] = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) BuildEvents(job string) (concourse.Events, error) {
-	fake.buildEventsMutex.Lock()
-	ret, specificReturn := fake.buildEventsReturnsOnCall[len(fake.buildEventsArgsForCall)]
-	fake.buildEventsArgsForCall = append(fake.buildEventsArgsForCall, struct {
-		job string
-	}{job})
-	fake.recordInvocation("BuildEvents", []interface{}{job})
-	fake.buildEventsMutex.Unlock()
-	if fake.BuildEventsStub != nil {
-		return fake.BuildEventsStub(job)
+func (fake *FakePilot) PausePipeline(arg1 string) (bool, error) {
+	fake.pausePipelineMutex.Lock()
+	ret, specificReturn := fake.pausePipelineReturnsOnCall[len(fake.pausePipelineArgsForCall)]
+	fake.pausePipelineArgsForCall = append(fake.pausePipelineArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PausePipeline", []interface{}{arg1})
+	fake.pausePipelineMutex.Unlock()
+	if fake.PausePipelineStub != nil {
+		return fake.PausePipelineStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.buildEventsReturns.result1, fake.buildEventsReturns.result2
+	fakeReturns := fake.pausePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) BuildEventsCallCount() int {
-	fake.buildEventsMutex.RLock()
-	defer fake.buildEventsMutex.RUnlock()
-	return len(fake.buildEventsArgsForCall)
+func (fake *FakePilot) PausePipelineCallCount() int {
+	fake.pausePipelineMutex.RLock()
+	defer fake.pausePipelineMutex.RUnlock()
+	return len(fake.pausePipelineArgsForCall)
 }
 
-func (fake *FakePilot) BuildEventsArgsForCall(i int) string {
-	fake.buildEventsMutex.RLock()
-	defer fake.buildEventsMutex.RUnlock()
-	return fake.buildEventsArgsForCall[i].job
+func (fake *FakePilot) PausePipelineCalls(stub func(string) (bool, error)) {
+	fake.pausePipelineMutex.Lock()
+	defer fake.pausePipelineMutex.Unlock()
+	fake.PausePipelineStub = stub
 }
 
-func (fake *FakePilot) BuildEventsReturns(result1 concourse.Events, result2 error) {
-	fake.BuildEventsStub = nil
-	fake.buildEventsReturns = struct {
-		result1 concourse.Events
+func (fake *FakePilot) PausePipelineArgsForCall(i int) string {
+	fake.pausePipelineMutex.RLock()
+	defer fake.pausePipelineMutex.RUnlock()
+	argsForCall := fake.pausePipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePilot) PausePipelineReturns(result1 bool, result2 error) {
+	fake.pausePipelineMutex.Lock()
+	defer fake.pausePipelineMutex.Unlock()
+	fake.PausePipelineStub = nil
+	fake.pausePipelineReturns = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) BuildEventsReturnsOnCall(i int, result1 concourse.Events, result2 error) {
-	fake.BuildEventsStub = nil
-	if fake.buildEventsReturnsOnCall == nil {
-		fake.buildEventsReturnsOnCall = make(map[int]struct {
-			result1 concourse.Events
+func (fake *FakePilot) PausePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pausePipelineMutex.Lock()
+	defer fake.pausePipelineMutex.Unlock()
+	fake.PausePipelineStub = nil
+	if fake.pausePipelineReturnsOnCall == nil {
+		fake.pausePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
 			result2 error
 		})
 	}
-	fake.buildEventsReturnsOnCall[i] = struct {
-		result1 concourse.Events
+	fake.pausePipelineReturnsOnCall[i] = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) FinishedBuilds(ctx context.Context) <-chan atc.Build {
-	fake.finishedBuildsMutex.Lock()
-	ret, specificReturn := fake.finishedBuildsReturnsOnCall[len(fake.finishedBuildsArgsForCall)]
-	fake.finishedBuildsArgsForCall = append(fake.finishedBuildsArgsForCall, struct {
-		ctx context.Context
-	}{ctx})
-	fake.recordInvocation("FinishedBuilds", []interface{}{ctx})
-	fake.finishedBuildsMutex.Unlock()
-	if fake.FinishedBuildsStub != nil {
-		return fake.FinishedBuildsStub(ctx)
+func (fake *FakePilot) URL() string {
+	fake.uRLMutex.Lock()
+	ret, specificReturn := fake.uRLReturnsOnCall[len(fake.uRLArgsForCall)]
+	fake.uRLArgsForCall = append(fake.uRLArgsForCall, struct {
+	}{})
+	fake.recordInvocation("URL", []interface{}{})
+	fake.uRLMutex.Unlock()
+	if fake.URLStub != nil {
+		return fake.URLStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.finishedBuildsReturns.result1
+	fakeReturns := fake.uRLReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakePilot) FinishedBuildsCallCount() int {
-	fake.finishedBuildsMutex.RLock()
-	defer fake.finishedBuildsMutex.RUnlock()
-	return len(fake.finishedBuildsArgsForCall)
+func (fake *FakePilot) URLCallCount() int {
+	fake.uRLMutex.RLock()
+	defer fake.uRLMutex.RUnlock()
+	return len(fake.uRLArgsForCall)
 }
 
-func (fake *FakePilot) FinishedBuildsArgsForCall(i int) context.Context {
-	fake.finishedBuildsMutex.RLock()
-	defer fake.finishedBuildsMutex.RUnlock()
-	return fake.finishedBuildsArgsForCall[i].ctx
+func (fake *FakePilot) URLCalls(stub func() string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = stub
 }
 
-func (fake *FakePilot) FinishedBuildsReturns(result1 <-chan atc.Build) {
-	fake.FinishedBuildsStub = nil
-	fake.finishedBuildsReturns = struct {
-		result1 <-chan atc.Build
+func (fake *FakePilot) URLReturns(result1 string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = nil
+	fake.uRLReturns = struct {
+		result1 string
 	}{result1}
 }
 
-func (fake *FakePilot) FinishedBuildsReturnsOnCall(i int, result1 <-chan atc.Build) {
-	fake.FinishedBuildsStub = nil
-	if fake.finishedBuildsReturnsOnCall == nil {
-		fake.finishedBuildsReturnsOnCall = make(map[int]struct {
-			result1 <-chan atc.Build
+func (fake *FakePilot) URLReturnsOnCall(i int, result1 string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = nil
+	if fake.uRLReturnsOnCall == nil {
+		fake.uRLReturnsOnCall = make(map[int]struct {
+			result1 string
 		})
 	}
-	fake.finishedBuildsReturnsOnCall[i] = struct {
-		result1 <-chan atc.Build
+	fake.uRLReturnsOnCall[i] = struct {
+		result1 string
 	}{result1}
 }
 
-func (fake *FakePilot) ListPipelines() ([]atc.Pipeline, error) {
-	fake.listPipelinesMutex.Lock()
-	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
-	fake.listPipelinesArgsForCall = append(fake.listPipelinesArgsForCall, struct{}{})
-	fake.recordInvocation("ListPipelines", []interface{}{})
-	fake.listPipelinesMutex.Unlock()
-	if fake.ListPipelinesStub != nil {
-		return fake.ListPipelinesStub()
+func (fake *FakePilot) UnpauseJob(arg1 string, arg2 string) (bool, error) {
+	fake.unpauseJobMutex.Lock()
+	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
+	fake.unpauseJobArgsForCall = append(fake.unpauseJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UnpauseJob", []interface{}{arg1, arg2})
+	fake.unpauseJobMutex.Unlock()
+	if fake.UnpauseJobStub != nil {
+		return fake.UnpauseJobStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.listPipelinesReturns.result1, fake.listPipelinesReturns.result2
+	fakeReturns := fake.unpauseJobReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePilot) ListPipelinesCallCount() int {
-	fake.listPipelinesMutex.RLock()
-	defer fake.listPipelinesMutex.RUnlock()
-	return len(fake.listPipelinesArgsForCall)
+func (fake *FakePilot) UnpauseJobCallCount() int {
+	fake.unpauseJobMutex.RLock()
+	defer fake.unpauseJobMutex.RUnlock()
+	return len(fake.unpauseJobArgsForCall)
 }
 
-func (fake *FakePilot) ListPipelinesReturns(result1 []atc.Pipeline, result2 error) {
-	fake.ListPipelinesStub = nil
-	fake.listPipelinesReturns = struct {
-		result1 []atc.Pipeline
+func (fake *FakePilot) UnpauseJobCalls(stub func(string, string) (bool, error)) {
+	fake.unpauseJobMutex.Lock()
+	defer fake.unpauseJobMutex.Unlock()
+	fake.UnpauseJobStub = stub
+}
+
+func (fake *FakePilot) UnpauseJobArgsForCall(i int) (string, string) {
+	fake.unpauseJobMutex.RLock()
+	defer fake.unpauseJobMutex.RUnlock()
+	argsForCall := fake.unpauseJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePilot) UnpauseJobReturns(result1 bool, result2 error) {
+	fake.unpauseJobMutex.Lock()
+	defer fake.unpauseJobMutex.Unlock()
+	fake.UnpauseJobStub = nil
+	fake.unpauseJobReturns = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePilot) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline, result2 error) {
-	fake.ListPipelinesStub = nil
-	if fake.listPipelinesReturnsOnCall == nil {
-		fake.listPipelinesReturnsOnCall = make(map[int]struct {
-			result1 []atc.Pipeline
+func (fake *FakePilot) UnpauseJobReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpauseJobMutex.Lock()
+	defer fake.unpauseJobMutex.Unlock()
+	fake.UnpauseJobStub = nil
+	if fake.unpauseJobReturnsOnCall == nil {
+		fake.unpauseJobReturnsOnCall = make(map[int]struct {
+			result1 bool
 			result2 error
 		})
 	}
-	fake.listPipelinesReturnsOnCall[i] = struct {
-		result1 []atc.Pipeline
+	fake.unpauseJobReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePilot) UnpausePipeline(arg1 string) (bool, error) {
+	fake.unpausePipelineMutex.Lock()
+	ret, specificReturn := fake.unpausePipelineReturnsOnCall[len(fake.unpausePipelineArgsForCall)]
+	fake.unpausePipelineArgsForCall = append(fake.unpausePipelineArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UnpausePipeline", []interface{}{arg1})
+	fake.unpausePipelineMutex.Unlock()
+	if fake.UnpausePipelineStub != nil {
+		return fake.UnpausePipelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.unpausePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePilot) UnpausePipelineCallCount() int {
+	fake.unpausePipelineMutex.RLock()
+	defer fake.unpausePipelineMutex.RUnlock()
+	return len(fake.unpausePipelineArgsForCall)
+}
+
+func (fake *FakePilot) UnpausePipelineCalls(stub func(string) (bool, error)) {
+	fake.unpausePipelineMutex.Lock()
+	defer fake.unpausePipelineMutex.Unlock()
+	fake.UnpausePipelineStub = stub
+}
+
+func (fake *FakePilot) UnpausePipelineArgsForCall(i int) string {
+	fake.unpausePipelineMutex.RLock()
+	defer fake.unpausePipelineMutex.RUnlock()
+	argsForCall := fake.unpausePipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePilot) UnpausePipelineReturns(result1 bool, result2 error) {
+	fake.unpausePipelineMutex.Lock()
+	defer fake.unpausePipelineMutex.Unlock()
+	fake.UnpausePipelineStub = nil
+	fake.unpausePipelineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePilot) UnpausePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpausePipelineMutex.Lock()
+	defer fake.unpausePipelineMutex.Unlock()
+	fake.UnpausePipelineStub = nil
+	if fake.unpausePipelineReturnsOnCall == nil {
+		fake.unpausePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpausePipelineReturnsOnCall[i] = struct {
+		result1 bool
 		result2 error
 	}{result1, result2}
 }
@@ -570,24 +747,26 @@ func (fake *FakePilot) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline,
 func (fake *FakePilot) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.uRLMutex.RLock()
-	defer fake.uRLMutex.RUnlock()
-	fake.pausePipelineMutex.RLock()
-	defer fake.pausePipelineMutex.RUnlock()
-	fake.unpausePipelineMutex.RLock()
-	defer fake.unpausePipelineMutex.RUnlock()
-	fake.pauseJobMutex.RLock()
-	defer fake.pauseJobMutex.RUnlock()
-	fake.unpauseJobMutex.RLock()
-	defer fake.unpauseJobMutex.RUnlock()
-	fake.createJobBuildMutex.RLock()
-	defer fake.createJobBuildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
 	defer fake.buildEventsMutex.RUnlock()
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
 	fake.finishedBuildsMutex.RLock()
 	defer fake.finishedBuildsMutex.RUnlock()
+	fake.healthMutex.RLock()
+	defer fake.healthMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
+	fake.pauseJobMutex.RLock()
+	defer fake.pauseJobMutex.RUnlock()
+	fake.pausePipelineMutex.RLock()
+	defer fake.pausePipelineMutex.RUnlock()
+	fake.uRLMutex.RLock()
+	defer fake.uRLMutex.RUnlock()
+	fake.unpauseJobMutex.RLock()
+	defer fake.unpauseJobMutex.RUnlock()
+	fake.unpausePipelineMutex.RLock()
+	defer fake.unpausePipelineMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,80 @@
+package flyontime
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// FlyTarget is a target of fly, as saved in ~/.flyrc by fly login.
+type FlyTarget struct {
+	API   string `yaml:"api"`
+	Team  string `yaml:"team"`
+	Token struct {
+		Type  string `yaml:"type"`
+		Value string `yaml:"value"`
+	} `yaml:"token"`
+}
+
+type flyrc struct {
+	Targets map[string]FlyTarget `yaml:"targets"`
+}
+
+// DefaultFlyrc returns the path of the ~/.flyrc file of the current user.
+func DefaultFlyrc() string {
+	home := os.Getenv("HOME")
+	if home == "" {
+		return ".flyrc"
+	}
+	return filepath.Join(home, ".flyrc")
+}
+
+// LoadFlyTarget loads the target with the given name from the flyrc file at
+// path.
+func LoadFlyTarget(path, name string) (FlyTarget, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return FlyTarget{}, err
+	}
+	t, err := parseFlyTarget(data, name)
+	if err != nil {
+		return FlyTarget{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return t, nil
+}
+
+func parseFlyTarget(data []byte, name string) (FlyTarget, error) {
+	var rc flyrc
+	if err := yaml.Unmarshal(data, &rc); err != nil {
+		return FlyTarget{}, err
+	}
+	t, ok := rc.Targets[name]
+	if !ok {
+		return FlyTarget{}, fmt.Errorf("unknown target %q", name)
+	}
+	return t, nil
+}
+
+// flyTargetToken returns a parser of the token of the target with the given
+// name from the contents of a flyrc file.
+func flyTargetToken(name string) func([]byte) (*oauth2.Token, error) {
+	return func(data []byte) (*oauth2.Token, error) {
+		t, err := parseFlyTarget(data, name)
+		if err != nil {
+			return nil, err
+		}
+		if t.Token.Value == "" {
+			return nil, errors.New("no token found, log in with fly first")
+		}
+		token := bearerToken(t.Token.Value)
+		if t.Token.Type != "" {
+			token.TokenType = t.Token.Type
+		}
+		return token, nil
+	}
+}
@@ -0,0 +1,126 @@
+package flyontime_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+const flyrcContents = `targets:
+  ci:
+    api: https://ci.example.com
+    team: dev
+    insecure: true
+    token:
+      type: bearer
+      value: %s
+  no-token:
+    api: https://other.example.com
+    team: main
+`
+
+var _ = Describe("Fly targets", func() {
+	var dir, flyrc string
+
+	writeFlyrc := func(token string, modTime time.Time) {
+		contents := fmt.Sprintf(flyrcContents, token)
+		Ω(ioutil.WriteFile(flyrc, []byte(contents), 0600)).Should(Succeed())
+		Ω(os.Chtimes(flyrc, modTime, modTime)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "flyontime")
+		Ω(err).ShouldNot(HaveOccurred())
+		flyrc = filepath.Join(dir, ".flyrc")
+		writeFlyrc("first-token", time.Now())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("LoadFlyTarget", func() {
+		It("should load the URL, team and token of the target", func() {
+			t, err := LoadFlyTarget(flyrc, "ci")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.API).Should(Equal("https://ci.example.com"))
+			Ω(t.Team).Should(Equal("dev"))
+			Ω(t.Token.Type).Should(Equal("bearer"))
+			Ω(t.Token.Value).Should(Equal("first-token"))
+		})
+
+		Context("when the target is unknown", func() {
+			It("should fail", func() {
+				_, err := LoadFlyTarget(flyrc, "prod")
+				Ω(err).Should(MatchError(ContainSubstring(`unknown target "prod"`)))
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			It("should fail", func() {
+				_, err := LoadFlyTarget(filepath.Join(dir, "missing"), "ci")
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("authenticating with the token of a target", func() {
+		var server *httptest.Server
+		var mu sync.Mutex
+		var authorizations []string
+
+		BeforeEach(func() {
+			authorizations = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				authorizations = append(authorizations, r.Header.Get("Authorization"))
+				w.Write([]byte("[]"))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newPilot := func(target string) *AutoPilot {
+			auth := ConcourseAuth{Mode: AuthToken, FlyTarget: target, Flyrc: flyrc}
+			pilot, err := NewAutoPilot(server.URL, "dev", auth, lager.NewLogger("test"))
+			Ω(err).ShouldNot(HaveOccurred())
+			return pilot
+		}
+
+		It("should reload the token when the file changes", func() {
+			pilot := newPilot("ci")
+			_, err := pilot.ListPipelines()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			writeFlyrc("second-token", time.Now().Add(time.Second))
+			_, err = pilot.ListPipelines()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			mu.Lock()
+			defer mu.Unlock()
+			Ω(authorizations).Should(Equal([]string{"Bearer first-token", "Bearer second-token"}))
+		})
+
+		Context("when the target has no token", func() {
+			It("should fail", func() {
+				_, err := newPilot("no-token").ListPipelines()
+				Ω(err).Should(MatchError(ContainSubstring("no token found")))
+			})
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package flyontime
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	buildsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyontime_builds_processed_total",
+		Help: "Number of finished builds processed, by status, team and pipeline.",
+	}, []string{"status", "team", "pipeline"})
+	notificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyontime_notifications_total",
+		Help: "Number of notifications, by backend and outcome (sent or failed).",
+	}, []string{"backend", "outcome"})
+	commandsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyontime_commands_total",
+		Help: "Number of commands received, by name and outcome (success, failure or unknown).",
+	}, []string{"command", "outcome"})
+	pollDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "flyontime_poll_duration_seconds",
+		Help: "Duration of polling Concourse for finished builds.",
+	})
+	pollErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "flyontime_poll_errors_total",
+		Help: "Number of failed polls of Concourse for finished builds.",
+	})
+)
+
+// Metrics of the monitored jobs, rather than of the bot itself.
+var (
+	buildDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "concourse_build_duration_seconds",
+		Help:    "Time from the start until the end of finished builds.",
+		Buckets: buildBuckets,
+	}, []string{"team", "pipeline", "job"})
+	buildQueueTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "concourse_build_queue_seconds",
+		Help:    "Time builds were pending before they started. Only builds seen while pending are observed.",
+		Buckets: buildBuckets,
+	}, []string{"team", "pipeline", "job"})
+	jobLastStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "concourse_job_last_status",
+		Help: "Status of the last finished build of jobs; 1 for the actual status, 0 for the others.",
+	}, []string{"team", "pipeline", "job", "status"})
+	jobConsecutiveFailures = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "concourse_job_consecutive_failures",
+		Help: "Number of consecutive failed builds of jobs.",
+	}, []string{"team", "pipeline", "job"})
+)
+
+var buildBuckets = []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200}
+
+var buildStatuses = []string{statusSucceeded, statusFailed, statusErrored, statusAborted}
+
+// observeBuild records the metrics of the finished build b, with h being the
+// already updated history of its job.
+func observeBuild(b atc.Build, h *jobHistory) {
+	if b.StartTime > 0 && b.EndTime >= b.StartTime {
+		buildDuration.WithLabelValues(b.TeamName, b.PipelineName, b.JobName).Observe(float64(b.EndTime - b.StartTime))
+	}
+	for _, status := range buildStatuses {
+		var v float64
+		if status == b.Status {
+			v = 1
+		}
+		jobLastStatus.WithLabelValues(b.TeamName, b.PipelineName, b.JobName, status).Set(v)
+	}
+	jobConsecutiveFailures.WithLabelValues(b.TeamName, b.PipelineName, b.JobName).Set(float64(h.ConsecutiveFailures))
+}
+
+// observeQueueTime records the queue time of b, which was first seen pending
+// at pendingSince.
+func observeQueueTime(b atc.Build, pendingSince time.Time) {
+	if b.StartTime == 0 {
+		// Builds aborted while pending never start.
+		return
+	}
+	d := time.Unix(b.StartTime, 0).Sub(pendingSince)
+	if d < 0 {
+		d = 0
+	}
+	buildQueueTime.WithLabelValues(b.TeamName, b.PipelineName, b.JobName).Observe(d.Seconds())
+}
+
+// RegisterGauges registers with r gauges reporting the number of failing and
+// muted jobs of m. It must be called at most once per registerer.
+func RegisterGauges(r prometheus.Registerer, m *Monitor) {
+	r.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "flyontime_failing_jobs",
+			Help: "Number of jobs whose last build has failed.",
+		}, func() float64 { return float64(m.FailingJobs()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "flyontime_muted_jobs",
+			Help: "Number of jobs whose notifications are muted.",
+		}, func() float64 { return float64(m.MutedJobs()) }),
+	)
+}
+
+// Instrument wraps n so that the notifications sent through it are counted
+// in metrics, labeled with backend.
+func Instrument(backend string, n Notifier) Notifier {
+	return &instrumentedNotifier{backend: backend, Notifier: n}
+}
+
+type instrumentedNotifier struct {
+	backend string
+	Notifier
+}
+
+func (n *instrumentedNotifier) Notify(ctx context.Context, notification *Notification) error {
+	err := n.Notifier.Notify(ctx, notification)
+	outcome := "sent"
+	if err != nil {
+		outcome = "failed"
+	}
+	notificationsTotal.WithLabelValues(n.backend, outcome).Inc()
+	return err
+}
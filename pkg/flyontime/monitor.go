@@ -2,6 +2,7 @@ package flyontime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/Bo0mer/flyontime/pkg/command"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/go-concourse/concourse"
@@ -30,24 +32,44 @@ type Pilot interface {
 	FinishedBuilds(ctx context.Context) <-chan atc.Build
 
 	ListPipelines() ([]atc.Pipeline, error)
+
+	// Health reports whether Concourse can be reached. It returns a non-nil
+	// error if it cannot.
+	Health() (map[string]interface{}, error)
 }
 
 type Monitor struct {
 	pilot Pilot
 	log   lager.Logger
 
-	cancel context.CancelFunc // signals to build producer to stop.
-	builds <-chan atc.Build
+	cancel  context.CancelFunc // signals to build producer to stop.
+	builds  <-chan atc.Build
+	started <-chan atc.Build // see NotifyStarted
 
 	commands <-chan *Command
 	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{} // closed once run returns
+
+	grammars map[CommandScope]*command.Grammar
+	handlers map[handlerKey]CommandFunc
 
 	history         map[jobKey]*jobHistory
+	notifier        Notifier
 	notifiers       map[jobStatus]notifyFunc
 	manuallyStarted map[int]func(b atc.Build)
+	seen            *SeenBuilds
+	saveInterval    time.Duration // how often seen is saved
+
+	watchInterval time.Duration
+	unreachable   bool // whether Concourse could not be reached on the last check
 
-	mu    sync.Mutex
-	muted map[jobKey]time.Time
+	mu      sync.Mutex
+	muted   map[jobKey]time.Time
+	failing int // number of jobs whose last build has failed
+
+	escalator  Escalator
+	escalation EscalationPolicy
 }
 
 type notifyFunc func(context.Context, atc.Build, *jobHistory) error
@@ -63,32 +85,211 @@ func NewMonitor(pilot Pilot, n Notifier, c Commander, logger lager.Logger) *Moni
 
 		commands: c.Commands(),
 		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+
+		grammars: map[CommandScope]*command.Grammar{
+			GlobalScope: new(command.Grammar),
+			ReplyScope:  new(command.Grammar),
+		},
+		handlers: make(map[handlerKey]CommandFunc),
 
 		history:         make(map[jobKey]*jobHistory),
+		notifier:        n,
 		notifiers:       defaultNotifiers(n, pilot),
 		manuallyStarted: make(map[int]func(atc.Build)),
+		seen:            NewSeenBuilds(DefaultSeenBuilds),
 		muted:           make(map[jobKey]time.Time),
 	}
+	for _, h := range m.builtinCommands() {
+		if err := m.Register(h); err != nil {
+			panic(err)
+		}
+	}
 
 	return m
 }
 
+// Register registers a command handler. It is an error to register a command
+// whose name or aliases are already registered in the same scope.
+// Register must not be called after Start.
+func (m *Monitor) Register(h CommandHandler) error {
+	if h.Name == "" || h.Handle == nil {
+		return fmt.Errorf("command %q must have both name and handler", h.Name)
+	}
+	scopes := h.Scope.scopes()
+	if len(scopes) == 0 {
+		return fmt.Errorf("command %q has no scope", h.Name)
+	}
+	for _, s := range scopes {
+		for _, n := range append([]string{h.Name}, h.Aliases...) {
+			if _, _, ok := m.grammars[s].Lookup(n, nil); ok {
+				return fmt.Errorf("command %q already registered", n)
+			}
+		}
+	}
+
+	spec := command.Spec{Name: h.Name, Aliases: h.Aliases, Args: h.Args, Flags: h.Flags, Help: h.Help}
+	for _, s := range scopes {
+		if err := m.grammars[s].Add(spec); err != nil {
+			return err
+		}
+		m.handlers[handlerKey{s, spec.Name}] = h.Handle
+	}
+	return nil
+}
+
+// Alias makes alias an alternative name for the command called name. It
+// applies to all scopes in which name is registered.
+// Alias must not be called after Start.
+func (m *Monitor) Alias(alias, name string) error {
+	var err error
+	var found bool
+	for _, g := range m.grammars {
+		if _, _, ok := g.Lookup(name, nil); !ok {
+			continue
+		}
+		found = true
+		if aerr := g.Alias(alias, name); aerr != nil {
+			err = aerr
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	return err
+}
+
+// Escalate makes the monitor escalate failures of critical jobs according to
+// policy, by opening incidents with e. Escalate must not be called after
+// Start.
+func (m *Monitor) Escalate(e Escalator, policy EscalationPolicy) {
+	m.escalator = e
+	m.escalation = policy
+}
+
+// RememberBuilds makes the monitor remember the handled builds in seen, e.g.
+// one loaded with LoadSeenBuilds in order to not notify builds again after a
+// restart. The set is saved every saveInterval and when the monitor stops.
+// By default, up to DefaultSeenBuilds builds are remembered in memory only.
+// RememberBuilds must not be called after Start.
+func (m *Monitor) RememberBuilds(seen *SeenBuilds, saveInterval time.Duration) {
+	m.seen = seen
+	m.saveInterval = saveInterval
+}
+
+// NotifyStarted makes the monitor send a notification for each build received
+// from started, e.g. through SendStarted, unless its job is muted.
+// NotifyStarted must not be called after Start.
+func (m *Monitor) NotifyStarted(started <-chan atc.Build) {
+	m.started = started
+}
+
+// FailingJobs returns the number of jobs whose last build has failed.
+func (m *Monitor) FailingJobs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failing
+}
+
+// MutedJobs returns the number of jobs whose notifications are currently
+// muted.
+func (m *Monitor) MutedJobs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	var n int
+	for _, until := range m.muted {
+		if now.Before(until) {
+			n++
+		}
+	}
+	return n
+}
+
+// WatchConcourse makes the monitor check every interval whether Concourse
+// could be reached (see Pilot.Health), sending a warning when it cannot and a
+// notification once it can again. WatchConcourse must not be called after
+// Start.
+func (m *Monitor) WatchConcourse(interval time.Duration) {
+	m.watchInterval = interval
+}
+
 func (m *Monitor) Start() {
+	defer close(m.done)
 	m.run()
 }
 
+// Stop stops the monitor and waits until Start returns, which includes saving
+// the handled builds (see RememberBuilds). Stop must not be called before
+// Start, but could be called more than once.
 func (m *Monitor) Stop() {
-	m.cancel()
-	close(m.stop)
+	m.stopOnce.Do(func() {
+		m.cancel()
+		close(m.stop)
+	})
+	<-m.done
 }
 
 func (m *Monitor) run() {
+	// Jobs that are failing for too long should be escalated even if there
+	// are no new builds for them.
+	var escalations <-chan time.Time
+	if m.escalator != nil && m.escalation.FailingFor > 0 {
+		interval := m.escalation.FailingFor / 10
+		if interval < time.Millisecond {
+			interval = time.Millisecond
+		}
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		escalations = t.C
+	}
+
+	var checks <-chan time.Time
+	if m.watchInterval > 0 {
+		t := time.NewTicker(m.watchInterval)
+		defer t.Stop()
+		checks = t.C
+	}
+
+	var saves <-chan time.Time
+	if m.saveInterval > 0 {
+		t := time.NewTicker(m.saveInterval)
+		defer t.Stop()
+		saves = t.C
+	}
+	defer m.saveSeenBuilds()
+
+	builds, started, commands := m.builds, m.started, m.commands
 	for {
 		select {
-		case b := <-m.builds:
+		case b, ok := <-builds:
+			if !ok {
+				// Receiving from a closed channel would never block.
+				m.log.Info("builds-closed")
+				builds = nil
+				continue
+			}
 			m.handleBuild(m.log.Session("handle-build"), b)
-		case c := <-m.commands:
+		case b, ok := <-started:
+			if !ok {
+				m.log.Info("started-closed")
+				started = nil
+				continue
+			}
+			m.notifyStarted(m.log.Session("notify-started", lager.Data{"build": b.ID}), b)
+		case c, ok := <-commands:
+			if !ok {
+				m.log.Info("commands-closed")
+				commands = nil
+				continue
+			}
 			m.handleCommand(m.log.Session("handle-command"), c)
+		case <-escalations:
+			m.escalateFailing(m.log.Session("escalate-failing"))
+		case <-checks:
+			m.checkConcourse(m.log.Session("check-concourse"))
+		case <-saves:
+			m.saveSeenBuilds()
 		case <-m.stop:
 			return
 		}
@@ -96,53 +297,114 @@ func (m *Monitor) run() {
 }
 
 func (m *Monitor) handleCommand(logger lager.Logger, c *Command) {
-	logger.Info(c.Name, lager.Data{"args": c.Args})
+	logger.Info(c.Name, lager.Data{"args": c.Args, "flags": c.Flags})
 
+	scope := GlobalScope
 	if c.Job != nil {
-		m.handleCommandForJob(c)
+		scope = ReplyScope
+	}
+	spec, args, ok := m.grammars[scope].Lookup(c.Name, c.Args)
+	if !ok {
+		// The name is not used as a label, as it could be anything.
+		commandsTotal.WithLabelValues("", "unknown").Inc()
+		c.Responses <- fmt.Sprintf("Unknown command: %q\nTo see the list of all available commands, use `help`.", c.Name)
+		close(c.Responses)
+		return
+	}
+	c.Name, c.Args = spec.Name, args
+	if err := spec.CheckFlags(c.Flags); err != nil {
+		commandsTotal.WithLabelValues(spec.Name, "failure").Inc()
+		c.Responses <- fmt.Sprintf("Invalid command: %v\nUsage: %s", err, spec.Usage())
+		close(c.Responses)
 		return
 	}
 
-	switch c.Name {
-	case "pause", "stop":
-		m.commandPausePipeline(c)
-	case "unpause", "play":
-		m.commandPlayPipeline(c)
-	case "pipelines":
-		m.commandPipelines(c)
-	case "help":
-		m.commandHelp(c)
-	default:
-		c.Responses <- fmt.Sprintf("Unknown command: %q\nTo see the list of all available commands, use `help`.", c.Name)
+	outcome := "success"
+	if err := m.handlers[handlerKey{scope, spec.Name}](m.pilot, c); err != nil {
+		logger.Error("fail", err)
+		outcome = "failure"
 	}
+	commandsTotal.WithLabelValues(spec.Name, outcome).Inc()
 }
 
-func (m *Monitor) handleCommandForJob(c *Command) {
-	switch c.Name {
-	case "rerun", "try again", "retry":
-		m.commandRerun(c)
-	case "pause", "stop":
-		m.commandPause(c)
-	case "unpause", "play":
-		m.commandPlay(c)
-	case "mute", "silence":
-		m.commandMute(c)
-	case "unmute":
-		m.commandUnmute(c)
-	case "help":
-		m.commandHelp(c)
-	default:
-		c.Responses <- fmt.Sprintf("Unknown command: %q\nTo see the list of all available commands, use `help`.", c.Name)
+func (m *Monitor) builtinCommands() []CommandHandler {
+	return []CommandHandler{
+		{
+			Name:   "pipelines",
+			Scope:  GlobalScope,
+			Help:   "List all pipelines and their status.",
+			Handle: m.commandPipelines,
+		},
+		{
+			Name:    "pause",
+			Aliases: []string{"stop"},
+			Scope:   GlobalScope,
+			Args:    "<pipeline>",
+			Help:    "Pause pipeline.",
+			Handle:  m.commandPausePipeline,
+		},
+		{
+			Name:    "unpause",
+			Aliases: []string{"play"},
+			Scope:   GlobalScope,
+			Args:    "<pipeline>",
+			Help:    "Unpause pipeline.",
+			Handle:  m.commandPlayPipeline,
+		},
+		{
+			Name:    "rerun",
+			Aliases: []string{"retry", "try again"},
+			Scope:   ReplyScope,
+			Help:    "Rerun the job and reply with its new status.",
+			Handle:  m.commandRerun,
+		},
+		{
+			Name:    "mute",
+			Aliases: []string{"silence"},
+			Scope:   ReplyScope,
+			Args:    "[duration]",
+			Flags:   []string{"--for=<duration>"},
+			Help:    "Mute notifications for the job for the specified duration (30m by default).",
+			Handle:  m.commandMute,
+		},
+		{
+			Name:   "unmute",
+			Scope:  ReplyScope,
+			Help:   "Turn job notifications back on.",
+			Handle: m.commandUnmute,
+		},
+		{
+			Name:    "pause",
+			Aliases: []string{"stop"},
+			Scope:   ReplyScope,
+			Args:    "[pipeline]",
+			Help:    "Pause the job, or the pipeline it is part of.",
+			Handle:  m.commandPause,
+		},
+		{
+			Name:    "unpause",
+			Aliases: []string{"play"},
+			Scope:   ReplyScope,
+			Args:    "[pipeline]",
+			Help:    "Unpause the job, or the pipeline it is part of.",
+			Handle:  m.commandPlay,
+		},
+		{
+			Name:   "help",
+			Scope:  GlobalScope | ReplyScope,
+			Help:   "Show this message.",
+			Handle: m.commandHelp,
+		},
 	}
 }
 
-func (m *Monitor) commandPipelines(c *Command) {
+func (m *Monitor) commandPipelines(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
-	ps, err := m.pilot.ListPipelines()
+	ps, err := p.ListPipelines()
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Listing pipelines failed: %v", err)
-		return
+		return err
 	}
 
 	bstr := func(b bool) string {
@@ -153,134 +415,148 @@ func (m *Monitor) commandPipelines(c *Command) {
 	}
 
 	var b strings.Builder
-	for _, p := range ps {
-		fmt.Fprintf(&b, "*%s*\n\tTeam: %s\n\tPaused: %s\n\tPublic: %s\n", p.Name, p.TeamName, bstr(p.Paused), bstr(p.Public))
+	for _, pl := range ps {
+		fmt.Fprintf(&b, "*%s*\n\tTeam: %s\n\tPaused: %s\n\tPublic: %s\n", pl.Name, pl.TeamName, bstr(pl.Paused), bstr(pl.Public))
 	}
 
 	c.Responses <- b.String()
+	return nil
 }
 
-func (m *Monitor) commandRerun(c *Command) {
+func (m *Monitor) commandRerun(p Pilot, c *Command) error {
 	j := c.Job
-	b, err := m.pilot.CreateJobBuild(j.Pipeline, j.Name)
+	b, err := p.CreateJobBuild(j.Pipeline, j.Name)
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Running %s failed: %v", c.Job.Name, err)
 		close(c.Responses)
-		return
+		return err
 	}
 	c.Responses <- fmt.Sprintf("Rerunning %s...", c.Job.Name)
 	m.manuallyStarted[b.ID] = func(b atc.Build) {
 		c.Responses <- fmt.Sprintf("Job %s.", b.Status)
 		close(c.Responses)
 	}
+	return nil
 }
 
-func (m *Monitor) commandPause(c *Command) {
+func (m *Monitor) commandPause(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
 	if len(c.Args) > 0 && c.Args[0] == "pipeline" {
-		ok, err := m.pilot.PausePipeline(c.Job.Pipeline)
+		ok, err := p.PausePipeline(c.Job.Pipeline)
 		if err != nil {
 			c.Responses <- fmt.Sprintf("Pausing pipeline %s failed: %v", c.Job.Pipeline, err)
+			return err
 		}
 		if ok {
 			c.Responses <- fmt.Sprintf("Pipeline %s is now paused.", c.Job.Pipeline)
 		} else {
 			c.Responses <- fmt.Sprintf("Pipeline %s is already paused.", c.Job.Pipeline)
 		}
-		return
+		return nil
 	}
 
-	ok, err := m.pilot.PauseJob(c.Job.Pipeline, c.Job.Name)
+	ok, err := p.PauseJob(c.Job.Pipeline, c.Job.Name)
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Pausing job %s failed: %v", c.Job.Name, err)
+		return err
 	}
 	if ok {
 		c.Responses <- fmt.Sprintf("Job %s is now paused.", c.Job.Name)
 	} else {
 		c.Responses <- fmt.Sprintf("Job %s is already paused.", c.Job.Name)
 	}
-	return
+	return nil
 }
 
-func (m *Monitor) commandPausePipeline(c *Command) {
+func (m *Monitor) commandPausePipeline(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
 	if len(c.Args) != 1 {
 		c.Responses <- fmt.Sprintf("Missing pipeline name. Usage: `%s <pipeline>`.", c.Name)
-		return
+		return errors.New("missing pipeline name")
 	}
 
 	pipeline := c.Args[0]
-	ok, err := m.pilot.PausePipeline(pipeline)
+	ok, err := p.PausePipeline(pipeline)
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Pausing pipeline %s failed: %v", pipeline, err)
+		return err
 	}
 	if ok {
 		c.Responses <- fmt.Sprintf("Pipeline %s is now paused.", pipeline)
 	} else {
 		c.Responses <- fmt.Sprintf("Pipeline %s is already paused.", pipeline)
 	}
+	return nil
 }
 
-func (m *Monitor) commandPlay(c *Command) {
+func (m *Monitor) commandPlay(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
 	if len(c.Args) > 0 && c.Args[0] == "pipeline" {
-		ok, err := m.pilot.UnpausePipeline(c.Job.Pipeline)
+		ok, err := p.UnpausePipeline(c.Job.Pipeline)
 		if err != nil {
 			c.Responses <- fmt.Sprintf("Unpausing pipeline %s failed: %v", c.Job.Pipeline, err)
+			return err
 		}
 		if ok {
 			c.Responses <- fmt.Sprintf("Pipeline %s is now unpaused.", c.Job.Pipeline)
 		} else {
 			c.Responses <- fmt.Sprintf("Pipeline %s is already unpaused.", c.Job.Pipeline)
 		}
-		return
+		return nil
 	}
 
-	ok, err := m.pilot.UnpauseJob(c.Job.Pipeline, c.Job.Name)
+	ok, err := p.UnpauseJob(c.Job.Pipeline, c.Job.Name)
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Unpausing job %s failed: %v", c.Job.Name, err)
+		return err
 	}
 	if ok {
 		c.Responses <- fmt.Sprintf("Job %s is now unpaused.", c.Job.Name)
 	} else {
 		c.Responses <- fmt.Sprintf("Job %s is already unpaused.", c.Job.Name)
 	}
+	return nil
 }
 
-func (m *Monitor) commandPlayPipeline(c *Command) {
+func (m *Monitor) commandPlayPipeline(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
 	if len(c.Args) != 1 {
 		c.Responses <- fmt.Sprintf("Missing pipeline name. Usage: `%s <pipeline>`.", c.Name)
-		return
+		return errors.New("missing pipeline name")
 	}
 
 	pipeline := c.Args[0]
-	ok, err := m.pilot.UnpausePipeline(pipeline)
+	ok, err := p.UnpausePipeline(pipeline)
 	if err != nil {
 		c.Responses <- fmt.Sprintf("Unpausing pipeline %s failed: %v", pipeline, err)
+		return err
 	}
 	if ok {
 		c.Responses <- fmt.Sprintf("Pipeline %s is now unpaused.", pipeline)
 	} else {
 		c.Responses <- fmt.Sprintf("Pipeline %s is already unpaused.", pipeline)
 	}
-	return
+	return nil
 }
 
-func (m *Monitor) commandMute(c *Command) {
+func (m *Monitor) commandMute(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
-	if len(c.Args) == 0 {
-		c.Args = []string{"30m"} // default mute duration
+	duration := "30m" // default mute duration
+	if len(c.Args) > 0 {
+		duration = c.Args[0]
 	}
-	d, err := time.ParseDuration(c.Args[0])
+	if v, ok := c.Flags["for"]; ok {
+		duration = v
+	}
+	d, err := time.ParseDuration(duration)
 	if err != nil {
-		c.Responses <- fmt.Sprintf("Invalid duration %q", c.Args[0])
-		return
+		c.Responses <- fmt.Sprintf("Invalid duration %q", duration)
+		return err
 	}
 
 	j := c.Job
@@ -289,9 +565,10 @@ func (m *Monitor) commandMute(c *Command) {
 	m.muted[jobKey{j.Team, j.Pipeline, j.Name}] = until
 	m.mu.Unlock()
 	c.Responses <- fmt.Sprintf("Muted notifications for %s until %s", j.Name, until.Format(time.Kitchen))
+	return nil
 }
 
-func (m *Monitor) commandUnmute(c *Command) {
+func (m *Monitor) commandUnmute(p Pilot, c *Command) error {
 	defer close(c.Responses)
 
 	j := c.Job
@@ -299,50 +576,43 @@ func (m *Monitor) commandUnmute(c *Command) {
 	delete(m.muted, jobKey{j.Team, j.Pipeline, j.Name})
 	m.mu.Unlock()
 	c.Responses <- fmt.Sprintf("Notifications for %s are back on.", j.Name)
+	return nil
 }
 
-func (m *Monitor) commandHelp(c *Command) {
-	const usage = `List of supported commands:
-*pipelines*
-	List all pipeline and their status.
-*pause <pipeline>*
-	Pause pipeline.
-*unpause <pipeline>*
-	Unpause pipeline.
-
-
-List of supported reply commands:
-*rerun*, *retry*
-	Rerun the job and reply with its new status.
-*mute* [duration]
-	Mute notifications for the job for the specified duration.
-*unmute*
-	Turn job notifications back on.
-*pause*, *stop*
-	Pause the job.
-*unpause*, *play*
-	Unpause the job.
-*pause pipeline*
-	Pause the pipeline the job is part of.
-*unpause pipeline*
-	Unpause the pipeline the job is part of.`
-
+func (m *Monitor) commandHelp(p Pilot, c *Command) error {
 	defer close(c.Responses)
-	c.Responses <- usage
+	c.Responses <- fmt.Sprintf("List of supported commands:\n%s\n\n\nList of supported reply commands:\n%s",
+		m.grammars[GlobalScope].Usage(), m.grammars[ReplyScope].Usage())
+	return nil
+}
+
+func (m *Monitor) saveSeenBuilds() {
+	if err := m.seen.Save(); err != nil {
+		m.log.Error("save-seen-builds.fail", err)
+	}
 }
 
 func (m *Monitor) handleBuild(logger lager.Logger, b atc.Build) {
+	if !m.seen.Add(b.ID) {
+		logger.Info("skip-already-handled", lager.Data{"build": b.ID})
+		return
+	}
 	if b.OneOff() {
 		logger.Info("skip-one-off")
 		// One off, no need to send notifications.
 		return
 	}
+	buildsProcessed.WithLabelValues(b.Status, b.TeamName, b.PipelineName).Inc()
 
 	h, ok := m.history[jobKey{b.TeamName, b.PipelineName, b.JobName}]
 	if !ok {
 		h = &jobHistory{}
 	}
-	defer m.updateHistory(b, h)
+	defer func() {
+		lastStatus := h.LastStatus
+		m.updateHistory(b, h)
+		m.escalate(logger.Session("escalate"), b, lastStatus, h)
+	}()
 
 	if respond, ok := m.isManuallyStarted(b); ok {
 		// Respond with the build status if it is manually started.
@@ -388,6 +658,32 @@ func (m *Monitor) shouldNotify(logger lager.Logger, b atc.Build, h *jobHistory)
 	return ok
 }
 
+func (m *Monitor) notifyStarted(logger lager.Logger, b atc.Build) {
+	if b.OneOff() {
+		return
+	}
+	m.mu.Lock()
+	until, muted := m.muted[jobKey{b.TeamName, b.PipelineName, b.JobName}]
+	m.mu.Unlock()
+	if muted && time.Now().Before(until) {
+		logger.Debug("notifications-muted")
+		return
+	}
+
+	ctx := lagerctx.NewContext(context.Background(), logger)
+	err := m.notifier.Notify(ctx, &Notification{
+		Severity:      SeverityInfo,
+		Title:         fmt.Sprintf("Job %s from %s has started.", b.JobName, b.PipelineName),
+		Job:           jobFromATCBuild(b),
+		DashboardLink: dashboardLink(m.pilot, b),
+	})
+	if err != nil {
+		logger.Error("fail", err)
+		return
+	}
+	logger.Info("done")
+}
+
 func (m *Monitor) notify(logger lager.Logger, build atc.Build, h *jobHistory) {
 	f, ok := m.notifiers[jobStatus{h.LastStatus, build.Status}]
 	if !ok {
@@ -404,19 +700,137 @@ func (m *Monitor) notify(logger lager.Logger, build atc.Build, h *jobHistory) {
 func (m *Monitor) updateHistory(b atc.Build, h *jobHistory) {
 	if b.Status == statusSucceeded {
 		h.ConsecutiveFailures = 0
+		h.FailingSince = time.Time{}
 	}
 	if b.Status == statusFailed {
 		h.ConsecutiveFailures++
+		if h.FailingSince.IsZero() {
+			h.FailingSince = buildEndTime(b)
+		}
+	}
+	if wasFailing, failing := h.LastStatus == statusFailed, b.Status == statusFailed; wasFailing != failing {
+		m.mu.Lock()
+		if failing {
+			m.failing++
+		} else {
+			m.failing--
+		}
+		m.mu.Unlock()
 	}
 	h.LastStatus = b.Status
+	h.LastBuild = b
 	m.history[jobKey{b.TeamName, b.PipelineName, b.JobName}] = h
+	observeBuild(b, h)
+}
+
+// checkConcourse sends a notification when Concourse stops or starts being
+// reachable.
+func (m *Monitor) checkConcourse(logger lager.Logger) {
+	details, err := m.pilot.Health()
+	var n *Notification
+	switch {
+	case err != nil && !m.unreachable:
+		logger.Error("unreachable", err, lager.Data{"details": details})
+		n = &Notification{
+			Severity: SeverityWarn,
+			Title:    fmt.Sprintf("Cannot reach Concourse: %v.", err),
+		}
+	case err == nil && m.unreachable:
+		logger.Info("reachable")
+		n = &Notification{
+			Severity: SeverityInfo,
+			Title:    "Concourse can be reached again.",
+		}
+	default:
+		return
+	}
+	m.unreachable = err != nil
+	n.DashboardLink = m.pilot.URL()
+
+	ctx := lagerctx.NewContext(context.Background(), logger)
+	if err := m.notifier.Notify(ctx, n); err != nil {
+		logger.Error("notify.fail", err)
+	}
+}
+
+// escalate triggers or resolves the incident for the job of b, if necessary.
+// An incident may have been opened before a restart, which is not known
+// after it. Therefore the incident is resolved whenever the job recovers,
+// including on its first success after a restart, and not only when it is
+// known to be open. Resolving is a no-op when there is no open incident.
+func (m *Monitor) escalate(logger lager.Logger, b atc.Build, lastStatus string, h *jobHistory) {
+	if m.escalator == nil || !m.escalation.critical(b.PipelineName) {
+		return
+	}
+	switch {
+	case b.Status == statusSucceeded && (h.Escalated || lastStatus != statusSucceeded):
+		m.resolveIncident(logger, h)
+	case m.escalation.shouldEscalate(h, time.Now()):
+		m.triggerIncident(logger, h)
+	}
+}
+
+// escalateFailing triggers incidents for all critical jobs that have been
+// failing for too long.
+func (m *Monitor) escalateFailing(logger lager.Logger) {
+	now := time.Now()
+	for _, h := range m.history {
+		if m.escalation.critical(h.LastBuild.PipelineName) && m.escalation.shouldEscalate(h, now) {
+			m.triggerIncident(logger, h)
+		}
+	}
+}
+
+func (m *Monitor) triggerIncident(logger lager.Logger, h *jobHistory) {
+	b := h.LastBuild
+	i := m.incidentFor(h)
+	i.Summary = fmt.Sprintf("Job %s from %s is failing (%d times in a row, since %s).",
+		b.JobName, b.PipelineName, h.ConsecutiveFailures, h.FailingSince.Format(time.RFC1123))
+
+	ctx := lagerctx.NewContext(context.Background(), logger)
+	if err := m.escalator.Trigger(ctx, i); err != nil {
+		logger.Error("trigger.fail", err, lager.Data{"dedup-key": i.DedupKey})
+		return
+	}
+	h.Escalated = true
+	logger.Info("triggered", lager.Data{"dedup-key": i.DedupKey})
+}
+
+func (m *Monitor) resolveIncident(logger lager.Logger, h *jobHistory) {
+	b := h.LastBuild
+	i := m.incidentFor(h)
+	i.Summary = fmt.Sprintf("Job %s from %s has recovered.", b.JobName, b.PipelineName)
+
+	ctx := lagerctx.NewContext(context.Background(), logger)
+	if err := m.escalator.Resolve(ctx, i); err != nil {
+		logger.Error("resolve.fail", err, lager.Data{"dedup-key": i.DedupKey})
+		// Retry with the next success.
+		h.Escalated = true
+		return
+	}
+	h.Escalated = false
+	logger.Info("resolved", lager.Data{"dedup-key": i.DedupKey})
 }
 
-func min(a, b int) int {
-	if a > b {
-		return a
+func (m *Monitor) incidentFor(h *jobHistory) *Incident {
+	b := h.LastBuild
+	key := jobKey{b.TeamName, b.PipelineName, b.JobName}
+	return &Incident{
+		DedupKey:      "flyontime/" + key.String(),
+		Job:           jobFromATCBuild(b),
+		DashboardLink: dashboardLink(m.pilot, b),
+		Failures:      h.ConsecutiveFailures,
+		FailingSince:  h.FailingSince,
 	}
-	return b
+}
+
+// buildEndTime returns the time at which b has finished, or the current time
+// if it is unknown.
+func buildEndTime(b atc.Build) time.Time {
+	if b.EndTime == 0 {
+		return time.Now()
+	}
+	return time.Unix(b.EndTime, 0)
 }
 
 func buildOutput(ctx context.Context, c Pilot, b atc.Build) string {
@@ -474,7 +888,10 @@ const (
 
 type jobHistory struct {
 	LastStatus          string
+	LastBuild           atc.Build
 	ConsecutiveFailures int
+	FailingSince        time.Time // Zero, unless the job is failing.
+	Escalated           bool      // Whether an incident may be open for the job.
 }
 
 type jobKey struct {
@@ -483,15 +900,25 @@ type jobKey struct {
 	Job      string
 }
 
+func (k jobKey) String() string {
+	return k.Team + "/" + k.Pipeline + "/" + k.Job
+}
+
+type handlerKey struct {
+	Scope CommandScope
+	Name  string
+}
+
 type jobStatus struct {
 	Old string
 	New string
 }
 
+func dashboardLink(concourse Pilot, b atc.Build) string {
+	return fmt.Sprintf("%s/teams/%s/pipelines/%s/jobs/%s/builds/%s", concourse.URL(), b.TeamName, b.PipelineName, b.JobName, b.Name)
+}
+
 func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
-	dashboardLink := func(b atc.Build) string {
-		return fmt.Sprintf("%s/teams/%s/pipelines/%s/jobs/%s/builds/%s", concourse.URL(), b.TeamName, b.PipelineName, b.JobName, b.Name)
-	}
 
 	// errored is used for all states that transition into errored build.
 	errored := func(ctx context.Context, b atc.Build, h *jobHistory) error {
@@ -499,7 +926,7 @@ func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
 			Severity:      SeverityError,
 			Title:         fmt.Sprintf("Job %s from %s has errored.", b.JobName, b.PipelineName),
 			Job:           jobFromATCBuild(b),
-			DashboardLink: dashboardLink(b),
+			DashboardLink: dashboardLink(concourse, b),
 		})
 	}
 
@@ -509,7 +936,7 @@ func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
 			Severity:      SeverityError,
 			Title:         fmt.Sprintf("Job %s from %s has been aborted.", b.JobName, b.PipelineName),
 			Job:           jobFromATCBuild(b),
-			DashboardLink: dashboardLink(b),
+			DashboardLink: dashboardLink(concourse, b),
 		})
 	}
 
@@ -518,7 +945,7 @@ func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
 		return n.Notify(ctx, &Notification{
 			Severity:      SeverityError,
 			Title:         fmt.Sprintf("Job %s from %s has failed.", b.JobName, b.PipelineName),
-			DashboardLink: dashboardLink(b),
+			DashboardLink: dashboardLink(concourse, b),
 			Job:           jobFromATCBuild(b),
 			JobOutput:     output,
 		})
@@ -532,7 +959,7 @@ func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
 			return n.Notify(ctx, &Notification{
 				Severity:      SeverityError,
 				Title:         fmt.Sprintf("Job %s from %s is still failing (%d times in a row).", b.JobName, b.PipelineName, h.ConsecutiveFailures),
-				DashboardLink: dashboardLink(b),
+				DashboardLink: dashboardLink(concourse, b),
 				Job:           jobFromATCBuild(b),
 				JobOutput:     output,
 			})
@@ -542,7 +969,7 @@ func defaultNotifiers(n Notifier, concourse Pilot) map[jobStatus]notifyFunc {
 				Severity:      SeverityInfo,
 				Title:         fmt.Sprintf("Job %s from %s has recovered after %d failure(s).", b.JobName, b.PipelineName, h.ConsecutiveFailures),
 				Job:           jobFromATCBuild(b),
-				DashboardLink: dashboardLink(b),
+				DashboardLink: dashboardLink(concourse, b),
 			})
 		},
 		{"", statusErrored}:              errored,
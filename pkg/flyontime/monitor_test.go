@@ -1,9 +1,16 @@
 package flyontime_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -12,6 +19,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
 
 	. "github.com/Bo0mer/flyontime/pkg/flyontime"
 	"github.com/Bo0mer/flyontime/pkg/flyontime/flyontimefakes"
@@ -24,11 +33,25 @@ var _ = Describe("Monitor", func() {
 	var pilot *flyontimefakes.FakePilot
 
 	var monitor *Monitor
+	var handlers []CommandHandler
+	var escalator *flyontimefakes.FakeEscalator
+	var escalation *EscalationPolicy
+	var watchInterval time.Duration
+	var seen *SeenBuilds
+	var saveInterval time.Duration
+	var started chan atc.Build
 
 	BeforeEach(func() {
 		commander = new(flyontimefakes.FakeCommander)
 		notifier = new(flyontimefakes.FakeNotifier)
 		pilot = new(flyontimefakes.FakePilot)
+		handlers = nil
+		escalator = new(flyontimefakes.FakeEscalator)
+		escalation = nil
+		watchInterval = 0
+		seen = nil
+		saveInterval = 0
+		started = nil
 	})
 
 	AfterEach(func() {
@@ -39,6 +62,21 @@ var _ = Describe("Monitor", func() {
 		logger := lager.NewLogger("test")
 		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
 		monitor = NewMonitor(pilot, notifier, commander, logger)
+		for _, h := range handlers {
+			Ω(monitor.Register(h)).Should(Succeed())
+		}
+		if escalation != nil {
+			monitor.Escalate(escalator, *escalation)
+		}
+		if watchInterval > 0 {
+			monitor.WatchConcourse(watchInterval)
+		}
+		if seen != nil {
+			monitor.RememberBuilds(seen, saveInterval)
+		}
+		if started != nil {
+			monitor.NotifyStarted(started)
+		}
 		go monitor.Start()
 	})
 
@@ -123,6 +161,105 @@ var _ = Describe("Monitor", func() {
 			})
 		})
 
+		Context("and it is a registered custom command", func() {
+			var handled chan *Command
+			var handleErr error
+
+			const failures = `flyontime_commands_total{command="deploy-status",outcome="failure"}`
+			var initialFailures float64
+
+			BeforeEach(func() {
+				handled = make(chan *Command, 1)
+				handleErr = nil
+				initialFailures = sample(failures)
+				handlers = append(handlers, CommandHandler{
+					Name:    "deploy-status",
+					Aliases: []string{"ds"},
+					Scope:   ReplyScope,
+					Help:    "Show deployment status.",
+					Handle: func(p Pilot, c *Command) error {
+						defer close(c.Responses)
+						handled <- c
+						c.Responses <- "all good"
+						return handleErr
+					},
+				})
+				c.Name = "ds"
+				c.Args = []string{"prod"}
+				c.Job = &Job{Name: "j1"}
+			})
+
+			JustBeforeEach(func() {
+				commands <- c
+			})
+
+			It("should invoke the handler with the canonical name", func() {
+				var hc *Command
+				Eventually(handled).Should(Receive(&hc))
+				Ω(hc.Name).Should(Equal("deploy-status"))
+				Ω(hc.Args).Should(Equal([]string{"prod"}))
+			})
+
+			It("should reply with the handler responses", func() {
+				var resp string
+				Eventually(responses).Should(Receive(&resp))
+				Ω(resp).Should(Equal("all good"))
+			})
+
+			Context("and the handler fails", func() {
+				BeforeEach(func() {
+					handleErr = errors.New("deployment unknown")
+				})
+
+				It("should count the command as failed", func() {
+					Eventually(func() float64 { return sample(failures) - initialFailures }).Should(BeNumerically("==", 1))
+				})
+			})
+
+			Context("but it is sent outside of its scope", func() {
+				BeforeEach(func() {
+					c.Job = nil
+				})
+
+				It("should reply with 'unknown command'", func() {
+					var resp string
+					Eventually(responses).Should(Receive(&resp))
+					Ω(resp).Should(ContainSubstring("Unknown command"))
+				})
+			})
+		})
+
+		Context("and it is a multi-word alias of rerun", func() {
+			BeforeEach(func() {
+				c.Name = "try"
+				c.Args = []string{"again"}
+				c.Job = &Job{
+					Team:     "t1",
+					Pipeline: "p1",
+					Name:     "j1",
+				}
+				commands <- c
+			})
+
+			It("should rerun the job", func() {
+				Eventually(pilot.CreateJobBuildCallCount).Should(Equal(1))
+			})
+		})
+
+		Context("and it is help", func() {
+			BeforeEach(func() {
+				c.Name = "help"
+				commands <- c
+			})
+
+			It("should list the commands with their aliases", func() {
+				var resp string
+				Eventually(responses).Should(Receive(&resp))
+				Ω(resp).Should(ContainSubstring("*pause*, *stop* <pipeline>"))
+				Ω(resp).Should(ContainSubstring("*rerun*, *retry*, *try again*"))
+			})
+		})
+
 		Context("and it is mute", func() {
 			BeforeEach(func() {
 				c.Name = "mute"
@@ -141,6 +278,11 @@ var _ = Describe("Monitor", func() {
 				Ω(resp).Should(ContainSubstring("Muted notifications for j1 until"))
 			})
 
+			It("should count the job as muted until the mute expires", func() {
+				Eventually(monitor.MutedJobs).Should(Equal(1))
+				Eventually(monitor.MutedJobs).Should(Equal(0))
+			})
+
 			Context("and the job should trigger notification again", func() {
 				BeforeEach(func() {
 					time.AfterFunc(5*time.Millisecond*durationScaleFactor, func() {
@@ -180,6 +322,47 @@ var _ = Describe("Monitor", func() {
 			})
 		})
 
+		Context("and it is mute with the duration given as a flag", func() {
+			BeforeEach(func() {
+				c.Name = "mute"
+				c.Flags = map[string]string{"for": "1h"}
+				c.Job = &Job{
+					Team:     "t1",
+					Pipeline: "p1",
+					Name:     "j1",
+				}
+				commands <- c
+			})
+
+			It("should mute the job for that duration", func() {
+				var resp string
+				Eventually(responses).Should(Receive(&resp))
+				Ω(resp).Should(ContainSubstring("Muted notifications for j1 until"))
+				Consistently(monitor.MutedJobs, 50*time.Millisecond*durationScaleFactor).Should(Equal(1))
+			})
+		})
+
+		Context("and it has a flag that the command does not accept", func() {
+			BeforeEach(func() {
+				c.Name = "mute"
+				c.Flags = map[string]string{"until": "5pm"}
+				c.Job = &Job{
+					Team:     "t1",
+					Pipeline: "p1",
+					Name:     "j1",
+				}
+				commands <- c
+			})
+
+			It("should reply with the usage of the command", func() {
+				var resp string
+				Eventually(responses).Should(Receive(&resp))
+				Ω(resp).Should(ContainSubstring("unknown flag --until"))
+				Ω(resp).Should(ContainSubstring("*mute*, *silence* [duration] [--for=<duration>]"))
+				Ω(monitor.MutedJobs()).Should(Equal(0))
+			})
+		})
+
 		Context("and it is unmute", func() {
 			Context("and the job was previously muted", func() {
 				var j *Job
@@ -505,6 +688,137 @@ var _ = Describe("Monitor", func() {
 			Entry("aborted -> errored", build("errored"), build("aborted"), 2),
 			Entry("aborted -> failed", build("errored"), build("aborted"), 2),
 		)
+
+		DescribeTable("Failing Jobs",
+			func(b1, b2 atc.Build, failing int) {
+				builds <- b1
+				builds <- b2
+
+				Eventually(monitor.FailingJobs).Should(Equal(failing))
+				Consistently(monitor.FailingJobs).Should(Equal(failing))
+			},
+			Entry("succeeded -> failed", build("succeeded"), build("failed"), 1),
+			Entry("failed -> failed", build("failed"), build("failed"), 1),
+			Entry("failed -> errored", build("failed"), build("errored"), 0),
+			Entry("failed -> succeeded", build("failed"), build("succeeded"), 0),
+		)
+	})
+
+	Context("when escalation is configured", func() {
+		var builds chan atc.Build
+
+		BeforeEach(func() {
+			builds = make(chan atc.Build, 10)
+			pilot.FinishedBuildsReturns(builds)
+			pilot.URLReturns("http://concourse")
+			escalation = &EscalationPolicy{
+				Pipelines:           []string{"prod-*"},
+				ConsecutiveFailures: 2,
+			}
+		})
+
+		criticalBuild := func(status string) atc.Build {
+			lastBuildID++
+			return atc.Build{
+				ID:           lastBuildID,
+				Name:         "1",
+				TeamName:     "t1",
+				PipelineName: "prod-deploy",
+				JobName:      "j1",
+				Status:       status,
+			}
+		}
+
+		Context("and a critical job fails less times than the threshold", func() {
+			BeforeEach(func() {
+				builds <- criticalBuild("failed")
+			})
+
+			It("should not trigger an incident", func() {
+				Consistently(escalator.TriggerCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("and a critical job fails consecutively", func() {
+			BeforeEach(func() {
+				builds <- criticalBuild("failed")
+				builds <- criticalBuild("failed")
+				builds <- criticalBuild("failed")
+			})
+
+			It("should trigger a single incident", func() {
+				Eventually(escalator.TriggerCallCount).Should(Equal(1))
+				Consistently(escalator.TriggerCallCount).Should(Equal(1))
+
+				_, i := escalator.TriggerArgsForCall(0)
+				Ω(i.DedupKey).Should(Equal("flyontime/t1/prod-deploy/j1"))
+				Ω(i.Job).Should(Equal(Job{Team: "t1", Pipeline: "prod-deploy", Name: "j1"}))
+				Ω(i.Failures).Should(Equal(2))
+				Ω(i.DashboardLink).Should(Equal("http://concourse/teams/t1/pipelines/prod-deploy/jobs/j1/builds/1"))
+			})
+
+			Context("and then recovers", func() {
+				BeforeEach(func() {
+					builds <- criticalBuild("succeeded")
+				})
+
+				It("should resolve the incident", func() {
+					Eventually(escalator.ResolveCallCount).Should(Equal(1))
+					_, i := escalator.ResolveArgsForCall(0)
+					Ω(i.DedupKey).Should(Equal("flyontime/t1/prod-deploy/j1"))
+				})
+			})
+
+			Context("but triggering the incident fails", func() {
+				BeforeEach(func() {
+					escalator.TriggerReturnsOnCall(0, errors.New("pager down"))
+				})
+
+				It("should retry with the next failure", func() {
+					Eventually(escalator.TriggerCallCount).Should(Equal(2))
+				})
+			})
+		})
+
+		Context("and a critical job succeeds for the first time since the start", func() {
+			BeforeEach(func() {
+				builds <- criticalBuild("succeeded")
+				builds <- criticalBuild("succeeded")
+			})
+
+			It("should resolve any incident opened before a restart once", func() {
+				Eventually(escalator.ResolveCallCount).Should(Equal(1))
+				Consistently(escalator.ResolveCallCount).Should(Equal(1))
+				_, i := escalator.ResolveArgsForCall(0)
+				Ω(i.DedupKey).Should(Equal("flyontime/t1/prod-deploy/j1"))
+			})
+		})
+
+		Context("and a job from a non-critical pipeline fails consecutively", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					b := criticalBuild("failed")
+					b.PipelineName = "staging-deploy"
+					builds <- b
+				}
+			})
+
+			It("should not trigger an incident", func() {
+				Consistently(escalator.TriggerCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("and a critical job has been failing for too long", func() {
+			BeforeEach(func() {
+				escalation.ConsecutiveFailures = 0
+				escalation.FailingFor = 20 * time.Millisecond * durationScaleFactor
+				builds <- criticalBuild("failed")
+			})
+
+			It("should trigger an incident without waiting for new builds", func() {
+				Eventually(escalator.TriggerCallCount).Should(Equal(1))
+			})
+		})
 	})
 
 	Context("when a build fails", func() {
@@ -551,11 +865,302 @@ var _ = Describe("Monitor", func() {
 			})
 		})
 	})
+
+	Context("when watching Concourse", func() {
+		BeforeEach(func() {
+			watchInterval = 5 * time.Millisecond * durationScaleFactor
+			pilot.URLReturns("https://ci.example.com")
+		})
+
+		Context("and it cannot be reached", func() {
+			var health *healthResult
+			BeforeEach(func() {
+				health = &healthResult{err: errors.New("no successful poll for 1m0s")}
+				pilot.HealthStub = health.get
+			})
+
+			It("should send a single warning", func() {
+				Eventually(notifier.NotifyCallCount).Should(Equal(1))
+				Consistently(notifier.NotifyCallCount).Should(Equal(1))
+				_, n := notifier.NotifyArgsForCall(0)
+				Ω(n.Severity).Should(Equal(SeverityWarn))
+				Ω(n.Title).Should(Equal("Cannot reach Concourse: no successful poll for 1m0s."))
+				Ω(n.DashboardLink).Should(Equal("https://ci.example.com"))
+			})
+
+			Context("and then it can be reached again", func() {
+				JustBeforeEach(func() {
+					Eventually(notifier.NotifyCallCount).Should(Equal(1))
+					health.set(nil)
+				})
+
+				It("should send a recovery notification", func() {
+					Eventually(notifier.NotifyCallCount).Should(Equal(2))
+					_, n := notifier.NotifyArgsForCall(1)
+					Ω(n.Severity).Should(Equal(SeverityInfo))
+					Ω(n.Title).Should(Equal("Concourse can be reached again."))
+				})
+			})
+		})
+
+		Context("and it can be reached", func() {
+			It("should not send any notifications", func() {
+				Eventually(pilot.HealthCallCount).Should(BeNumerically(">", 1))
+				Consistently(notifier.NotifyCallCount).Should(Equal(0))
+			})
+		})
+	})
+
+	Context("when builds start and started builds are notified", func() {
+		BeforeEach(func() {
+			started = make(chan atc.Build, 2)
+			pilot.URLReturns("https://ci.example.com")
+		})
+
+		It("should send a notification for the job of each build", func() {
+			started <- atc.Build{ID: 1, Status: "started", TeamName: "t1", PipelineName: "p1", JobName: "j1"}
+			Eventually(notifier.NotifyCallCount).Should(Equal(1))
+			_, n := notifier.NotifyArgsForCall(0)
+			Ω(n.Severity).Should(Equal(SeverityInfo))
+			Ω(n.Title).Should(Equal("Job j1 from p1 has started."))
+			Ω(n.Job).Should(Equal(Job{Team: "t1", Pipeline: "p1", Name: "j1"}))
+		})
+
+		It("should not send notifications for one-off builds", func() {
+			started <- atc.Build{ID: 1, Status: "started", TeamName: "t1"}
+			Consistently(notifier.NotifyCallCount).Should(Equal(0))
+		})
+
+		Context("and the job is muted", func() {
+			BeforeEach(func() {
+				commands := make(chan *Command, 1)
+				commands <- &Command{
+					Name:      "mute",
+					Job:       &Job{Team: "t1", Pipeline: "p1", Name: "j1"},
+					Responses: make(chan string, 1),
+				}
+				commander.CommandsReturns(commands)
+			})
+
+			It("should not send any notification", func() {
+				Eventually(monitor.MutedJobs).Should(Equal(1))
+				started <- atc.Build{ID: 1, Status: "started", TeamName: "t1", PipelineName: "p1", JobName: "j1"}
+				Consistently(notifier.NotifyCallCount).Should(Equal(0))
+			})
+		})
+	})
+
+	Context("when a build is delivered more than once", func() {
+		var builds chan atc.Build
+		var b atc.Build
+
+		BeforeEach(func() {
+			builds = make(chan atc.Build, 3)
+			pilot.FinishedBuildsReturns(builds)
+			b = atc.Build{
+				ID:           4242,
+				TeamName:     "t1",
+				PipelineName: "p1",
+				JobName:      "dedup-job",
+				Status:       "failed",
+			}
+		})
+
+		It("should handle it only once", func() {
+			builds <- b
+			builds <- b
+			builds <- b
+
+			Eventually(notifier.NotifyCallCount).Should(Equal(1))
+			Consistently(notifier.NotifyCallCount).Should(Equal(1))
+			Ω(monitor.FailingJobs()).Should(Equal(1))
+		})
+
+		It("should still handle the next build of the job", func() {
+			builds <- b
+			builds <- b
+			next := b
+			next.ID++
+			builds <- next
+
+			// Failed -> failed.
+			Eventually(notifier.NotifyCallCount).Should(Equal(2))
+			Consistently(notifier.NotifyCallCount).Should(Equal(2))
+		})
+
+		Context("and it has been handled before a restart", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "flyontime")
+				Ω(err).ShouldNot(HaveOccurred())
+				file := filepath.Join(dir, "seen.json")
+				Ω(ioutil.WriteFile(file, []byte("[4242]"), 0600)).Should(Succeed())
+				seen, err = LoadSeenBuilds(file, 10)
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should not handle it again", func() {
+				builds <- b
+				Consistently(notifier.NotifyCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("and the handled builds are remembered in a file", func() {
+			var dir, file string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "flyontime")
+				Ω(err).ShouldNot(HaveOccurred())
+				file = filepath.Join(dir, "seen.json")
+				seen, err = LoadSeenBuilds(file, 10)
+				Ω(err).ShouldNot(HaveOccurred())
+				saveInterval = 10 * time.Millisecond
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should save them periodically", func() {
+				builds <- b
+				Eventually(func() string {
+					data, _ := ioutil.ReadFile(file)
+					return string(data)
+				}).Should(Equal("[4242]"))
+			})
+
+			Context("but not in a while", func() {
+				BeforeEach(func() {
+					saveInterval = time.Hour
+				})
+
+				It("should save them by the time the monitor is stopped", func() {
+					builds <- b
+					Eventually(seen.Len).Should(Equal(1))
+					monitor.Stop()
+					data, err := ioutil.ReadFile(file)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(data)).Should(Equal("[4242]"))
+				})
+			})
+		})
+	})
+
+	Context("when the builds channel is closed", func() {
+		var commands chan *Command
+		BeforeEach(func() {
+			builds := make(chan atc.Build)
+			close(builds)
+			pilot.FinishedBuildsReturns(builds)
+			commands = make(chan *Command, 1)
+			commander.CommandsReturns(commands)
+		})
+
+		It("should keep handling commands", func() {
+			responses := make(chan string, 1)
+			commands <- &Command{Name: "help", Responses: responses}
+			Eventually(responses).Should(Receive())
+		})
+	})
+
+	Context("when builds finish", func() {
+		var builds chan atc.Build
+		BeforeEach(func() {
+			builds = make(chan atc.Build, 2)
+			pilot.FinishedBuildsReturns(builds)
+
+			b := atc.Build{
+				TeamName:     "metrics-team",
+				PipelineName: "p",
+				JobName:      "j",
+				Status:       "failed",
+				StartTime:    100,
+				EndTime:      145,
+			}
+			b.ID = 1
+			builds <- b
+			b.ID = 2
+			builds <- b
+		})
+
+		const durationSum = `concourse_build_duration_seconds_sum{job="j",pipeline="p",team="metrics-team"}`
+
+		var initialDurationSum float64
+		BeforeEach(func() {
+			// Metrics are global and outlive the spec, hence counters and
+			// histograms are asserted relative to their initial values.
+			initialDurationSum = sample(durationSum)
+		})
+
+		It("should expose the job status, consecutive failures and build duration", func() {
+			Eventually(exposition).Should(ContainSubstring(`concourse_job_consecutive_failures{job="j",pipeline="p",team="metrics-team"} 2`))
+			Ω(exposition()).Should(ContainSubstring(`concourse_job_last_status{job="j",pipeline="p",status="failed",team="metrics-team"} 1`))
+			Ω(exposition()).Should(ContainSubstring(`concourse_job_last_status{job="j",pipeline="p",status="succeeded",team="metrics-team"} 0`))
+			Eventually(func() float64 { return sample(durationSum) - initialDurationSum }).Should(BeNumerically("==", 90))
+		})
+	})
 })
 
+// exposition returns the metrics in the default registry in the text
+// exposition format.
+func exposition() string {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	Ω(err).ShouldNot(HaveOccurred())
+	var b bytes.Buffer
+	for _, mf := range mfs {
+		_, err := expfmt.MetricFamilyToText(&b, mf)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return b.String()
+}
+
+// sample returns the value of the sample with the given name and labels, or
+// 0 if there is none yet.
+func sample(series string) float64 {
+	for _, line := range strings.Split(exposition(), "\n") {
+		if strings.HasPrefix(line, series+" ") {
+			v, err := strconv.ParseFloat(strings.TrimPrefix(line, series+" "), 64)
+			Ω(err).ShouldNot(HaveOccurred())
+			return v
+		}
+	}
+	return 0
+}
+
+var lastBuildID int
+
+// build returns a new build of the same job, which is handled only once.
 func build(status string) atc.Build {
+	lastBuildID++
 	return atc.Build{
+		ID:      lastBuildID,
 		JobName: "job",
 		Status:  status,
 	}
 }
+
+// healthResult is the result of Health of a fake pilot, which is changed
+// while the monitor calls it.
+type healthResult struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (h *healthResult) get() (map[string]interface{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return nil, h.err
+}
+
+func (h *healthResult) set(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.err = err
+}
@@ -2,6 +2,8 @@ package flyontime
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/concourse/atc"
 )
@@ -32,8 +34,16 @@ type Notification struct {
 	Severity      Severity
 	Title         string
 	DashboardLink string
-	Job           Job
-	JobOutput     string
+	// Job is the zero Job for notifications which are not about a job,
+	// e.g. that Concourse cannot be reached. See HasJob.
+	Job       Job
+	JobOutput string
+}
+
+// HasJob reports whether n is about a job. Replies to notifications are
+// commands for their job, hence those without one should not accept replies.
+func (n *Notification) HasJob() bool {
+	return n.Job != (Job{})
 }
 
 //go:generate counterfeiter . Notifier
@@ -41,3 +51,21 @@ type Notification struct {
 type Notifier interface {
 	Notify(ctx context.Context, n *Notification) error
 }
+
+// MultiNotifier sends each notification via all of its notifiers.
+type MultiNotifier []Notifier
+
+// Notify sends n via all notifiers, even if some of them fail. The returned
+// error, if any, describes all failures.
+func (mn MultiNotifier) Notify(ctx context.Context, n *Notification) error {
+	var errs []string
+	for _, notifier := range mn {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
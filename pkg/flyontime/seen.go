@@ -0,0 +1,156 @@
+package flyontime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultSeenBuilds is the number of build IDs remembered by default.
+const DefaultSeenBuilds = 10000
+
+// DefaultSeenBuildsSaveInterval is how often remembered builds are saved by
+// default, see Monitor.RememberBuilds.
+const DefaultSeenBuildsSaveInterval = 10 * time.Second
+
+// SeenBuilds is a bounded set of the IDs of handled builds, used in order to
+// handle each build once, even if it is delivered again. Once full, the
+// oldest IDs are forgotten first. It is safe for concurrent use.
+type SeenBuilds struct {
+	mu    sync.Mutex
+	size  int
+	file  string
+	ids   map[int]bool
+	order []int // oldest first
+	dirty bool  // whether IDs were added since the last save
+}
+
+// NewSeenBuilds returns an empty set which remembers up to size IDs.
+func NewSeenBuilds(size int) *SeenBuilds {
+	if size <= 0 {
+		size = DefaultSeenBuilds
+	}
+	return &SeenBuilds{
+		size: size,
+		ids:  make(map[int]bool),
+	}
+}
+
+// LoadSeenBuilds returns a set which remembers up to size IDs, loaded from
+// file, to which it is saved by Save. The set is empty if file does not exist
+// yet.
+func LoadSeenBuilds(file string, size int) (*SeenBuilds, error) {
+	s := NewSeenBuilds(size)
+	s.file = file
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add adds id to the set. It returns false if id is already in the set.
+func (s *SeenBuilds) Add(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.add(id) {
+		return false
+	}
+	s.dirty = true
+	return true
+}
+
+func (s *SeenBuilds) add(id int) bool {
+	if s.ids[id] {
+		return false
+	}
+	if len(s.order) >= s.size {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.ids[id] = true
+	s.order = append(s.order, id)
+	return true
+}
+
+// Len returns the number of IDs in the set.
+func (s *SeenBuilds) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.order)
+}
+
+// Save saves the set to the file it was loaded from, if any, unless no IDs
+// were added since the last save. The file is replaced atomically, so that it
+// is never left partially written.
+func (s *SeenBuilds) Save() (err error) {
+	s.mu.Lock()
+	if s.file == "" || !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(s.order)
+	s.dirty = false
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			// Try again on the next save.
+			s.mu.Lock()
+			s.dirty = true
+			s.mu.Unlock()
+		}
+	}()
+
+	f, err := ioutil.TempFile(filepath.Dir(s.file), filepath.Base(s.file))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.file)
+}
+
+// MarshalJSON encodes the set as a list of IDs, oldest first.
+func (s *SeenBuilds) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Marshal(s.order)
+}
+
+// UnmarshalJSON adds the IDs of a list encoded by MarshalJSON to the set.
+func (s *SeenBuilds) UnmarshalJSON(data []byte) error {
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ids == nil {
+		s.ids = make(map[int]bool)
+	}
+	if s.size <= 0 {
+		s.size = DefaultSeenBuilds
+	}
+	for _, id := range ids {
+		s.add(id)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package flyontime_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+var _ = Describe("SeenBuilds", func() {
+	var seen *SeenBuilds
+
+	BeforeEach(func() {
+		seen = NewSeenBuilds(3)
+	})
+
+	It("should add each build only once", func() {
+		Ω(seen.Add(1)).Should(BeTrue())
+		Ω(seen.Add(2)).Should(BeTrue())
+		Ω(seen.Add(1)).Should(BeFalse())
+		Ω(seen.Len()).Should(Equal(2))
+	})
+
+	Context("when it is full", func() {
+		BeforeEach(func() {
+			seen.Add(1)
+			seen.Add(2)
+			seen.Add(3)
+		})
+
+		It("should forget the oldest builds first", func() {
+			Ω(seen.Add(4)).Should(BeTrue())
+			Ω(seen.Len()).Should(Equal(3))
+			Ω(seen.Add(2)).Should(BeFalse())
+			Ω(seen.Add(1)).Should(BeTrue())
+		})
+	})
+
+	Context("when it is loaded from a file", func() {
+		var dir, file string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "flyontime")
+			Ω(err).ShouldNot(HaveOccurred())
+			file = filepath.Join(dir, "seen.json")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		Context("which does not exist yet", func() {
+			It("should be empty", func() {
+				seen, err := LoadSeenBuilds(file, 3)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(seen.Len()).Should(Equal(0))
+			})
+		})
+
+		Context("which is invalid", func() {
+			BeforeEach(func() {
+				Ω(ioutil.WriteFile(file, []byte("{"), 0600)).Should(Succeed())
+			})
+
+			It("should fail", func() {
+				_, err := LoadSeenBuilds(file, 3)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+
+		It("should remember the builds saved to it", func() {
+			seen, err := LoadSeenBuilds(file, 3)
+			Ω(err).ShouldNot(HaveOccurred())
+			for id := 1; id <= 4; id++ {
+				seen.Add(id)
+			}
+			Ω(seen.Save()).Should(Succeed())
+
+			seen, err = LoadSeenBuilds(file, 3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(seen.Len()).Should(Equal(3))
+			Ω(seen.Add(4)).Should(BeFalse())
+			Ω(seen.Add(1)).Should(BeTrue())
+		})
+
+		It("should not save it again until builds are added", func() {
+			seen, err := LoadSeenBuilds(file, 3)
+			Ω(err).ShouldNot(HaveOccurred())
+			seen.Add(1)
+			Ω(seen.Save()).Should(Succeed())
+			Ω(os.Remove(file)).Should(Succeed())
+
+			Ω(seen.Save()).Should(Succeed())
+			Ω(file).ShouldNot(BeAnExistingFile())
+
+			seen.Add(1)
+			Ω(seen.Save()).Should(Succeed())
+			Ω(file).ShouldNot(BeAnExistingFile())
+
+			seen.Add(2)
+			Ω(seen.Save()).Should(Succeed())
+			Ω(ioutil.ReadFile(file)).Should(MatchJSON("[1,2]"))
+		})
+
+		It("should keep only the newest builds if it is smaller", func() {
+			Ω(ioutil.WriteFile(file, []byte("[1,2,3]"), 0600)).Should(Succeed())
+			seen, err := LoadSeenBuilds(file, 2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(seen.Add(3)).Should(BeFalse())
+			Ω(seen.Add(1)).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package flyontime
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+// follow follows the events of the running build b and sends it to finished
+// once it finishes. If the events cannot be followed, the build is left to be
+// polled.
+func (p *AutoPilot) follow(ctx context.Context, logger lager.Logger, b atc.Build, finished chan<- atc.Build) {
+	events, err := p.BuildEvents(strconv.Itoa(b.ID))
+	if err != nil {
+		logger.Error("get-build-events.fail-will-poll", err)
+		return
+	}
+	// NextEvent blocks, hence the events are closed in order to stop
+	// following them.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		events.Close()
+	}()
+
+	for {
+		ev, err := events.NextEvent()
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				logger.Error("next-event.fail-will-poll", err)
+			}
+			return
+		}
+		if p.OnEvent != nil {
+			p.OnEvent(b, ev)
+		}
+		s, ok := ev.(event.Status)
+		if !ok || !isFinished(s.Status) {
+			continue
+		}
+
+		logger.Info("finished", lager.Data{"status": s.Status})
+		select {
+		case finished <- p.finishedBuild(logger, b, s):
+		case <-ctx.Done():
+		}
+		return
+	}
+}
+
+// finishedBuild returns the build b, which has finished according to s.
+func (p *AutoPilot) finishedBuild(logger lager.Logger, b atc.Build, s event.Status) atc.Build {
+	latest, found, err := p.Build(strconv.Itoa(b.ID))
+	if err == nil && found && !latest.IsRunning() {
+		return latest
+	}
+	if err != nil {
+		logger.Error("get-build.fail", err)
+	}
+	// The build is not updated yet, or could not be retrieved.
+	b.Status = string(s.Status)
+	b.EndTime = s.Time
+	return b
+}
+
+// SendStarted returns a function to be used as AutoPilot.OnEvent, which sends
+// each followed build to started once it starts. Builds are dropped while
+// started is full, so that following the events of others is not held up.
+func SendStarted(started chan<- atc.Build) func(atc.Build, atc.Event) {
+	return func(b atc.Build, e atc.Event) {
+		s, ok := e.(event.Status)
+		if !ok || s.Status != atc.StatusStarted {
+			return
+		}
+		b.Status = string(s.Status)
+		b.StartTime = s.Time
+		select {
+		case started <- b:
+		default:
+		}
+	}
+}
+
+func isFinished(s atc.BuildStatus) bool {
+	switch s {
+	case atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored, atc.StatusAborted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Package health implements HTTP endpoints reporting the health of the
+// components of flyontime, suitable for liveness and readiness probes.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Check reports the health of a component. It returns details about the state
+// of the component and a non-nil error if the component is unhealthy.
+type Check func() (details map[string]interface{}, err error)
+
+// Status is the result of a single check.
+type Status struct {
+	Status  string                 `json:"status"` // either "ok" or "fail"
+	Error   string                 `json:"error,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the result of all checks.
+type Report struct {
+	Status string            `json:"status"` // "fail" if any check fails
+	Checks map[string]Status `json:"checks"`
+}
+
+// Checks is a set of named checks served as an HTTP endpoint. It is safe for
+// concurrent use. The zero value is an empty set, which is always healthy.
+type Checks struct {
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// Add adds check to the set under name, replacing any check with the same
+// name.
+func (c *Checks) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checks == nil {
+		c.checks = make(map[string]Check)
+	}
+	c.checks[name] = check
+}
+
+// Run runs all checks.
+func (c *Checks) Run() Report {
+	c.mu.Lock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.Unlock()
+
+	r := Report{Status: "ok", Checks: make(map[string]Status, len(checks))}
+	for name, check := range checks {
+		details, err := check()
+		s := Status{Status: "ok", Details: details}
+		if err != nil {
+			s.Status, s.Error = "fail", err.Error()
+			r.Status = "fail"
+		}
+		r.Checks[name] = s
+	}
+	return r
+}
+
+// ServeHTTP responds with the JSON encoded report of all checks. The status
+// code is 503 if any of them fails.
+func (c *Checks) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r := c.Run()
+	w.Header().Set("Content-Type", "application/json")
+	if r.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(r)
+}
@@ -0,0 +1,73 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/health"
+)
+
+var _ = Describe("Checks", func() {
+	var checks *Checks
+
+	BeforeEach(func() {
+		checks = &Checks{}
+	})
+
+	serve := func() (int, Report) {
+		rec := httptest.NewRecorder()
+		checks.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+		Ω(rec.Header().Get("Content-Type")).Should(Equal("application/json"))
+		var r Report
+		Ω(json.Unmarshal(rec.Body.Bytes(), &r)).Should(Succeed())
+		return rec.Code, r
+	}
+
+	It("should be healthy when there are no checks", func() {
+		code, r := serve()
+		Ω(code).Should(Equal(http.StatusOK))
+		Ω(r.Status).Should(Equal("ok"))
+	})
+
+	Context("when all checks pass", func() {
+		BeforeEach(func() {
+			checks.Add("concourse", func() (map[string]interface{}, error) {
+				return map[string]interface{}{"polls": 3}, nil
+			})
+		})
+
+		It("should respond with ok and the details of each check", func() {
+			code, r := serve()
+			Ω(code).Should(Equal(http.StatusOK))
+			Ω(r.Status).Should(Equal("ok"))
+			Ω(r.Checks).Should(HaveKey("concourse"))
+			Ω(r.Checks["concourse"].Status).Should(Equal("ok"))
+			Ω(r.Checks["concourse"].Details).Should(HaveKeyWithValue("polls", BeNumerically("==", 3)))
+		})
+	})
+
+	Context("when a check fails", func() {
+		BeforeEach(func() {
+			checks.Add("concourse", func() (map[string]interface{}, error) {
+				return nil, nil
+			})
+			checks.Add("chat", func() (map[string]interface{}, error) {
+				return map[string]interface{}{"connected": false}, errors.New("boom")
+			})
+		})
+
+		It("should respond with service unavailable and the error", func() {
+			code, r := serve()
+			Ω(code).Should(Equal(http.StatusServiceUnavailable))
+			Ω(r.Status).Should(Equal("fail"))
+			Ω(r.Checks["concourse"].Status).Should(Equal("ok"))
+			Ω(r.Checks["chat"].Status).Should(Equal("fail"))
+			Ω(r.Checks["chat"].Error).Should(Equal("boom"))
+		})
+	})
+})
@@ -0,0 +1,312 @@
+// Package matrix implements a Matrix bot that sends notifications to a room
+// and accepts commands as replies to them or mentions.
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/lunixbochs/vtclean"
+	"github.com/pkg/errors"
+)
+
+// DefaultSyncTimeout is the default duration for which the homeserver holds
+// sync requests when there are no new events.
+const DefaultSyncTimeout = 30 * time.Second
+
+// maxOutput is the maximum length of job output included in notifications.
+// Homeservers reject events over 65536 bytes, and each character of the
+// output could take up to 10 bytes once escaped as HTML and then as JSON.
+const maxOutput = 4000
+
+type Notifier struct {
+	Homeserver  string // Base URL of the homeserver, e.g. https://matrix.org.
+	AccessToken string
+	RoomID      string
+	SyncTimeout time.Duration
+	Client      *http.Client
+	Retention   time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect   backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
+	Logger      lager.Logger
+
+	initOnce sync.Once
+	login    chat.Init // obtains the bot user
+	conn     chat.Connection
+	userID   string
+	txnID    int64
+
+	router chat.Router
+	events chat.Registry // maps event id to notification
+}
+
+// setup applies the defaults.
+func (mx *Notifier) setup() {
+	mx.initOnce.Do(func() {
+		mx.conn.Reconnect = mx.Reconnect
+		mx.events.Name = "matrix_events"
+		mx.events.TTL = mx.Retention
+		if mx.SyncTimeout == 0 {
+			mx.SyncTimeout = DefaultSyncTimeout
+		}
+		if mx.Client == nil {
+			mx.Client = &http.Client{Timeout: mx.SyncTimeout + 30*time.Second}
+		}
+		if mx.Logger == nil {
+			mx.Logger = lager.NewLogger("")
+		}
+	})
+}
+
+func (mx *Notifier) init() error {
+	mx.setup()
+	return mx.login.Do(func() error {
+		var whoami struct {
+			UserID string `json:"user_id"`
+		}
+		if err := mx.do(context.Background(), http.MethodGet, "/account/whoami", nil, nil, &whoami); err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		mx.userID = whoami.UserID
+		return nil
+	})
+}
+
+func (mx *Notifier) Commands() <-chan *flyontime.Command {
+	mx.setup()
+	logger := mx.Logger.Session("commands")
+
+	var since string
+	go mx.conn.Run(logger, func() error {
+		if err := mx.init(); err != nil {
+			return err
+		}
+		for {
+			timeout := mx.SyncTimeout
+			if since == "" {
+				// Do not wait for new events on the initial sync.
+				timeout = 0
+			}
+			resp, err := mx.sync(since, timeout)
+			if err != nil {
+				return errors.Wrap(err, "error syncing")
+			}
+			mx.conn.Up()
+			if since != "" {
+				// Events from the initial sync are old, skip them.
+				for _, ev := range resp.Rooms.Join[mx.RoomID].Timeline.Events {
+					mx.handleEvent(logger, ev)
+				}
+			}
+			since = resp.NextBatch
+		}
+	})
+
+	return mx.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to the Matrix
+// homeserver.
+func (mx *Notifier) Health() (map[string]interface{}, error) {
+	return mx.conn.Health(mx.init)
+}
+
+func (mx *Notifier) sync(since string, timeout time.Duration) (*syncResponse, error) {
+	q := url.Values{}
+	q.Set("timeout", fmt.Sprint(int64(timeout/time.Millisecond)))
+	if since != "" {
+		q.Set("since", since)
+	}
+	var resp syncResponse
+	err := mx.do(context.Background(), http.MethodGet, "/sync", q, nil, &resp)
+	return &resp, err
+}
+
+func (mx *Notifier) handleEvent(logger lager.Logger, ev event) {
+	if ev.Type != "m.room.message" || ev.Sender == mx.userID {
+		return
+	}
+
+	if rel := ev.Content.RelatesTo; rel != nil && rel.InReplyTo != nil {
+		if n, ok := mx.events.Get(rel.InReplyTo.EventID); ok {
+			mx.router.Route(logger.Session("handle-reply"), stripReplyFallback(ev.Content.Body), &n.Job, mx.replyTo(ev.EventID))
+			return
+		}
+	}
+
+	if text, ok := mx.stripMention(ev.Content.Body); ok {
+		mx.router.Route(logger.Session("handle-mention"), text, nil, mx.replyTo(ev.EventID))
+	}
+}
+
+// stripMention returns the text following the mention of the bot, if the
+// body starts with one. The bot could be mentioned either by its full user
+// id or by its local part, e.g. "@flyontime:matrix.org" or "flyontime:".
+func (mx *Notifier) stripMention(body string) (string, bool) {
+	localpart := strings.SplitN(strings.TrimPrefix(mx.userID, "@"), ":", 2)[0]
+	for _, mention := range []string{mx.userID, localpart + ":", "@" + localpart} {
+		if text, ok := chat.StripMention(body, mention); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// stripReplyFallback removes the quote of the original message, which
+// clients prepend to the body of replies.
+func stripReplyFallback(body string) string {
+	lines := strings.Split(body, "\n")
+	i := 0
+	for i < len(lines) && strings.HasPrefix(lines[i], ">") {
+		i++
+	}
+	return strings.Join(lines[i:], "\n")
+}
+
+func (mx *Notifier) replyTo(eventID string) chat.Reply {
+	return func(reply string) error {
+		_, err := mx.send(context.Background(), &content{
+			MsgType: "m.notice",
+			Body:    reply,
+			RelatesTo: &relation{
+				InReplyTo: &inReplyTo{EventID: eventID},
+			},
+		})
+		return err
+	}
+}
+
+func (mx *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := mx.init(); err != nil {
+		return err
+	}
+
+	body := n.Title
+	if n.DashboardLink != "" {
+		body = fmt.Sprintf("%s\n%s", body, n.DashboardLink)
+	}
+	eventID, err := mx.send(ctx, &content{
+		MsgType:       "m.notice",
+		Body:          body,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatHTML(n),
+	})
+	if err != nil {
+		return err
+	}
+
+	if n.HasJob() {
+		mx.events.Put(eventID, n)
+	}
+	return nil
+}
+
+func (mx *Notifier) send(ctx context.Context, c *content) (eventID string, err error) {
+	txnID := atomic.AddInt64(&mx.txnID, 1)
+	path := fmt.Sprintf("/rooms/%s/send/m.room.message/flyontime-%d-%d",
+		url.PathEscape(mx.RoomID), time.Now().UnixNano(), txnID)
+
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	if err := mx.do(ctx, http.MethodPut, path, nil, c, &resp); err != nil {
+		return "", err
+	}
+	return resp.EventID, nil
+}
+
+// do performs a request to the client-server API, encoding in and decoding
+// the response into out, unless they are nil.
+func (mx *Notifier) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	u := strings.TrimSuffix(mx.Homeserver, "/") + "/_matrix/client/v3" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+mx.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := mx.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s %s: %s: %s", method, path, resp.Status, msg)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func formatHTML(n *flyontime.Notification) string {
+	var b strings.Builder
+	title := html.EscapeString(n.Title)
+	if n.DashboardLink != "" {
+		title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(n.DashboardLink), title)
+	}
+	fmt.Fprintf(&b, `<p><font color="%s">&#x25CF;</font> <strong>%s</strong></p>`, chat.HexColor(n.Severity), title)
+	if n.JobOutput != "" {
+		out := chat.Truncate(vtclean.Clean(n.JobOutput, false), maxOutput)
+		fmt.Fprintf(&b, "<pre><code>%s</code></pre>", html.EscapeString(out))
+	}
+	return b.String()
+}
+
+type syncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []event `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+type event struct {
+	Type    string  `json:"type"`
+	EventID string  `json:"event_id"`
+	Sender  string  `json:"sender"`
+	Content content `json:"content"`
+}
+
+type content struct {
+	MsgType       string    `json:"msgtype"`
+	Body          string    `json:"body"`
+	Format        string    `json:"format,omitempty"`
+	FormattedBody string    `json:"formatted_body,omitempty"`
+	RelatesTo     *relation `json:"m.relates_to,omitempty"`
+}
+
+type relation struct {
+	InReplyTo *inReplyTo `json:"m.in_reply_to,omitempty"`
+}
+
+type inReplyTo struct {
+	EventID string `json:"event_id"`
+}
@@ -0,0 +1,234 @@
+package matrix_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/matrix"
+)
+
+// homeserver is a minimal stub of the Matrix client-server API.
+type homeserver struct {
+	*httptest.Server
+	roomID string
+
+	mu      sync.Mutex
+	sent    []map[string]interface{}
+	pending []map[string]interface{}
+	batch   int
+}
+
+func newHomeserver(roomID string) *homeserver {
+	hs := &homeserver{roomID: roomID}
+	hs.Server = httptest.NewServer(http.HandlerFunc(hs.serve))
+	return hs
+}
+
+func (hs *homeserver) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	Ω(r.Header.Get("Authorization")).Should(Equal("Bearer t0k3n"))
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/_matrix/client/v3")
+	switch {
+	case path == "/account/whoami":
+		fmt.Fprint(w, `{"user_id": "@flyontime:example.org"}`)
+	case strings.HasPrefix(path, "/rooms/"+strings.Replace(hs.roomID, "!", "%21", 1)+"/send/m.room.message/"):
+		var content map[string]interface{}
+		Ω(json.NewDecoder(r.Body).Decode(&content)).Should(Succeed())
+		hs.mu.Lock()
+		hs.sent = append(hs.sent, content)
+		id := len(hs.sent)
+		hs.mu.Unlock()
+		fmt.Fprintf(w, `{"event_id": "$sent%d"}`, id)
+	case path == "/sync":
+		initial := r.URL.Query().Get("since") == ""
+		hs.mu.Lock()
+		var events []map[string]interface{}
+		if !initial {
+			events, hs.pending = hs.pending, nil
+		}
+		hs.batch++
+		batch := hs.batch
+		hs.mu.Unlock()
+		if len(events) == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		resp := map[string]interface{}{"next_batch": fmt.Sprintf("s%d", batch)}
+		if !initial {
+			resp["rooms"] = map[string]interface{}{
+				"join": map[string]interface{}{
+					hs.roomID: map[string]interface{}{
+						"timeline": map[string]interface{}{"events": events},
+					},
+				},
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (hs *homeserver) message(id, sender, body string, replyTo string) {
+	content := map[string]interface{}{"msgtype": "m.text", "body": body}
+	if replyTo != "" {
+		content["m.relates_to"] = map[string]interface{}{
+			"m.in_reply_to": map[string]interface{}{"event_id": replyTo},
+		}
+	}
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.pending = append(hs.pending, map[string]interface{}{
+		"type":     "m.room.message",
+		"event_id": id,
+		"sender":   sender,
+		"content":  content,
+	})
+}
+
+func (hs *homeserver) sentMessages() []map[string]interface{} {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	return append([]map[string]interface{}(nil), hs.sent...)
+}
+
+var _ = Describe("Notifier", func() {
+	var hs *homeserver
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		hs = newHomeserver("!room:example.org")
+		notifier = &Notifier{
+			Homeserver:  hs.URL,
+			AccessToken: "t0k3n",
+			RoomID:      "!room:example.org",
+			SyncTimeout: 10 * time.Millisecond,
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "<boom>",
+		}
+	})
+
+	AfterEach(func() {
+		hs.Close()
+	})
+
+	Describe("Notify", func() {
+		It("should send an HTML formatted message", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := hs.sentMessages()
+			Ω(sent).Should(HaveLen(1))
+			Ω(sent[0]).Should(HaveKeyWithValue("msgtype", "m.notice"))
+			Ω(sent[0]).Should(HaveKeyWithValue("body", "Job j1 from p1 has failed.\nhttp://concourse/builds/1"))
+			Ω(sent[0]).Should(HaveKeyWithValue("format", "org.matrix.custom.html"))
+			Ω(sent[0]["formatted_body"]).Should(ContainSubstring(`<a href="http://concourse/builds/1">Job j1 from p1 has failed.</a>`))
+			Ω(sent[0]["formatted_body"]).Should(ContainSubstring("<pre><code>&lt;boom&gt;</code></pre>"))
+		})
+
+		It("should send only the end of long job output", func() {
+			notification.JobOutput = strings.Repeat("<", 100000) + "boom"
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := hs.sentMessages()
+			Ω(sent).Should(HaveLen(1))
+			Ω(sent[0]["formatted_body"]).Should(ContainSubstring("&lt;boom</code></pre>"))
+			body, err := json.Marshal(sent[0])
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(len(body)).Should(BeNumerically("<", 65536))
+		})
+
+		It("should fail once the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Ω(notifier.Notify(ctx, notification)).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("Commands", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			commands = notifier.Commands()
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+		})
+
+		Context("when a reply to a notification is received", func() {
+			BeforeEach(func() {
+				hs.message("$reply", "@alice:example.org", "> <@flyontime:example.org> Job j1 from p1 has failed.\n\nmute 1h", "$sent1")
+			})
+
+			It("should send a command for the notified job", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("mute"))
+				Ω(c.Args).Should(Equal([]string{"1h"}))
+				Ω(c.Job).Should(Equal(&notification.Job))
+			})
+
+			It("should post the responses as replies", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				c.Responses <- "Muted."
+				close(c.Responses)
+
+				Eventually(hs.sentMessages).Should(HaveLen(2))
+				reply := hs.sentMessages()[1]
+				Ω(reply).Should(HaveKeyWithValue("body", "Muted."))
+				Ω(reply["m.relates_to"]).Should(Equal(map[string]interface{}{
+					"m.in_reply_to": map[string]interface{}{"event_id": "$reply"},
+				}))
+			})
+		})
+
+		Context("when the bot is mentioned", func() {
+			BeforeEach(func() {
+				hs.message("$mention", "@alice:example.org", "flyontime: pause my-pipeline", "")
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when a user whose name starts with the bot's one is mentioned", func() {
+			BeforeEach(func() {
+				hs.message("$other-mention", "@alice:example.org", "@flyontime2 pause my-pipeline", "")
+			})
+
+			It("should neither send any command nor reply", func() {
+				Consistently(commands).ShouldNot(Receive())
+				Ω(hs.sentMessages()).Should(HaveLen(1))
+			})
+		})
+
+		Context("when an unrelated message is received", func() {
+			BeforeEach(func() {
+				hs.message("$other", "@alice:example.org", "hello there", "")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+})
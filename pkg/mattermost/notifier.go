@@ -7,10 +7,12 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
 	"github.com/Bo0mer/flyontime/pkg/flyontime"
-	"github.com/lunixbochs/vtclean"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/pkg/errors"
 )
@@ -21,22 +23,33 @@ type Notifier struct {
 	ChannelID   string // provide either ChannelId or TeamName and ChannelName
 	TeamName    string
 	ChannelName string
+	Retention   time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect   backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
 	Logger      lager.Logger
 
+	// CommandToken is the token of the custom slash command, see
+	// SlashCommandHandler.
+	CommandToken string
+	// SlashResponseType is the type of responses to slash commands, either
+	// model.COMMAND_RESPONSE_TYPE_EPHEMERAL (the default) or
+	// model.COMMAND_RESPONSE_TYPE_IN_CHANNEL.
+	SlashResponseType string
+
 	initOnce sync.Once
+	login    chat.Init // obtains the channel and the bot user
 	client   *model.Client4
 	self     *model.User
-	initErr  error
+	conn     chat.Connection
 
-	commands chan *flyontime.Command
-	posts    map[string]*flyontime.Notification // maps post id to notification
+	router chat.Router
+	posts  chat.Registry // maps post id to notification
 }
 
 func (mm *Notifier) init() error {
-	if mm.initErr != nil {
-		return mm.initErr
-	}
 	mm.initOnce.Do(func() {
+		mm.conn.Reconnect = mm.Reconnect
+		mm.posts.Name = "mattermost_posts"
+		mm.posts.TTL = mm.Retention
 		mm.client = &model.Client4{
 			Url:        mm.API,
 			ApiUrl:     mm.API + model.API_URL_SUFFIX,
@@ -44,18 +57,17 @@ func (mm *Notifier) init() error {
 			AuthToken:  mm.Token,
 			AuthType:   "bearer",
 		}
-
+	})
+	return mm.login.Do(func() error {
 		if mm.ChannelID == "" {
 			team, resp := mm.client.GetTeamByName(mm.TeamName, "")
 			if resp.Error != nil {
-				mm.initErr = errors.Wrap(resp.Error, "error obtaining team")
-				return
+				return errors.Wrap(resp.Error, "error obtaining team")
 			}
 
 			channel, resp := mm.client.GetChannelByName(mm.ChannelName, team.Id, "")
 			if resp.Error != nil {
-				mm.initErr = errors.Wrap(resp.Error, "error obtaining channel")
-				return
+				return errors.Wrap(resp.Error, "error obtaining channel")
 			}
 
 			mm.ChannelID = channel.Id
@@ -63,21 +75,16 @@ func (mm *Notifier) init() error {
 
 		self, resp := mm.client.GetMe("")
 		if resp.Error != nil {
-			mm.initErr = errors.Wrap(resp.Error, "error obtaining bot info")
-			return
+			return errors.Wrap(resp.Error, "error obtaining bot info")
 		}
 		mm.updateBotUser(self)
 
-		mm.commands = make(chan *flyontime.Command)
-		mm.posts = make(map[string]*flyontime.Notification)
 		mm.self = self
+		return nil
 	})
-	return mm.initErr
 }
 
 func (mm *Notifier) Commands() <-chan *flyontime.Command {
-	mm.init()
-
 	logger := mm.Logger.Session("commands")
 	api, err := url.Parse(mm.API)
 	if err != nil {
@@ -91,14 +98,17 @@ func (mm *Notifier) Commands() <-chan *flyontime.Command {
 	}
 
 	go func() {
-		for {
-			ws, err := model.NewWebSocketClient4(api.String(), mm.Token)
-			if err != nil {
-				logger.Error("websocket-connect.fail", err)
-				return
+		mm.conn.Run(logger, func() error {
+			if err := mm.init(); err != nil {
+				return err
+			}
+			ws, appErr := model.NewWebSocketClient4(api.String(), mm.Token)
+			if appErr != nil {
+				return errors.Wrap(appErr, "error connecting to websocket")
 			}
 
 			ws.Listen()
+			mm.conn.Up()
 
 			for ev := range ws.EventChannel {
 				if ev.EventType() != model.WEBSOCKET_EVENT_POSTED {
@@ -107,7 +117,7 @@ func (mm *Notifier) Commands() <-chan *flyontime.Command {
 				}
 				postJSON, ok := ev.Data["post"].(string)
 				if !ok {
-					logger.Error("get-post-data.fail", err)
+					logger.Error("get-post-data.fail", errors.New("post is missing"))
 					continue
 				}
 				p := model.PostFromJson(strings.NewReader(postJSON))
@@ -120,25 +130,29 @@ func (mm *Notifier) Commands() <-chan *flyontime.Command {
 			}
 
 			if ws.ListenError != nil {
-				logger.Error("fail-will-retry", ws.ListenError)
-				continue
+				return ws.ListenError
 			}
-			logger.Info("exit")
-			break
-		}
+			return nil
+		})
+		logger.Info("exit")
 	}()
 
-	return mm.commands
+	return mm.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Mattermost.
+func (mm *Notifier) Health() (map[string]interface{}, error) {
+	return mm.conn.Health(mm.init)
 }
 
 func (mm *Notifier) handlePost(logger lager.Logger, post *model.Post) {
-	if n, ok := mm.posts[post.ParentId]; ok {
+	if n, ok := mm.posts.Get(post.ParentId); ok {
 		mm.handleReply(logger.Session("handle-reply"), post, n)
 		return
 	}
 
-	if strings.HasPrefix(post.Message, fmt.Sprintf("@%s ", strings.ToLower(mm.self.Username))) {
-		mm.handleMention(logger.Session("handle-mention"), post)
+	if text, ok := chat.StripMention(post.Message, mm.mention()); ok {
+		mm.handleMention(logger.Session("handle-mention"), post, text)
 		return
 	}
 
@@ -149,49 +163,23 @@ func (mm *Notifier) handlePost(logger lager.Logger, post *model.Post) {
 }
 
 func (mm *Notifier) handleReply(logger lager.Logger, reply *model.Post, to *flyontime.Notification) {
-	cmd, args := parseCommand(reply.Message)
-
-	replyFunc := mm.replyToThread(reply.Id, reply.RootId)
-	mm.run(logger, &flyontime.Command{Name: cmd, Args: args, Job: &to.Job}, replyFunc)
+	mm.router.Route(logger, reply.Message, &to.Job, mm.replyToThread(reply.Id, reply.RootId))
 }
 
-func (mm *Notifier) handleMention(logger lager.Logger, post *model.Post) {
-	words := strings.Split(strings.TrimSpace(post.Message), " ")
-	if len(words) < 2 {
-		logger.Info("missing-command")
-		return
-	}
-
-	cmd, args := words[1], words[2:]
-	mm.run(logger, &flyontime.Command{Name: cmd, Args: args}, mm.replyToChannel(post.ChannelId))
+func (mm *Notifier) handleMention(logger lager.Logger, post *model.Post, text string) {
+	mm.router.Route(logger, text, nil, mm.replyToChannel(post.ChannelId))
 }
 
 func (mm *Notifier) handleDirectMessage(logger lager.Logger, dm *model.Post) {
-	cmd, args := parseCommand(dm.Message)
-
-	mm.run(logger, &flyontime.Command{Name: cmd, Args: args}, mm.replyToChannel(dm.ChannelId))
+	mm.router.Route(logger, dm.Message, nil, mm.replyToChannel(dm.ChannelId))
 }
 
-func (mm *Notifier) run(logger lager.Logger, c *flyontime.Command, reply replyFunc) {
-	go func() {
-		responses := make(chan string)
-		c.Responses = responses
-
-		// Send the command.
-		mm.commands <- c
-
-		// And post each response as a message.
-		for r := range responses {
-			if err := reply(r); err != nil {
-				logger.Error("reply.fail", err)
-			}
-		}
-	}()
+// mention returns the text used to mention the bot.
+func (mm *Notifier) mention() string {
+	return fmt.Sprintf("@%s", mm.self.Username)
 }
 
-type replyFunc func(string) error
-
-func (mm *Notifier) replyToThread(parentID, rootID string) replyFunc {
+func (mm *Notifier) replyToThread(parentID, rootID string) chat.Reply {
 	return func(reply string) error {
 		_, resp := mm.client.CreatePost(&model.Post{
 			Message:   reply,
@@ -208,7 +196,7 @@ func (mm *Notifier) replyToThread(parentID, rootID string) replyFunc {
 	}
 }
 
-func (mm *Notifier) replyToChannel(cid string) replyFunc {
+func (mm *Notifier) replyToChannel(cid string) chat.Reply {
 	return func(reply string) error {
 		_, resp := mm.client.CreatePost(&model.Post{
 			Message:   reply,
@@ -240,12 +228,12 @@ func (mm *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error
 	post := &model.Post{ChannelId: mm.ChannelID}
 	post.AddProp("attachments", []*model.SlackAttachment{
 		&model.SlackAttachment{
-			Color:      colorFor(n.Severity),
+			Color:      chat.Color(n.Severity),
 			AuthorName: "Concourse",
 			AuthorIcon: "https://concourse.ci/favicon.ico",
 			Title:      n.Title,
 			TitleLink:  n.DashboardLink,
-			Text:       formatCode(n.JobOutput),
+			Text:       chat.FormatCode(n.JobOutput, 0),
 		},
 	})
 
@@ -253,8 +241,9 @@ func (mm *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error
 	if resp.Error != nil {
 		return resp.Error
 	}
-	// TODO(borshukov): Get rid of old posts.
-	mm.posts[p.Id] = n
+	if n.HasJob() {
+		mm.posts.Put(p.Id, n)
+	}
 	return nil
 }
 
@@ -271,36 +260,10 @@ func (mm *Notifier) updateBotUser(user *model.User) {
 		return
 	}
 
-	ok, resp := mm.client.SetProfileImage(user.Id, concourseLogoPNG[:])
+	ok, resp := mm.client.SetProfileImage(user.Id, chat.LogoPNG)
 	if !ok || resp.Error != nil {
 		logger.Error("set-profile-image.fail", resp.Error)
 	}
 
 	logger.Info("done")
 }
-
-func colorFor(severity flyontime.Severity) string {
-	if severity == flyontime.SeverityInfo {
-		return "good"
-	}
-	return "danger"
-}
-
-func formatCode(code string) string {
-	if code == "" {
-		return ""
-	}
-	return fmt.Sprintf("```text\n%s\n```", vtclean.Clean(code, false))
-}
-func parseCommand(text string) (string, []string) {
-	s := strings.Split(text, " ")
-	switch len(s) {
-	case 0:
-		return "", nil
-	case 1:
-		return s[0], nil
-	default:
-		return s[0], s[1:]
-	}
-
-}
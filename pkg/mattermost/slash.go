@@ -0,0 +1,80 @@
+package mattermost
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+)
+
+// responseClient posts responses to slash commands.
+var responseClient = &http.Client{Timeout: 30 * time.Second}
+
+// SlashCommandHandler returns a handler for the requests of a custom slash
+// command, e.g. "/concourse pause my-pipeline". Requests are validated using
+// CommandToken. Responses to the commands are posted using the response URL
+// of each request.
+func (mm *Notifier) SlashCommandHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := mm.Logger.Session("slash-command")
+
+		if err := r.ParseForm(); err != nil {
+			logger.Error("parse-form.fail", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !mm.validToken(r) {
+			logger.Info("invalid-token")
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		logger = logger.WithData(lager.Data{
+			"command": r.Form.Get("command"),
+			"user":    r.Form.Get("user_name"),
+		})
+		mm.router.Route(logger, r.Form.Get("text"), nil, mm.replyToResponseURL(r.Form.Get("response_url")))
+		// Responses are posted asynchronously, thus there is nothing to
+		// respond with right away.
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// validToken reports whether r carries the command token, either in the
+// Authorization header or in the form.
+func (mm *Notifier) validToken(r *http.Request) bool {
+	if mm.CommandToken == "" {
+		return false
+	}
+	token := r.Form.Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Token ") {
+		token = strings.TrimPrefix(auth, "Token ")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(mm.CommandToken)) == 1
+}
+
+func (mm *Notifier) replyToResponseURL(responseURL string) chat.Reply {
+	responseType := mm.SlashResponseType
+	if responseType == "" {
+		responseType = model.COMMAND_RESPONSE_TYPE_EPHEMERAL
+	}
+	return func(reply string) error {
+		resp, err := responseClient.Post(responseURL, "application/json", strings.NewReader((&model.CommandResponse{
+			ResponseType: responseType,
+			Text:         reply,
+		}).ToJson()))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("posting to response url: %s", resp.Status)
+		}
+		return nil
+	}
+}
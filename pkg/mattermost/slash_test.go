@@ -0,0 +1,66 @@
+package mattermost_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/mattermost"
+)
+
+var _ = Describe("SlashCommandHandler", func() {
+	var notifier *Notifier
+	var form url.Values
+
+	BeforeEach(func() {
+		notifier = &Notifier{
+			CommandToken: "t0k3n",
+			Logger:       lagertest.NewTestLogger("mattermost"),
+		}
+		form = url.Values{
+			"command":      {"/concourse"},
+			"text":         {"pipelines"},
+			"response_url": {"http://mattermost/hooks/commands/1"},
+		}
+	})
+
+	serve := func(header http.Header) int {
+		req := httptest.NewRequest("POST", "/mattermost/commands", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		notifier.SlashCommandHandler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	It("should reject requests with invalid token", func() {
+		form.Set("token", "other")
+		Ω(serve(nil)).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject requests without token", func() {
+		Ω(serve(nil)).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject all requests when no token is configured", func() {
+		notifier.CommandToken = ""
+		form.Set("token", "")
+		Ω(serve(nil)).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("should accept requests with the token in the form", func() {
+		form.Set("token", "t0k3n")
+		Ω(serve(nil)).Should(Equal(http.StatusOK))
+	})
+
+	It("should accept requests with the token in the Authorization header", func() {
+		Ω(serve(http.Header{"Authorization": {"Token t0k3n"}})).Should(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,13 @@
+package msteams_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMSTeams(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MSTeams Suite")
+}
@@ -0,0 +1,183 @@
+// Package msteams implements a notifier that posts Adaptive Cards to
+// Microsoft Teams incoming webhooks.
+package msteams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/lunixbochs/vtclean"
+)
+
+// DefaultMaxOutputLength is the default maximum length of the job output
+// included in the posted cards.
+const DefaultMaxOutputLength = 2000
+
+type Notifier struct {
+	WebhookURL      string
+	MaxOutputLength int // Longer job output is truncated from the beginning.
+	Client          *http.Client
+	Logger          lager.Logger
+
+	initOnce sync.Once
+}
+
+func (t *Notifier) init() {
+	t.initOnce.Do(func() {
+		if t.Client == nil {
+			t.Client = &http.Client{Timeout: 30 * time.Second}
+		}
+		if t.MaxOutputLength == 0 {
+			t.MaxOutputLength = DefaultMaxOutputLength
+		}
+		if t.Logger == nil {
+			t.Logger = lager.NewLogger("")
+		}
+	})
+}
+
+func (t *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	t.init()
+	logger := t.Logger.Session("notify")
+
+	body, err := json.Marshal(message{
+		Type: "message",
+		Attachments: []attachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     t.cardFor(n),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		logger.Error("post.fail", err)
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("unexpected response status: %s", resp.Status)
+		logger.Error("post.fail", err)
+		return err
+	}
+	return nil
+}
+
+func (t *Notifier) cardFor(n *flyontime.Notification) card {
+	style, color := stylesFor(n.Severity)
+	title := n.Title
+	if n.DashboardLink != "" {
+		title = fmt.Sprintf("[%s](%s)", n.Title, n.DashboardLink)
+	}
+
+	c := card{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		MSTeams: map[string]string{"width": "Full"},
+		Body: []element{
+			{
+				Type:  "Container",
+				Style: style,
+				Bleed: true,
+				Items: []element{
+					{Type: "TextBlock", Text: "Concourse", Size: "Small", IsSubtle: true},
+					{Type: "TextBlock", Text: title, Size: "Medium", Weight: "Bolder", Color: color, Wrap: true},
+				},
+			},
+		},
+	}
+	if n.HasJob() {
+		c.Body = append(c.Body, element{
+			Type: "FactSet",
+			Facts: []fact{
+				{Title: "Team", Value: n.Job.Team},
+				{Title: "Pipeline", Value: n.Job.Pipeline},
+				{Title: "Job", Value: n.Job.Name},
+			},
+		})
+	}
+	if out := chat.Truncate(vtclean.Clean(n.JobOutput, false), t.MaxOutputLength); out != "" {
+		c.Body = append(c.Body, element{Type: "TextBlock", Text: out, FontType: "Monospace", Wrap: true})
+	}
+	if n.DashboardLink != "" {
+		c.Actions = append(c.Actions, action{Type: "Action.OpenUrl", Title: "View build", URL: n.DashboardLink})
+	}
+	return c
+}
+
+// stylesFor returns the container style and text color for severity.
+func stylesFor(severity flyontime.Severity) (style, color string) {
+	switch severity {
+	case flyontime.SeverityInfo:
+		return "good", "Good"
+	case flyontime.SeverityWarn:
+		return "warning", "Warning"
+	default:
+		return "attention", "Attention"
+	}
+}
+
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string `json:"contentType"`
+	Content     card   `json:"content"`
+}
+
+type card struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	MSTeams map[string]string `json:"msteams,omitempty"`
+	Body    []element         `json:"body"`
+	Actions []action          `json:"actions,omitempty"`
+}
+
+type element struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	Size     string    `json:"size,omitempty"`
+	Weight   string    `json:"weight,omitempty"`
+	Color    string    `json:"color,omitempty"`
+	FontType string    `json:"fontType,omitempty"`
+	IsSubtle bool      `json:"isSubtle,omitempty"`
+	Wrap     bool      `json:"wrap,omitempty"`
+	Style    string    `json:"style,omitempty"`
+	Bleed    bool      `json:"bleed,omitempty"`
+	Items    []element `json:"items,omitempty"`
+	Facts    []fact    `json:"facts,omitempty"`
+}
+
+type fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type action struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,161 @@
+package msteams_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/msteams"
+)
+
+var _ = Describe("Notifier", func() {
+	var server *httptest.Server
+	var requests chan *http.Request
+	var bodies chan []byte
+	var status int32
+
+	var logger *lagertest.TestLogger
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		requests = make(chan *http.Request, 10)
+		bodies = make(chan []byte, 10)
+		atomic.StoreInt32(&status, http.StatusOK)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			requests <- r
+			bodies <- b
+			w.WriteHeader(int(atomic.LoadInt32(&status)))
+		}))
+
+		logger = lagertest.NewTestLogger("msteams")
+		notifier = &Notifier{
+			WebhookURL: server.URL,
+			Logger:     logger,
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "\x1b[31mboom\x1b[0m",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// card returns the Adaptive Card of the posted message.
+	card := func() map[string]interface{} {
+		var msg struct {
+			Type        string `json:"type"`
+			Attachments []struct {
+				ContentType string                 `json:"contentType"`
+				Content     map[string]interface{} `json:"content"`
+			} `json:"attachments"`
+		}
+		Ω(json.Unmarshal(<-bodies, &msg)).Should(Succeed())
+		Ω(msg.Type).Should(Equal("message"))
+		Ω(msg.Attachments).Should(HaveLen(1))
+		Ω(msg.Attachments[0].ContentType).Should(Equal("application/vnd.microsoft.card.adaptive"))
+		return msg.Attachments[0].Content
+	}
+
+	It("should post an Adaptive Card", func() {
+		Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+		var r *http.Request
+		Eventually(requests).Should(Receive(&r))
+		Ω(r.Method).Should(Equal("POST"))
+		Ω(r.Header.Get("Content-Type")).Should(Equal("application/json"))
+
+		c := card()
+		Ω(c).Should(HaveKeyWithValue("type", "AdaptiveCard"))
+		Ω(c).Should(HaveKeyWithValue("version", "1.4"))
+
+		body := c["body"].([]interface{})
+		Ω(body).Should(HaveLen(3))
+		header := body[0].(map[string]interface{})
+		Ω(header).Should(HaveKeyWithValue("style", "attention"))
+		Ω(header["items"]).Should(ContainElement(And(
+			HaveKeyWithValue("text", "[Job j1 from p1 has failed.](http://concourse/builds/1)"),
+			HaveKeyWithValue("color", "Attention"),
+		)))
+		Ω(body[1]).Should(HaveKeyWithValue("facts", ConsistOf(
+			map[string]interface{}{"title": "Team", "value": "t1"},
+			map[string]interface{}{"title": "Pipeline", "value": "p1"},
+			map[string]interface{}{"title": "Job", "value": "j1"},
+		)))
+		Ω(body[2]).Should(HaveKeyWithValue("text", "boom"))
+		Ω(body[2]).Should(HaveKeyWithValue("fontType", "Monospace"))
+
+		Ω(c["actions"]).Should(ConsistOf(map[string]interface{}{
+			"type":  "Action.OpenUrl",
+			"title": "View build",
+			"url":   "http://concourse/builds/1",
+		}))
+	})
+
+	Context("when the notification is informational", func() {
+		BeforeEach(func() {
+			notification.Severity = flyontime.SeverityInfo
+		})
+
+		It("should use the good style", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			header := card()["body"].([]interface{})[0]
+			Ω(header).Should(HaveKeyWithValue("style", "good"))
+		})
+	})
+
+	Context("when the job output is long", func() {
+		BeforeEach(func() {
+			notifier.MaxOutputLength = 10
+			notification.JobOutput = strings.Repeat("x", 20) + "the end"
+		})
+
+		It("should keep its end", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			output := card()["body"].([]interface{})[2]
+			Ω(output).Should(HaveKeyWithValue("text", "…xxthe end"))
+		})
+	})
+
+	Context("when there is no job output or dashboard link", func() {
+		BeforeEach(func() {
+			notification.JobOutput = ""
+			notification.DashboardLink = ""
+		})
+
+		It("should post only the title and facts", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			c := card()
+			Ω(c["body"]).Should(HaveLen(2))
+			Ω(c).ShouldNot(HaveKey("actions"))
+		})
+	})
+
+	Context("when the webhook responds with an error", func() {
+		BeforeEach(func() {
+			atomic.StoreInt32(&status, http.StatusBadRequest)
+		})
+
+		It("should return and log an error", func() {
+			err := notifier.Notify(context.Background(), notification)
+			Ω(err).Should(MatchError("unexpected response status: 400 Bad Request"))
+			Ω(logger).Should(gbytes.Say("msteams.notify.post.fail"))
+		})
+	})
+})
@@ -0,0 +1,247 @@
+// Package rocketchat implements a Rocket.Chat bot that sends notifications to
+// a channel and accepts commands as thread replies to them, mentions or direct
+// messages.
+package rocketchat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/pkg/errors"
+)
+
+// maxOutput is the maximum length of job output included in notifications,
+// chosen so that messages fit in the 5000 characters limit Rocket.Chat has by
+// default, along with the title.
+const maxOutput = 4000
+
+type Notifier struct {
+	API       string // URL of the Rocket.Chat server.
+	UserID    string
+	Token     string // Personal access token of the bot user.
+	Channel   string // Name of the channel, without the leading #.
+	Client    *http.Client
+	Retention time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
+	Logger    lager.Logger
+
+	initOnce sync.Once
+	login    chat.Init // obtains the bot user and the channel
+	conn     chat.Connection
+	self     user
+	roomID   string
+
+	router   chat.Router
+	messages chat.Registry // maps message id to notification
+}
+
+// setup applies the defaults.
+func (rc *Notifier) setup() {
+	rc.initOnce.Do(func() {
+		rc.conn.Reconnect = rc.Reconnect
+		rc.messages.Name = "rocketchat_messages"
+		rc.messages.TTL = rc.Retention
+		if rc.Client == nil {
+			rc.Client = &http.Client{Timeout: 30 * time.Second}
+		}
+		if rc.Logger == nil {
+			rc.Logger = lager.NewLogger("")
+		}
+	})
+}
+
+func (rc *Notifier) init() error {
+	rc.setup()
+	return rc.login.Do(func() error {
+		if err := rc.call("GET", "me", nil, &rc.self); err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		var info struct {
+			Room struct {
+				ID string `json:"_id"`
+			} `json:"room"`
+		}
+		q := url.Values{"roomName": {strings.TrimPrefix(rc.Channel, "#")}}
+		if err := rc.call("GET", "rooms.info?"+q.Encode(), nil, &info); err != nil {
+			return errors.Wrap(err, "error obtaining channel")
+		}
+		rc.roomID = info.Room.ID
+		return nil
+	})
+}
+
+func (rc *Notifier) Commands() <-chan *flyontime.Command {
+	rc.setup()
+	logger := rc.Logger.Session("commands")
+
+	go rc.conn.Run(logger, func() error {
+		if err := rc.init(); err != nil {
+			return err
+		}
+		return listen(rc.API, rc.Token, logger, rc.conn.Up, func(m *message, room roomInfo) {
+			if m.User.ID == rc.self.ID {
+				// Do not reply to self.
+				return
+			}
+			rc.handleMessage(logger, m, room)
+		})
+	})
+
+	return rc.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Rocket.Chat.
+func (rc *Notifier) Health() (map[string]interface{}, error) {
+	return rc.conn.Health(rc.init)
+}
+
+func (rc *Notifier) handleMessage(logger lager.Logger, m *message, room roomInfo) {
+	if n, ok := rc.notification(m.ThreadID); ok {
+		rc.router.Route(logger.Session("handle-reply"), m.Text, &n.Job, rc.replyTo(m.RoomID, m.ThreadID))
+		return
+	}
+
+	if text, ok := chat.StripMention(m.Text, rc.mention()); ok && m.RoomID == rc.roomID {
+		rc.router.Route(logger.Session("handle-mention"), text, nil, rc.replyTo(m.RoomID, m.ThreadID))
+		return
+	}
+
+	if room.RoomType == "d" {
+		rc.router.Route(logger.Session("handle-dm"), m.Text, nil, rc.replyTo(m.RoomID, ""))
+	}
+}
+
+// mention returns the text used to mention the bot.
+func (rc *Notifier) mention() string {
+	return "@" + rc.self.Username
+}
+
+func (rc *Notifier) notification(messageID string) (*flyontime.Notification, bool) {
+	if messageID == "" {
+		return nil, false
+	}
+	return rc.messages.Get(messageID)
+}
+
+// replyTo returns a function that posts replies in roomID, in the thread
+// started by threadID if it is not empty.
+func (rc *Notifier) replyTo(roomID, threadID string) chat.Reply {
+	return func(reply string) error {
+		return rc.call("POST", "chat.postMessage", postMessage{
+			RoomID:   roomID,
+			ThreadID: threadID,
+			Text:     reply,
+		}, nil)
+	}
+}
+
+func (rc *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := rc.init(); err != nil {
+		return err
+	}
+
+	var posted struct {
+		Message message `json:"message"`
+	}
+	err := rc.call("POST", "chat.postMessage", postMessage{
+		RoomID: rc.roomID,
+		Attachments: []attachment{{
+			Color:      chat.Color(n.Severity),
+			AuthorName: "Concourse",
+			AuthorIcon: "https://concourse.ci/favicon.ico",
+			Title:      n.Title,
+			TitleLink:  n.DashboardLink,
+			Text:       chat.FormatCode(n.JobOutput, maxOutput),
+		}},
+	}, &posted)
+	if err != nil {
+		return err
+	}
+
+	if n.HasJob() {
+		rc.messages.Put(posted.Message.ID, n)
+	}
+	return nil
+}
+
+// call invokes the REST API endpoint at path with body (if not nil) and
+// decodes the response into result (if not nil).
+func (rc *Notifier) call(method, path string, body, result interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(rc.API, "/")+"/api/v1/"+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-Id", rc.UserID)
+	req.Header.Set("X-Auth-Token", rc.Token)
+
+	resp, err := rc.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&e)
+		return errors.Errorf("%s %s: %s %s", method, path, resp.Status, e.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+type user struct {
+	ID       string `json:"_id"`
+	Username string `json:"username"`
+}
+
+type message struct {
+	ID       string `json:"_id"`
+	RoomID   string `json:"rid"`
+	Text     string `json:"msg"`
+	ThreadID string `json:"tmid"`
+	User     user   `json:"u"`
+	EditedAt *date  `json:"editedAt"`
+}
+
+type date struct {
+	Millis int64 `json:"$date"`
+}
+
+type postMessage struct {
+	RoomID      string       `json:"roomId"`
+	ThreadID    string       `json:"tmid,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type attachment struct {
+	Color      string `json:"color"`
+	AuthorName string `json:"author_name"`
+	AuthorIcon string `json:"author_icon"`
+	Title      string `json:"title"`
+	TitleLink  string `json:"title_link,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
@@ -0,0 +1,383 @@
+package rocketchat_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/rocketchat"
+)
+
+// server is a minimal stub of the Rocket.Chat REST and Realtime APIs.
+type server struct {
+	*httptest.Server
+	events chan map[string]interface{} // sent to the connected realtime client
+
+	mu         sync.Mutex
+	sent       []map[string]interface{}
+	logins     []interface{}
+	subs       []map[string]interface{}
+	pongs      int
+	loginError bool
+}
+
+func newServer() *server {
+	s := &server{events: make(chan map[string]interface{}, 10)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *server) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	if r.URL.Path == "/websocket" {
+		s.serveRealtime(w, r)
+		return
+	}
+	Ω(r.Header.Get("X-User-Id")).Should(Equal("B1"))
+	Ω(r.Header.Get("X-Auth-Token")).Should(Equal("t0k3n"))
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/api/v1/me":
+		fmt.Fprint(w, `{"success": true, "_id": "B1", "username": "flyontime"}`)
+	case r.Method == "GET" && r.URL.Path == "/api/v1/rooms.info" && r.URL.Query().Get("roomName") == "ci":
+		fmt.Fprint(w, `{"success": true, "room": {"_id": "R1"}}`)
+	case r.Method == "POST" && r.URL.Path == "/api/v1/chat.postMessage":
+		var m map[string]interface{}
+		Ω(json.NewDecoder(r.Body).Decode(&m)).Should(Succeed())
+		s.mu.Lock()
+		s.sent = append(s.sent, m)
+		id := len(s.sent)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"success": true, "message": {"_id": "M%d", "rid": %q}}`, id, m["roomId"])
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "error": "error-room-not-found"}`)
+	}
+}
+
+func (s *server) serveRealtime(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// The client connects, logs in and subscribes without waiting for any
+	// response.
+	var connect, login, sub struct {
+		Msg    string        `json:"msg"`
+		Method string        `json:"method"`
+		Name   string        `json:"name"`
+		Params []interface{} `json:"params"`
+	}
+	if conn.ReadJSON(&connect) != nil || conn.ReadJSON(&login) != nil || conn.ReadJSON(&sub) != nil {
+		return
+	}
+	Ω(connect.Msg).Should(Equal("connect"))
+	Ω(login.Method).Should(Equal("login"))
+	Ω(sub.Msg).Should(Equal("sub"))
+
+	s.mu.Lock()
+	s.logins = append(s.logins, login.Params[0])
+	s.subs = append(s.subs, map[string]interface{}{"name": sub.Name, "params": sub.Params})
+	loginError := s.loginError
+	s.mu.Unlock()
+
+	result := map[string]interface{}{"msg": "result", "id": "login"}
+	if loginError {
+		result["error"] = map[string]interface{}{"error": 403, "reason": "User not found"}
+	}
+	// The connection could outlive the spec that made it, hence failing to
+	// respond is not asserted.
+	if err := conn.WriteJSON(result); err != nil {
+		return
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var m struct {
+				Msg string `json:"msg"`
+			}
+			if err := conn.ReadJSON(&m); err != nil {
+				return
+			}
+			if m.Msg == "pong" {
+				s.mu.Lock()
+				s.pongs++
+				s.mu.Unlock()
+			}
+		}
+	}()
+
+	for {
+		select {
+		case m := <-s.events:
+			if err := conn.WriteJSON(m); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// message sends a message posted by userID in a room of the given type,
+// which is a thread reply if threadID is not empty.
+func (s *server) message(id, roomID, roomType, threadID, userID, text string) {
+	s.changed(newMessage(id, roomID, threadID, userID, text), roomType)
+}
+
+// edit sends an edit of a message posted by userID, see message.
+func (s *server) edit(id, roomID, roomType, threadID, userID, text string) {
+	m := newMessage(id, roomID, threadID, userID, text)
+	m["editedAt"] = map[string]interface{}{"$date": 1500000000000}
+	s.changed(m, roomType)
+}
+
+func newMessage(id, roomID, threadID, userID, text string) map[string]interface{} {
+	m := map[string]interface{}{
+		"_id": id,
+		"rid": roomID,
+		"msg": text,
+		"u":   map[string]interface{}{"_id": userID, "username": userID},
+	}
+	if threadID != "" {
+		m["tmid"] = threadID
+	}
+	return m
+}
+
+// changed sends m as a change of the messages of the bot.
+func (s *server) changed(m map[string]interface{}, roomType string) {
+	s.events <- map[string]interface{}{
+		"msg":        "changed",
+		"collection": "stream-room-messages",
+		"id":         "id",
+		"fields": map[string]interface{}{
+			"eventName": "__my_messages__",
+			"args":      []interface{}{m, map[string]interface{}{"roomType": roomType}},
+		},
+	}
+}
+
+func (s *server) sentMessages() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}(nil), s.sent...)
+}
+
+func (s *server) subscriptions() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}(nil), s.subs...)
+}
+
+func (s *server) pinged() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pongs
+}
+
+func (s *server) failLogins() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loginError = true
+}
+
+func (s *server) loggedIn() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]interface{}(nil), s.logins...)
+}
+
+var _ = Describe("Notifier", func() {
+	var s *server
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		s = newServer()
+		notifier = &Notifier{
+			API:       s.URL,
+			UserID:    "B1",
+			Token:     "t0k3n",
+			Channel:   "#ci",
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "boom",
+		}
+	})
+
+	AfterEach(func() {
+		s.Close()
+	})
+
+	Describe("Notify", func() {
+		It("should post an attachment to the channel", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := s.sentMessages()
+			Ω(sent).Should(HaveLen(1))
+			Ω(sent[0]).Should(HaveKeyWithValue("roomId", "R1"))
+			Ω(sent[0]["attachments"]).Should(ConsistOf(map[string]interface{}{
+				"color":       "danger",
+				"author_name": "Concourse",
+				"author_icon": "https://concourse.ci/favicon.ico",
+				"title":       "Job j1 from p1 has failed.",
+				"title_link":  "http://concourse/builds/1",
+				"text":        "```\nboom\n```",
+			}))
+		})
+
+		It("should post only the end of long job output", func() {
+			notification.JobOutput = strings.Repeat("x", 10000) + "boom"
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := s.sentMessages()
+			Ω(sent).Should(HaveLen(1))
+			text := sent[0]["attachments"].([]interface{})[0].(map[string]interface{})["text"].(string)
+			Ω(len(text)).Should(BeNumerically("<", 5000))
+			Ω(text).Should(HaveSuffix("boom\n```"))
+		})
+
+		Context("when the channel does not exist", func() {
+			BeforeEach(func() {
+				notifier.Channel = "missing"
+			})
+
+			It("should return an error", func() {
+				err := notifier.Notify(context.Background(), notification)
+				Ω(err).Should(MatchError(ContainSubstring("error-room-not-found")))
+			})
+		})
+	})
+
+	Describe("Commands", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			commands = notifier.Commands()
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			Eventually(s.loggedIn).Should(HaveLen(1))
+		})
+
+		Context("when a notification is replied to in its thread", func() {
+			BeforeEach(func() {
+				s.message("T1", "R1", "c", "M1", "U1", "mute 1h")
+			})
+
+			It("should send a command for its job and post the responses in the thread", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("mute"))
+				Ω(c.Args).Should(Equal([]string{"1h"}))
+				Ω(c.Job).Should(Equal(&notification.Job))
+
+				c.Responses <- "Muted."
+				close(c.Responses)
+
+				Eventually(s.sentMessages).Should(HaveLen(2))
+				Ω(s.sentMessages()[1]).Should(Equal(map[string]interface{}{
+					"roomId": "R1",
+					"tmid":   "M1",
+					"text":   "Muted.",
+				}))
+			})
+		})
+
+		Context("when a reply to a notification is edited", func() {
+			BeforeEach(func() {
+				s.edit("T1", "R1", "c", "M1", "U1", "rerun")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the bot is mentioned in the channel", func() {
+			BeforeEach(func() {
+				s.message("T1", "R1", "c", "", "U1", "@flyontime pause my-pipeline")
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when a user whose name starts with the bot's one is mentioned", func() {
+			BeforeEach(func() {
+				s.message("T1", "R1", "c", "", "U1", "@flyontime2 pause my-pipeline")
+			})
+
+			It("should neither send any command nor reply", func() {
+				Consistently(commands).ShouldNot(Receive())
+				Ω(s.sentMessages()).Should(HaveLen(1))
+			})
+		})
+
+		Context("when the bot is mentioned in another channel", func() {
+			BeforeEach(func() {
+				s.message("T1", "R2", "c", "", "U1", "@flyontime pause my-pipeline")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+
+		Context("when a direct message is received", func() {
+			BeforeEach(func() {
+				s.message("T1", "D1", "d", "", "U1", "pause my-pipeline")
+			})
+
+			It("should send a global command and reply in the same room", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Job).Should(BeNil())
+
+				c.Responses <- "Paused."
+				close(c.Responses)
+
+				Eventually(s.sentMessages).Should(HaveLen(2))
+				Ω(s.sentMessages()[1]).Should(Equal(map[string]interface{}{
+					"roomId": "D1",
+					"text":   "Paused.",
+				}))
+			})
+		})
+
+		Context("when the message is from the bot itself", func() {
+			BeforeEach(func() {
+				s.message("T1", "D1", "d", "", "B1", "pause my-pipeline")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+})
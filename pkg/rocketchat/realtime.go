@@ -0,0 +1,128 @@
+package rocketchat
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+// ddp is a message of the Realtime API, which is based on the Distributed Data
+// Protocol, see https://developer.rocket.chat/reference/api/realtime-api
+type ddp struct {
+	Msg        string          `json:"msg"`
+	ID         string          `json:"id,omitempty"`
+	Version    string          `json:"version,omitempty"`
+	Support    []string        `json:"support,omitempty"`
+	Method     string          `json:"method,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Params     []interface{}   `json:"params,omitempty"`
+	Collection string          `json:"collection,omitempty"`
+	Fields     *streamFields   `json:"fields,omitempty"`
+	Error      json.RawMessage `json:"error,omitempty"`
+}
+
+type streamFields struct {
+	EventName string            `json:"eventName"`
+	Args      []json.RawMessage `json:"args"`
+}
+
+// roomInfo is the second argument of stream-room-messages events when
+// subscribed to the messages of all rooms of the user.
+type roomInfo struct {
+	RoomType string `json:"roomType"`
+}
+
+// realtime is a single connection to the Realtime API.
+type realtime struct {
+	conn   *websocket.Conn
+	logger lager.Logger
+
+	wmu sync.Mutex // guards writes to conn
+}
+
+// listen connects to the Realtime API of the server at api, logs in with
+// token and calls dispatch for each message posted in any of the rooms of
+// the user until the connection fails. loggedIn is called once the login
+// succeeds.
+func listen(api, token string, logger lager.Logger, loggedIn func(), dispatch func(m *message, room roomInfo)) error {
+	url := strings.TrimSuffix(api, "/") + "/websocket"
+	url = strings.Replace(url, "http", "ws", 1) // http -> ws, https -> wss
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to realtime api")
+	}
+	rt := &realtime{conn: conn, logger: logger}
+	defer conn.Close()
+
+	if err := rt.send(ddp{Msg: "connect", Version: "1", Support: []string{"1"}}); err != nil {
+		return err
+	}
+	if err := rt.send(ddp{
+		Msg:    "method",
+		ID:     "login",
+		Method: "login",
+		Params: []interface{}{map[string]string{"resume": token}},
+	}); err != nil {
+		return err
+	}
+	if err := rt.send(ddp{
+		Msg:    "sub",
+		ID:     "messages",
+		Name:   "stream-room-messages",
+		Params: []interface{}{"__my_messages__", false},
+	}); err != nil {
+		return err
+	}
+
+	for {
+		var m ddp
+		if err := conn.ReadJSON(&m); err != nil {
+			return errors.Wrap(err, "error reading from realtime api")
+		}
+		switch m.Msg {
+		case "ping":
+			if err := rt.send(ddp{Msg: "pong"}); err != nil {
+				return err
+			}
+		case "result":
+			if m.ID == "login" && m.Error != nil {
+				return errors.Errorf("login failed: %s", m.Error)
+			}
+			if m.ID == "login" {
+				loggedIn()
+			}
+		case "nosub":
+			return errors.Errorf("subscription rejected: %s", m.Error)
+		case "changed":
+			if m.Collection != "stream-room-messages" || m.Fields == nil || len(m.Fields.Args) == 0 {
+				continue
+			}
+			var msg message
+			if err := json.Unmarshal(m.Fields.Args[0], &msg); err != nil {
+				logger.Error("decode-message.fail", err)
+				continue
+			}
+			if msg.EditedAt != nil {
+				// Edits of earlier messages, e.g. of commands that were
+				// already run, are delivered as well.
+				logger.Debug("skip-edited-message", lager.Data{"id": msg.ID})
+				continue
+			}
+			var room roomInfo
+			if len(m.Fields.Args) > 1 {
+				json.Unmarshal(m.Fields.Args[1], &room)
+			}
+			dispatch(&msg, room)
+		}
+	}
+}
+
+func (rt *realtime) send(m ddp) error {
+	rt.wmu.Lock()
+	defer rt.wmu.Unlock()
+	return rt.conn.WriteJSON(m)
+}
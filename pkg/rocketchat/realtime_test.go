@@ -0,0 +1,66 @@
+package rocketchat_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	. "github.com/Bo0mer/flyontime/pkg/rocketchat"
+)
+
+var _ = Describe("Realtime API", func() {
+	var s *server
+	var notifier *Notifier
+
+	BeforeEach(func() {
+		s = newServer()
+		notifier = &Notifier{
+			API:       s.URL,
+			UserID:    "B1",
+			Token:     "t0k3n",
+			Channel:   "ci",
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+		}
+	})
+
+	JustBeforeEach(func() {
+		notifier.Commands()
+	})
+
+	AfterEach(func() {
+		s.Close()
+	})
+
+	health := func() error {
+		_, err := notifier.Health()
+		return err
+	}
+
+	It("should log in with the token and subscribe to the messages of the bot", func() {
+		Eventually(s.loggedIn).Should(ConsistOf(map[string]interface{}{"resume": "t0k3n"}))
+		Ω(s.subscriptions()).Should(ConsistOf(map[string]interface{}{
+			"name":   "stream-room-messages",
+			"params": []interface{}{"__my_messages__", false},
+		}))
+		Eventually(health).Should(Succeed())
+	})
+
+	It("should answer pings", func() {
+		Eventually(s.loggedIn).Should(HaveLen(1))
+		s.events <- map[string]interface{}{"msg": "ping"}
+		Eventually(s.pinged).Should(Equal(1))
+	})
+
+	Context("when logging in fails", func() {
+		BeforeEach(func() {
+			s.failLogins()
+		})
+
+		It("should not be healthy and should keep logging in", func() {
+			Eventually(s.loggedIn).Should(HaveLen(2))
+			Ω(health()).Should(MatchError(ContainSubstring("login failed")))
+		})
+	})
+})
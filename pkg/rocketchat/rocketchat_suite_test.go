@@ -0,0 +1,13 @@
+package rocketchat_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRocketChat(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RocketChat Suite")
+}
@@ -0,0 +1,132 @@
+package slacker
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+)
+
+// maxRequestBody is the maximum size of request bodies accepted from Slack.
+const maxRequestBody = 1 << 20
+
+// eventCallback is the outer event of the Events API, see
+// https://api.slack.com/apis/connections/events-api#callback-field
+type eventCallback struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	EventID   string `json:"event_id"`
+	Event     event  `json:"event"`
+}
+
+type event struct {
+	Type        string `json:"type"`
+	SubType     string `json:"subtype"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	User        string `json:"user"`
+	BotID       string `json:"bot_id"`
+	Text        string `json:"text"`
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"`
+}
+
+// EventsHandler returns a handler for requests of the Events API, to be used
+// instead of RTM or Socket Mode. Requests are verified using SigningSecret.
+// The app has to be subscribed to the app_mention, message.channels and
+// message.im events.
+func (s *Notifier) EventsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setup()
+		logger := s.Logger.Session("events")
+
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+		if err != nil {
+			logger.Error("read-body.fail", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := VerifyRequest(s.SigningSecret, r.Header, body, time.Now()); err != nil {
+			logger.Error("verify.fail", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var cb eventCallback
+		if err := json.Unmarshal(body, &cb); err != nil {
+			logger.Error("decode.fail", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch cb.Type {
+		case "url_verification":
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, cb.Challenge)
+		case "event_callback":
+			// Mentions and messages of the bot itself are recognized by
+			// its id, hence events are handled only once it is known.
+			// Slack retries the delivery of events that failed.
+			if err := s.init(); err != nil {
+				logger.Error("init.fail", err)
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			s.handleEvent(logger, &cb)
+		}
+	})
+}
+
+// handleEvent handles events received through the Events API, either over
+// HTTP or Socket Mode.
+func (s *Notifier) handleEvent(logger lager.Logger, cb *eventCallback) {
+	// Slack retries delivery of events that were not acknowledged in time,
+	// thus the same event could be received more than once.
+	if cb.EventID != "" {
+		if _, ok := s.events.Get(cb.EventID); ok {
+			logger.Debug("duplicate-event", lager.Data{"event-id": cb.EventID})
+			return
+		}
+		s.events.Put(cb.EventID, true)
+	}
+
+	ev := &cb.Event
+	if ev.User == "" || ev.User == s.selfID || ev.BotID != "" || ev.SubType != "" {
+		// Do not reply to self, other bots or message changes.
+		return
+	}
+
+	switch ev.Type {
+	case "message":
+		if ev.ChannelType == "im" {
+			s.run(s.stripMention(ev.Text), nil, s.replyToIM(ev.Channel))
+			return
+		}
+		if n, ok := s.notification(ev); ok {
+			s.run(s.stripMention(ev.Text), &n.Job, s.replyToThread(ev.Channel, ev.ThreadTS))
+		}
+	case "app_mention":
+		if _, ok := s.notification(ev); ok {
+			// Already handled as a reply to the notification.
+			return
+		}
+		s.run(s.stripMention(ev.Text), nil, s.replyToThread(ev.Channel, ev.ThreadTS))
+	}
+}
+
+// notification returns the notification, to whose thread ev is a reply.
+func (s *Notifier) notification(ev *event) (*flyontime.Notification, bool) {
+	if ev.Channel != s.ChannelID || ev.ThreadTS == "" || ev.ThreadTS == ev.TS {
+		return nil, false
+	}
+	return s.threads.Get(ev.ThreadTS)
+}
+
+// stripMention removes the leading mention of the bot from text.
+func (s *Notifier) stripMention(text string) string {
+	return strings.TrimPrefix(strings.TrimSpace(text), "<@"+s.selfID+">")
+}
@@ -0,0 +1,469 @@
+package slacker_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/slacker"
+)
+
+// webAPI is a minimal stub of the Slack Web API and Socket Mode.
+type webAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	appToken    string
+	envelopes   chan interface{} // sent to the Socket Mode client
+	posted      []url.Values
+	responses   []map[string]interface{} // posted to response URLs
+	acks        []string
+	connections int
+	authDown    int // number of auth.test calls to fail
+}
+
+func newWebAPI() *webAPI {
+	a := &webAPI{}
+	a.Server = httptest.NewServer(http.HandlerFunc(a.serve))
+	return a
+}
+
+// reset forgets all requests and accepts Socket Mode connections only for
+// appToken, so that notifiers of previous specs could not connect.
+func (a *webAPI) reset(appToken string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.appToken = appToken
+	a.envelopes = make(chan interface{}, 10)
+	a.posted = nil
+	a.responses = nil
+	a.acks = nil
+	a.connections = 0
+	a.authDown = 0
+}
+
+// failAuth makes the next n calls of auth.test fail.
+func (a *webAPI) failAuth(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.authDown = n
+}
+
+func (a *webAPI) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	switch r.URL.Path {
+	case "/auth.test":
+		a.mu.Lock()
+		down := a.authDown > 0
+		if down {
+			a.authDown--
+		}
+		a.mu.Unlock()
+		if down {
+			fmt.Fprint(w, `{"ok": false, "error": "fatal_error"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok": true, "user_id": "UBOT", "user": "flyontime"}`)
+	case "/users.setPhoto":
+		fmt.Fprint(w, `{"ok": true}`)
+	case "/chat.postMessage":
+		Ω(r.ParseForm()).Should(Succeed())
+		a.mu.Lock()
+		a.posted = append(a.posted, r.PostForm)
+		ts := fmt.Sprintf("1500000000.%06d", len(a.posted))
+		a.mu.Unlock()
+		fmt.Fprintf(w, `{"ok": true, "channel": %q, "ts": %q}`, r.PostForm.Get("channel"), ts)
+	case "/response":
+		var resp map[string]interface{}
+		Ω(json.NewDecoder(r.Body).Decode(&resp)).Should(Succeed())
+		a.mu.Lock()
+		a.responses = append(a.responses, resp)
+		a.mu.Unlock()
+	case "/apps.connections.open":
+		a.mu.Lock()
+		appToken := a.appToken
+		a.mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+appToken {
+			fmt.Fprint(w, `{"ok": false, "error": "invalid_auth"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"ok": true, "url": "ws%s/socket-mode"}`, strings.TrimPrefix(a.URL, "http"))
+	case "/socket-mode":
+		a.serveSocketMode(w, r)
+	default:
+		fmt.Fprint(w, `{"ok": false, "error": "unknown_method"}`)
+	}
+}
+
+func (a *webAPI) serveSocketMode(w http.ResponseWriter, r *http.Request) {
+	// Connections outlive the specs that made them, hence failures are not
+	// asserted.
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	a.mu.Lock()
+	a.connections++
+	envelopes := a.envelopes
+	a.mu.Unlock()
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "hello"}); err != nil {
+		return
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var ack struct {
+				EnvelopeID string `json:"envelope_id"`
+			}
+			if err := conn.ReadJSON(&ack); err != nil {
+				return
+			}
+			a.mu.Lock()
+			a.acks = append(a.acks, ack.EnvelopeID)
+			a.mu.Unlock()
+		}
+	}()
+
+	for {
+		select {
+		case env := <-envelopes:
+			if err := conn.WriteJSON(env); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// send sends an envelope to the connected Socket Mode client.
+func (a *webAPI) send(env map[string]interface{}) {
+	a.mu.Lock()
+	envelopes := a.envelopes
+	a.mu.Unlock()
+	envelopes <- env
+}
+
+func (a *webAPI) postedMessages() []url.Values {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]url.Values(nil), a.posted...)
+}
+
+func (a *webAPI) responsesPosted() []map[string]interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]map[string]interface{}(nil), a.responses...)
+}
+
+func (a *webAPI) acknowledged() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.acks...)
+}
+
+func (a *webAPI) connected() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.connections
+}
+
+// signedRequest returns a request with body signed using secret.
+func signedRequest(target, secret string, body []byte) *http.Request {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	r := httptest.NewRequest("POST", target, bytes.NewReader(body))
+	r.Header.Set("X-Slack-Request-Timestamp", ts)
+	r.Header.Set("X-Slack-Signature", Sign(secret, ts, body))
+	return r
+}
+
+// callback returns an event_callback of the Events API with the given id.
+func callback(id string, ev map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "event_callback", "event_id": id, "event": ev}
+}
+
+var _ = Describe("EventsHandler", func() {
+	var notifier *Notifier
+	var handler http.Handler
+	var commands <-chan *flyontime.Command
+	var notification *flyontime.Notification
+	var notificationTS string
+
+	BeforeEach(func() {
+		api.reset("")
+		notifier = &Notifier{
+			Token:         "xoxb-t0k3n",
+			SigningSecret: "s3cr3t",
+			ChannelID:     "C1",
+			Logger:        lagertest.NewTestLogger("slack"),
+		}
+		handler = notifier.EventsHandler()
+		commands = notifier.Commands()
+
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+		}
+		Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+		// The stub uses the number of posted messages as timestamp.
+		notificationTS = "1500000000.000001"
+	})
+
+	deliver := func(cb map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(cb)
+		Ω(err).ShouldNot(HaveOccurred())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedRequest("/slack/events", "s3cr3t", body))
+		return w
+	}
+
+	It("should be healthy", func() {
+		_, err := notifier.Health()
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should respond to URL verification with the challenge", func() {
+		w := deliver(map[string]interface{}{"type": "url_verification", "challenge": "ch4ll3ng3"})
+		Ω(w.Code).Should(Equal(http.StatusOK))
+		Ω(w.Body.String()).Should(Equal("ch4ll3ng3"))
+	})
+
+	Context("when the request is not signed with the signing secret", func() {
+		It("should reject it", func() {
+			body := []byte(`{"type": "url_verification", "challenge": "ch4ll3ng3"}`)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, signedRequest("/slack/events", "other", body))
+			Ω(w.Code).Should(Equal(http.StatusUnauthorized))
+			Ω(w.Body.String()).ShouldNot(ContainSubstring("ch4ll3ng3"))
+		})
+	})
+
+	Context("when the bot is mentioned", func() {
+		var mention map[string]interface{}
+
+		BeforeEach(func() {
+			mention = callback("E1", map[string]interface{}{
+				"type":    "app_mention",
+				"channel": "C1",
+				"user":    "U1",
+				"text":    "<@UBOT> pause my-pipeline",
+				"ts":      "1500000001.000001",
+			})
+			Ω(deliver(mention).Code).Should(Equal(http.StatusOK))
+		})
+
+		It("should send a global command and post the responses in the channel", func() {
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("pause"))
+			Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+			Ω(c.Job).Should(BeNil())
+
+			c.Responses <- "Paused."
+			close(c.Responses)
+
+			Eventually(api.postedMessages).Should(HaveLen(2))
+			reply := api.postedMessages()[1]
+			Ω(reply.Get("channel")).Should(Equal("C1"))
+			Ω(reply.Get("text")).Should(Equal("Paused."))
+			Ω(reply.Get("thread_ts")).Should(BeEmpty())
+		})
+
+		Context("and the event is retried", func() {
+			It("should send the command only once", func() {
+				Ω(deliver(mention).Code).Should(Equal(http.StatusOK))
+				Eventually(commands).Should(Receive())
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Context("when a notification is replied to in its thread", func() {
+		BeforeEach(func() {
+			deliver(callback("E1", map[string]interface{}{
+				"type":      "message",
+				"channel":   "C1",
+				"user":      "U1",
+				"text":      "mute 1h",
+				"ts":        "1500000001.000001",
+				"thread_ts": notificationTS,
+			}))
+		})
+
+		It("should send a command for its job and post the responses in the thread", func() {
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("mute"))
+			Ω(c.Args).Should(Equal([]string{"1h"}))
+			Ω(c.Job).Should(Equal(&notification.Job))
+
+			c.Responses <- "Muted."
+			close(c.Responses)
+
+			Eventually(api.postedMessages).Should(HaveLen(2))
+			reply := api.postedMessages()[1]
+			Ω(reply.Get("channel")).Should(Equal("C1"))
+			Ω(reply.Get("text")).Should(Equal("Muted."))
+			Ω(reply.Get("thread_ts")).Should(Equal(notificationTS))
+		})
+	})
+
+	Context("when the bot is mentioned in the thread of a notification", func() {
+		BeforeEach(func() {
+			// Slack sends both a message and an app_mention event.
+			for i, typ := range []string{"message", "app_mention"} {
+				deliver(callback(fmt.Sprintf("E%d", i), map[string]interface{}{
+					"type":      typ,
+					"channel":   "C1",
+					"user":      "U1",
+					"text":      "<@UBOT> mute 1h",
+					"ts":        "1500000001.000001",
+					"thread_ts": notificationTS,
+				}))
+			}
+		})
+
+		It("should send a single command for its job", func() {
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("mute"))
+			Ω(c.Job).Should(Equal(&notification.Job))
+			Consistently(commands).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a direct message is received", func() {
+		BeforeEach(func() {
+			deliver(callback("E1", map[string]interface{}{
+				"type":         "message",
+				"channel":      "D1",
+				"channel_type": "im",
+				"user":         "U1",
+				"text":         "pause my-pipeline",
+				"ts":           "1500000001.000001",
+			}))
+		})
+
+		It("should send a global command and reply in the same channel", func() {
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("pause"))
+			Ω(c.Job).Should(BeNil())
+
+			c.Responses <- "Paused."
+			close(c.Responses)
+
+			Eventually(api.postedMessages).Should(HaveLen(2))
+			Ω(api.postedMessages()[1].Get("channel")).Should(Equal("D1"))
+		})
+	})
+
+	Context("when a message in the channel is not a reply to a notification", func() {
+		BeforeEach(func() {
+			deliver(callback("E1", map[string]interface{}{
+				"type":    "message",
+				"channel": "C1",
+				"user":    "U1",
+				"text":    "pause my-pipeline",
+				"ts":      "1500000001.000001",
+			}))
+		})
+
+		It("should not send any command", func() {
+			Consistently(commands).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a message should be ignored", func() {
+		BeforeEach(func() {
+			for i, ev := range []map[string]interface{}{
+				{"user": "UBOT"},
+				{"user": "U1", "bot_id": "B1"},
+				{"user": "U1", "subtype": "message_changed"},
+			} {
+				ev["type"] = "message"
+				ev["channel"] = "D1"
+				ev["channel_type"] = "im"
+				ev["text"] = "pause my-pipeline"
+				deliver(callback(fmt.Sprintf("E%d", i), ev))
+			}
+		})
+
+		It("should not send any command", func() {
+			Consistently(commands).ShouldNot(Receive())
+		})
+	})
+})
+
+var _ = Describe("EventsHandler when Slack cannot be reached at startup", func() {
+	var notifier *Notifier
+	var handler http.Handler
+	var commands <-chan *flyontime.Command
+
+	BeforeEach(func() {
+		api.reset("")
+		api.failAuth(1)
+		notifier = &Notifier{
+			Token:         "xoxb-t0k3n",
+			SigningSecret: "s3cr3t",
+			ChannelID:     "C1",
+			Logger:        lagertest.NewTestLogger("slack"),
+		}
+		handler = notifier.EventsHandler()
+		commands = notifier.Commands()
+	})
+
+	It("should not be healthy until the bot user is obtained", func() {
+		_, err := notifier.Health()
+		Ω(err).Should(MatchError(ContainSubstring("init failed")))
+
+		_, err = notifier.Health()
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should fail the events until the bot user is obtained", func() {
+		body, err := json.Marshal(callback("E1", map[string]interface{}{
+			"type":    "app_mention",
+			"channel": "C1",
+			"user":    "U1",
+			"text":    "<@UBOT> pause my-pipeline",
+			"ts":      "1500000001.000001",
+		}))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedRequest("/slack/events", "s3cr3t", body))
+		Ω(w.Code).Should(Equal(http.StatusServiceUnavailable))
+		Consistently(commands).ShouldNot(Receive())
+
+		// Slack retries the delivery.
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, signedRequest("/slack/events", "s3cr3t", body))
+		Ω(w.Code).Should(Equal(http.StatusOK))
+		var c *flyontime.Command
+		Eventually(commands).Should(Receive(&c))
+		Ω(c.Name).Should(Equal("pause"))
+		Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+	})
+})
@@ -1,707 +0,0 @@
-package slacker
-
-var concourseLogoPNG = [...]byte{0x89, 0x50, 0x4e, 0x47, 0xd, 0xa, 0x1a, 0xa,
-	0x0, 0x0, 0x0, 0xd, 0x49, 0x48, 0x44, 0x52, 0x0, 0x0, 0x1, 0x13, 0x0, 0x0, 0x1,
-	0x13, 0x8, 0x6, 0x0, 0x0, 0x0, 0x15, 0xa9, 0xe2, 0xb8, 0x0, 0x0, 0x23, 0xbd,
-	0x49, 0x44, 0x41, 0x54, 0x78, 0xda, 0xec, 0x9d, 0x4f, 0x52, 0x1b, 0xcb, 0xf2,
-	0xfd, 0x33, 0x8b, 0x46, 0xd8, 0x4c, 0xbe, 0xbc, 0x11, 0x26, 0xde, 0xe0, 0xc9,
-	0x2b, 0x30, 0xe, 0x7, 0xdc, 0x1, 0x38, 0x2c, 0x56, 0x60, 0xbc, 0x2, 0xc3, 0xa,
-	0x80, 0x15, 0x60, 0x56, 0x80, 0xbd, 0x2, 0xf0, 0xa, 0x80, 0x15, 0x8, 0x7, 0x68,
-	0x70, 0x21, 0x6e, 0x58, 0x5e, 0x1, 0xf2, 0xc0, 0x21, 0x6b, 0xf4, 0xe3, 0x4e,
-	0x30, 0x12, 0xa2, 0xf2, 0x17, 0x5d, 0x92, 0x40, 0xd8, 0x48, 0xea, 0x6e, 0xf5,
-	0x9f, 0xea, 0xee, 0x73, 0x22, 0x6e, 0xc4, 0xbb, 0xef, 0x86, 0x8d, 0x68, 0x55,
-	0x7d, 0xfa, 0x64, 0x56, 0x56, 0xa6, 0x22, 0x8, 0x82, 0xa0, 0x10, 0x4, 0x98,
-	0x40, 0x10, 0x4, 0x98, 0x40, 0x10, 0x4, 0x98, 0x40, 0x10, 0x4, 0x98, 0x40, 0x10,
-	0x4, 0x1, 0x26, 0x10, 0x4, 0x1, 0x26, 0x10, 0x4, 0x1, 0x26, 0x10, 0x4, 0x1,
-	0x26, 0x10, 0x4, 0x41, 0x80, 0x9, 0x4, 0x41, 0x80, 0x9, 0x4, 0x41, 0x80, 0x9,
-	0x4, 0x41, 0x80, 0x9, 0x4, 0x41, 0x10, 0x60, 0x2, 0x41, 0x50, 0x82, 0x72, 0xf0,
-	0x8, 0xa0, 0x3c, 0x6a, 0xf1, 0xa4, 0xb1, 0x4a, 0x4c, 0x6f, 0x99, 0xa9, 0x44,
-	0x22, 0x45, 0xf7, 0xff, 0x13, 0xe6, 0x63, 0x12, 0x3a, 0x6a, 0xde, 0x5c, 0xed,
-	0x7f, 0x5b, 0x79, 0x7e, 0x89, 0xa7, 0xe4, 0x4f, 0x8c, 0x47, 0x0, 0xe5, 0xd,
-	0x22, 0xac, 0x68, 0xb7, 0x7, 0x90, 0xc7, 0x77, 0x5, 0xd7, 0x34, 0xb5, 0xdf,
-	0x9d, 0x2f, 0xfd, 0xb7, 0x8a, 0x27, 0x6, 0x98, 0x40, 0xd0, 0x3, 0xbd, 0x28,
-	0x5f, 0xcc, 0x4c, 0x15, 0x9e, 0xee, 0xb1, 0xd0, 0xaa, 0xd7, 0x3f, 0xd3, 0x16,
-	0x59, 0xf9, 0xe7, 0xf5, 0xdc, 0x31, 0x9e, 0x1e, 0x60, 0x2, 0x41, 0x46, 0xb,
-	0x95, 0x1f, 0xf3, 0x4c, 0x4e, 0x99, 0x45, 0x66, 0xfc, 0xfc, 0x39, 0x61, 0xbe,
-	0x6c, 0xb6, 0xae, 0x9e, 0x23, 0xe4, 0xf1, 0x26, 0x24, 0x60, 0xa1, 0x8c, 0x83,
-	0xa4, 0xbe, 0xa6, 0x64, 0xe2, 0xab, 0x5f, 0x90, 0x98, 0x37, 0xad, 0xc8, 0x4c,
-	0xc1, 0x79, 0xb2, 0x89, 0xa7, 0x8, 0x98, 0x40, 0x0, 0xc9, 0x9a, 0x12, 0xde,
-	0x1b, 0xcb, 0xba, 0x2b, 0xb5, 0x81, 0x27, 0x9, 0x98, 0x40, 0x0, 0xc9, 0xde,
-	0xd8, 0x79, 0x0, 0x91, 0x19, 0x37, 0x4c, 0xc2, 0x13, 0x5, 0x4c, 0x20, 0x80,
-	0x24, 0x8c, 0x6d, 0x2, 0x98, 0x0, 0x26, 0x50, 0xfe, 0x40, 0xf2, 0x63, 0x3e,
-	0x5c, 0x90, 0x10, 0x91, 0xa6, 0x22, 0x9e, 0xec, 0x68, 0xa1, 0x68, 0xd, 0xca,
-	0x8c, 0x5e, 0x94, 0xeb, 0x45, 0x26, 0x55, 0x26, 0x12, 0x3c, 0xc, 0x38, 0x13,
-	0x8, 0xa, 0xa, 0x92, 0x8b, 0x99, 0xa9, 0x49, 0x75, 0x10, 0xe4, 0xd4, 0x6, 0x2,
-	0x4c, 0x20, 0xe8, 0x4e, 0x53, 0x93, 0xd3, 0xbb, 0x4c, 0x82, 0xdc, 0x6, 0x60,
-	0x2, 0x41, 0xc1, 0xb5, 0x50, 0xa9, 0xaf, 0x31, 0xc9, 0x5a, 0x54, 0x7f, 0x3f,
-	0xb3, 0x42, 0xd1, 0x1a, 0x60, 0x2, 0x65, 0x1f, 0x24, 0x11, 0x24, 0x5c, 0x7f,
-	0xd3, 0xad, 0x68, 0xdc, 0xd1, 0xf1, 0x20, 0x24, 0x60, 0xa1, 0xd4, 0x2a, 0xae,
-	0x84, 0xab, 0x52, 0x1a, 0xce, 0xc4, 0x8b, 0x83, 0xc3, 0x23, 0x80, 0xd2, 0x9,
-	0x92, 0x8b, 0x99, 0xa9, 0xc9, 0xe9, 0x72, 0x1c, 0x79, 0x92, 0xbf, 0x97, 0x9f,
-	0x61, 0x9f, 0x20, 0xcc, 0x81, 0xb2, 0xaa, 0xb8, 0x12, 0xae, 0x42, 0x8c, 0x10,
-	0x7, 0x30, 0x81, 0xb2, 0xaa, 0xc5, 0xd3, 0xc6, 0x66, 0x94, 0x9, 0xd7, 0xdf, 0x4,
-	0x98, 0x0, 0x26, 0x50, 0x16, 0xf5, 0xea, 0xa4, 0x5e, 0x62, 0x92, 0xdd, 0x18,
-	0x7f, 0xe4, 0x37, 0x3c, 0x75, 0xc0, 0x4, 0xca, 0x98, 0x5e, 0x94, 0x2f, 0x66,
-	0x26, 0x94, 0x3a, 0x88, 0xf3, 0x67, 0xa, 0xb7, 0xd1, 0x1c, 0x9, 0x30, 0x81,
-	0xb2, 0xa6, 0xa9, 0xc2, 0x74, 0xec, 0x15, 0xae, 0x68, 0xdd, 0x8, 0x98, 0x40,
-	0x19, 0xd3, 0xc2, 0x49, 0xfd, 0x3, 0x8b, 0x94, 0xe2, 0x75, 0x25, 0xc, 0x57, 0x2,
-	0x98, 0x40, 0x99, 0x2, 0x49, 0xe5, 0xc7, 0xbc, 0x62, 0xde, 0x8e, 0xfb, 0xe7,
-	0x8a, 0x8, 0x5c, 0x9, 0x60, 0x2, 0x65, 0x45, 0x2f, 0xca, 0x17, 0x33, 0x8a, 0x9c,
-	0x83, 0x24, 0x7e, 0x36, 0x13, 0x7f, 0xc7, 0x37, 0xe0, 0x5d, 0xa8, 0x80, 0xcd,
-	0xdc, 0xe6, 0xab, 0x17, 0x1d, 0xa7, 0xd3, 0x7f, 0x43, 0x11, 0xdd, 0x85, 0x5,
-	0xac, 0xd4, 0x9b, 0x91, 0x6f, 0x61, 0x91, 0x7f, 0x3b, 0xaf, 0x18, 0x7d, 0x68,
-	0x4b, 0xae, 0xa0, 0x30, 0xf9, 0x74, 0x7b, 0xe8, 0x58, 0x8a, 0x8, 0x85, 0x32,
-	0x7a, 0xc0, 0x24, 0x17, 0x7a, 0x75, 0x52, 0x2f, 0x4d, 0xb0, 0x9a, 0x17, 0x92,
-	0xff, 0x31, 0xf3, 0x3c, 0x13, 0x15, 0x7, 0x6d, 0x3a, 0x61, 0xbe, 0x34, 0xf5,
-	0x12, 0x42, 0x35, 0x11, 0xfd, 0x9d, 0x49, 0x55, 0x6f, 0x49, 0x5f, 0xb6, 0xdb,
-	0xd7, 0x55, 0x9b, 0x3b, 0xaf, 0x9b, 0x19, 0x37, 0x24, 0x89, 0x35, 0x74, 0x76,
-	0x9f, 0xf, 0x56, 0x9a, 0x1f, 0x27, 0x7, 0xa5, 0x22, 0x67, 0xc0, 0xe2, 0xb8,
-	0x2e, 0xe3, 0x45, 0xff, 0x4, 0xba, 0x61, 0xe0, 0x10, 0xad, 0xbf, 0xb8, 0xd0,
-	0x68, 0xb6, 0x75, 0xf5, 0xdb, 0xca, 0x5c, 0x2d, 0x8d, 0xe1, 0xcd, 0x54, 0x61,
-	0xfa, 0x22, 0xb1, 0xfe, 0x24, 0xcc, 0xb5, 0xbf, 0x97, 0x66, 0x9f, 0x63, 0xf5,
-	0xc1, 0x99, 0xa4, 0x3e, 0x54, 0x99, 0x9a, 0x54, 0xab, 0xc4, 0xf2, 0x86, 0x88,
-	0x4b, 0x9d, 0xd, 0xd5, 0xbd, 0xcc, 0x26, 0x8f, 0xc1, 0x43, 0x8e, 0x85, 0xe4,
-	0x88, 0x48, 0x57, 0xb3, 0x72, 0x94, 0xd9, 0x19, 0x98, 0x95, 0x5c, 0xa3, 0x23,
-	0x21, 0x24, 0x5f, 0x1, 0x93, 0x14, 0xbb, 0xf, 0x92, 0x89, 0xf7, 0x8a, 0x79,
-	0xb5, 0xe3, 0x3c, 0xa4, 0xb, 0xe, 0x79, 0x64, 0xa1, 0x73, 0x55, 0x44, 0x1f,
-	0xd9, 0x94, 0xdb, 0x8, 0x3b, 0x84, 0xf3, 0x33, 0x79, 0x2f, 0x1a, 0x9a, 0xf0,
-	0x17, 0xac, 0x4a, 0xc0, 0x24, 0xc5, 0x0, 0x31, 0x99, 0xd0, 0x1, 0x6f, 0x4a,
-	0x73, 0xe1, 0xec, 0x73, 0xf3, 0x46, 0x1f, 0x7e, 0x5b, 0x79, 0x56, 0xcb, 0xae,
-	0x2b, 0xbb, 0x98, 0x71, 0x94, 0xda, 0x1b, 0xf4, 0x1c, 0xe2, 0x12, 0x92, 0xaf,
-	0x80, 0x49, 0x3a, 0x72, 0x1, 0x93, 0xd3, 0x6b, 0xcc, 0xb4, 0x31, 0xa, 0x20,
-	0x66, 0x80, 0xb6, 0xc8, 0x61, 0xeb, 0x46, 0x3e, 0x65, 0x19, 0x20, 0xfd, 0x4a,
-	0xf2, 0xf4, 0xa6, 0x5f, 0x98, 0x31, 0xc, 0x98, 0x58, 0x6d, 0xdd, 0x27, 0x14,
-	0x6f, 0x74, 0xec, 0xbb, 0xc, 0xed, 0xe7, 0x63, 0x2a, 0x2f, 0x35, 0x7d, 0x3a,
-	0x5b, 0x9e, 0x3d, 0xcc, 0x9b, 0x53, 0x53, 0x42, 0x89, 0x8f, 0xe3, 0x44, 0xe5,
-	0x2b, 0x60, 0x62, 0xe9, 0x6, 0xa9, 0xaf, 0x29, 0x52, 0x9d, 0xb7, 0xed, 0x70,
-	0x80, 0x5c, 0x92, 0xd0, 0x61, 0xf3, 0x46, 0xef, 0xe4, 0xc5, 0x85, 0xfc, 0x61,
-	0xc4, 0xc8, 0xd9, 0xb5, 0x61, 0x4c, 0x5, 0x2a, 0x5f, 0x1, 0x13, 0xab, 0x42,
-	0x99, 0x82, 0xf3, 0x64, 0x53, 0x29, 0xf5, 0xfe, 0x2e, 0x99, 0x3a, 0x4, 0x22,
-	0xa2, 0xf5, 0xa7, 0xd6, 0xcd, 0xaf, 0x8f, 0x79, 0x9e, 0xb6, 0x6f, 0x9a, 0x42,
-	0xc7, 0x7c, 0xf7, 0x66, 0x30, 0xd5, 0x4, 0x6d, 0x7, 0x2, 0xbd, 0xc, 0xa0, 0xd0,
-	0x21, 0xc2, 0x4a, 0x6d, 0x8c, 0x3a, 0xd6, 0xbc, 0x83, 0x48, 0xfb, 0x3a, 0xd7,
-	0x10, 0x21, 0x1b, 0x6a, 0x4a, 0x1e, 0x77, 0x89, 0x3b, 0x67, 0xcb, 0xb3, 0x1f,
-	0xb1, 0xaa, 0x1, 0x93, 0xd8, 0xb5, 0x78, 0xda, 0xd8, 0x24, 0xa6, 0x6d, 0x40,
-	0x24, 0x80, 0x2b, 0x39, 0xa9, 0x7f, 0x48, 0xe2, 0x22, 0x9f, 0x7, 0xa8, 0x1c,
-	0x37, 0x5b, 0x7a, 0x3d, 0x8d, 0x45, 0x7f, 0x80, 0x49, 0x1a, 0x21, 0x72, 0xd2,
-	0x58, 0x65, 0x45, 0xbb, 0x5e, 0x4e, 0x20, 0x84, 0x78, 0xbf, 0x79, 0x73, 0xb5,
-	0x5, 0x88, 0xf4, 0xbb, 0x92, 0x7a, 0xf1, 0xc9, 0x24, 0x5f, 0xd8, 0xfa, 0xf9,
-	0x8c, 0x4b, 0xd1, 0xb4, 0x7e, 0xf6, 0x3a, 0x5f, 0xc9, 0x70, 0xc0, 0x24, 0xd6,
-	0x18, 0xff, 0xc7, 0x3c, 0x93, 0xb3, 0xeb, 0x25, 0xce, 0x17, 0xe2, 0xea, 0xad,
-	0xe8, 0x2d, 0x1c, 0x37, 0x3e, 0x2, 0xe3, 0xca, 0xcf, 0x83, 0xc4, 0xb, 0xd4,
-	0x3c, 0x41, 0x85, 0xe, 0x9b, 0xad, 0x5f, 0xeb, 0x78, 0x11, 0x0, 0x26, 0xa1,
-	0xc6, 0xf7, 0x85, 0xc9, 0xa7, 0xdb, 0x8a, 0x46, 0x1f, 0x61, 0x22, 0xf6, 0x1e,
-	0xae, 0x57, 0x27, 0xf5, 0x92, 0xc3, 0x5c, 0x4e, 0xcb, 0xe7, 0x75, 0xbf, 0xcf,
-	0x5b, 0xad, 0xdf, 0xe1, 0xa5, 0xf0, 0xb8, 0xd0, 0xcf, 0xc4, 0x67, 0x48, 0xf3,
-	0xa4, 0x30, 0xfd, 0xd5, 0x1b, 0x48, 0xdc, 0x37, 0xd9, 0xd5, 0x73, 0x80, 0x64,
-	0xb0, 0x26, 0x94, 0xda, 0x4e, 0xd5, 0x9b, 0x57, 0x64, 0xc6, 0x85, 0xdf, 0xc2,
-	0x49, 0xfd, 0x3, 0xbe, 0x3d, 0x38, 0x93, 0xc0, 0x6e, 0xa4, 0x73, 0xf1, 0x6c,
-	0xb4, 0x1d, 0x47, 0x8c, 0x9d, 0x4d, 0x57, 0xf2, 0x48, 0xe8, 0x8a, 0xfc, 0x17,
-	0x9c, 0x89, 0xff, 0x45, 0xdf, 0x39, 0xb6, 0xf4, 0x4, 0x92, 0x63, 0xe3, 0x46,
-	0x0, 0x92, 0xd1, 0xae, 0x84, 0xd5, 0x6e, 0xba, 0xdf, 0xc2, 0xb2, 0x36, 0x35,
-	0x39, 0x5d, 0x76, 0x5f, 0x34, 0xf8, 0x36, 0xe1, 0x4c, 0x46, 0x6a, 0xe1, 0xf4,
-	0xe7, 0xae, 0x97, 0x90, 0xa6, 0xfb, 0xa6, 0xda, 0x42, 0x48, 0xe3, 0xf1, 0xb9,
-	0x56, 0xea, 0x6b, 0x51, 0xf, 0x1b, 0x8f, 0xcd, 0xa1, 0x30, 0x5f, 0xa, 0xb5,
-	0x57, 0xd0, 0xc5, 0x1e, 0x30, 0x19, 0x10, 0xd6, 0x98, 0x7e, 0x22, 0x7, 0x9e,
-	0xc6, 0x4f, 0x32, 0xd7, 0x34, 0xb5, 0xdf, 0x61, 0x31, 0x79, 0xd7, 0x5f, 0x95,
-	0xc6, 0x85, 0xd, 0x97, 0xf9, 0xc2, 0x4, 0xa, 0x12, 0xb3, 0x8, 0x73, 0xfe, 0xd0,
-	0xe2, 0x49, 0x63, 0x75, 0xaa, 0xa0, 0xbe, 0x7a, 0x1, 0x89, 0x1b, 0xd6, 0x5c,
-	0xb7, 0xae, 0x5e, 0x2, 0x24, 0xfe, 0x9e, 0x6f, 0x96, 0x40, 0x42, 0xfd, 0x89,
-	0xd9, 0x4a, 0x7d, 0xd, 0x30, 0x81, 0x3a, 0xf6, 0xfb, 0xa4, 0xfe, 0x81, 0x59,
-	0x3c, 0xd, 0x7a, 0xd2, 0x44, 0x1f, 0xcf, 0x96, 0x66, 0x57, 0x90, 0x80, 0xf3,
-	0xbd, 0xe2, 0x36, 0x32, 0xfb, 0xab, 0x9, 0xef, 0xe5, 0x19, 0x28, 0x8, 0x73,
-	0x7c, 0x9e, 0xd6, 0x18, 0x90, 0xb0, 0xac, 0x9f, 0x2f, 0xcd, 0xed, 0xe3, 0xc9,
-	0xf9, 0x53, 0xda, 0x4f, 0x70, 0xbc, 0x2a, 0xaf, 0xeb, 0x43, 0x1, 0x24, 0xa6,
-	0x59, 0x51, 0xd9, 0xeb, 0xb1, 0x6f, 0x5b, 0x64, 0x5, 0x20, 0x9, 0xa6, 0xb4,
-	0xd5, 0x95, 0xc0, 0xa1, 0xc0, 0x99, 0x78, 0xf, 0x6b, 0x3a, 0x25, 0xf1, 0x65,
-	0x2f, 0x61, 0xd, 0xb2, 0xf6, 0xe3, 0x3f, 0x6b, 0x25, 0x13, 0x5f, 0xed, 0xdb,
-	0x1, 0x5c, 0x8b, 0x2a, 0x87, 0x93, 0x37, 0x87, 0xa2, 0x0, 0x12, 0x80, 0x24,
-	0x96, 0x3d, 0x2b, 0x8e, 0x95, 0xb9, 0x12, 0x4d, 0x7a, 0xe7, 0xfa, 0x46, 0x9e,
-	0xb, 0xf1, 0x3e, 0x1c, 0xa, 0x9c, 0x49, 0x0, 0x90, 0x78, 0xaf, 0x73, 0x10, 0xe2,
-	0x6a, 0xf3, 0xe6, 0xa, 0x89, 0xd6, 0xb1, 0x42, 0x49, 0x3b, 0x6f, 0x6, 0xbb,
-	0x2f, 0x89, 0xb3, 0xa5, 0xd9, 0xff, 0xfc, 0xf6, 0x82, 0xd9, 0xd, 0xbb, 0x49,
-	0x53, 0x5e, 0x1c, 0x8a, 0x3, 0x90, 0x64, 0xb, 0x24, 0xa6, 0xd7, 0x2c, 0xa9,
-	0x19, 0x21, 0x3d, 0xcf, 0x8a, 0x5f, 0x10, 0xf1, 0xc, 0x9, 0xd5, 0x3a, 0x9b,
-	0x47, 0x7f, 0x69, 0xb5, 0xe8, 0x38, 0xee, 0xfe, 0x1c, 0x5, 0x87, 0xec, 0x7c,
-	0x3b, 0xb, 0x3d, 0xa8, 0x54, 0xee, 0x3a, 0xcf, 0x15, 0xaf, 0xbd, 0x69, 0xfc,
-	0x38, 0x94, 0x57, 0x27, 0xf5, 0x5a, 0xd6, 0xeb, 0x50, 0x18, 0x20, 0x19, 0x1c,
-	0x4b, 0x5f, 0xb7, 0xae, 0x5e, 0xda, 0xc, 0x12, 0xf7, 0x8d, 0x5f, 0x28, 0x50,
-	0x89, 0xc5, 0xcc, 0x11, 0x9e, 0xef, 0xd5, 0xc6, 0xf4, 0xfa, 0xc9, 0x76, 0xe0,
-	0x71, 0x7d, 0x98, 0xf4, 0xef, 0xb0, 0x58, 0x69, 0xfc, 0x3f, 0x5b, 0xba, 0xa8,
-	0x3d, 0x74, 0xc, 0xb7, 0x3, 0x6b, 0x84, 0x4c, 0xe1, 0x62, 0x41, 0xed, 0x85,
-	0xe5, 0x52, 0xf2, 0x10, 0x2a, 0x33, 0x40, 0x92, 0x9e, 0x2f, 0xde, 0xb4, 0x3f,
-	0x28, 0x3c, 0x59, 0x75, 0xe1, 0xf1, 0xfb, 0x98, 0x50, 0xf3, 0x99, 0x45, 0xf6,
-	0x89, 0x6f, 0x3f, 0xdb, 0xf4, 0xb9, 0x6d, 0x2d, 0x9d, 0x77, 0x5d, 0xe7, 0xd9,
-	0xf2, 0xec, 0xcb, 0x91, 0x20, 0xc, 0xd1, 0xa5, 0xb8, 0xdf, 0x51, 0xb3, 0x75,
-	0xf5, 0x3c, 0xab, 0x21, 0x73, 0x2e, 0x60, 0x62, 0xba, 0xa1, 0xb1, 0x1c, 0xa4,
-	0x11, 0x24, 0x77, 0x0, 0x21, 0x7e, 0xfb, 0xd8, 0xf1, 0xb5, 0x49, 0x1c, 0xa,
-	0x1d, 0xd9, 0x7a, 0xb9, 0x70, 0xb1, 0xd2, 0x28, 0x5b, 0xd3, 0x28, 0x3a, 0x60,
-	0x1e, 0xa3, 0x3b, 0xeb, 0x79, 0xcf, 0xd3, 0xf5, 0x8a, 0xc, 0x86, 0xce, 0x80,
-	0x49, 0xff, 0x42, 0x48, 0xe1, 0xa9, 0x8d, 0x29, 0x3b, 0x67, 0x7a, 0xcb, 0x24,
-	0x6b, 0x8f, 0x85, 0x60, 0x5a, 0xeb, 0xcf, 0xb6, 0xf7, 0x91, 0xb5, 0x39, 0xf1,
-	0xea, 0xd7, 0x21, 0x74, 0xeb, 0x91, 0x76, 0x1f, 0xfd, 0x3e, 0xfc, 0x3, 0x65,
-	0xff, 0x6c, 0x79, 0x76, 0x1d, 0x30, 0xc9, 0x28, 0x48, 0xc8, 0x82, 0xac, 0xfb,
-	0xa3, 0xd3, 0xfe, 0x7e, 0x87, 0x8, 0xe9, 0x9d, 0xb4, 0x9c, 0xc, 0xf8, 0xb9,
-	0x75, 0x1d, 0xab, 0x2b, 0x21, 0xfa, 0x78, 0xbe, 0xfc, 0x6c, 0x2b, 0x68, 0xd8,
-	0xc6, 0xa4, 0x76, 0xc7, 0xd, 0x7b, 0xb2, 0x78, 0xcb, 0x3c, 0xb3, 0x30, 0xe9,
-	0x26, 0xd0, 0xbe, 0x7a, 0x6, 0x89, 0xc8, 0xce, 0xf9, 0xeb, 0xb9, 0xf, 0x49,
-	0x7d, 0xd6, 0xc2, 0x24, 0x6f, 0x30, 0xf3, 0xda, 0xa3, 0x9f, 0x37, 0x65, 0x10,
-	0xe9, 0xc9, 0xd6, 0xdb, 0xc1, 0xd7, 0x37, 0xf2, 0x7c, 0x9c, 0x13, 0x2d, 0xbf,
-	0x2f, 0xa9, 0xc1, 0x2f, 0xaf, 0xdb, 0x4c, 0x5d, 0x12, 0xe5, 0x6c, 0x82, 0xa4,
-	0x5b, 0x22, 0xef, 0x31, 0xc6, 0x15, 0xa6, 0xc3, 0xb3, 0xa5, 0x67, 0xef, 0x12,
-	0x1, 0xde, 0xa4, 0xda, 0x1e, 0x64, 0x9d, 0xd3, 0xdc, 0x43, 0xd6, 0x4f, 0x9e,
-	0x2a, 0xde, 0x10, 0x27, 0x9c, 0xef, 0xda, 0xef, 0x1a, 0x7b, 0x7c, 0xf7, 0xd9,
-	0x7f, 0x62, 0xe8, 0x47, 0x99, 0xac, 0x80, 0x35, 0x97, 0xf6, 0xbc, 0x82, 0x84,
-	0xb8, 0xda, 0x6c, 0xfd, 0x5a, 0x8f, 0x1b, 0x76, 0x6e, 0x8, 0xf0, 0x64, 0x92,
-	0x2f, 0x6, 0x81, 0xc4, 0xb5, 0xe2, 0xa9, 0xee, 0x21, 0xcb, 0xf4, 0xd6, 0xca,
-	0x8f, 0x25, 0xb4, 0x1a, 0x46, 0x55, 0xaa, 0xb, 0x80, 0xe6, 0xcd, 0xd5, 0x8a,
-	0xb, 0xa7, 0xe0, 0x64, 0x93, 0xa2, 0xbb, 0x56, 0xe1, 0x4c, 0x6c, 0x8d, 0xd3,
-	0x7d, 0xc, 0x74, 0x4a, 0x22, 0xe1, 0x3a, 0xea, 0xa8, 0xd1, 0x85, 0x9b, 0x70,
-	0x7b, 0x3d, 0xcd, 0xf6, 0xd7, 0xb6, 0x9, 0x7d, 0x3, 0x9e, 0x73, 0x68, 0x49,
-	0xd0, 0xc5, 0xd3, 0xc6, 0xde, 0x38, 0x89, 0xd9, 0xac, 0xe4, 0x4f, 0x32, 0x5,
-	0x13, 0xbf, 0xd6, 0x3a, 0xce, 0x84, 0xab, 0xa9, 0x4c, 0x65, 0xb5, 0x3b, 0xc8,
-	0x31, 0x65, 0x69, 0x2c, 0x46, 0x5a, 0xda, 0x32, 0x86, 0xd9, 0x14, 0x7a, 0x1c,
-	0xa0, 0x74, 0x4e, 0x97, 0xf4, 0xcb, 0xb4, 0x4f, 0xe, 0x54, 0xd9, 0xc9, 0x93,
-	0xd4, 0x8b, 0xa4, 0x68, 0xcf, 0xcf, 0x42, 0x8a, 0x3, 0x24, 0xbd, 0x90, 0xc6,
-	0x61, 0x1e, 0x18, 0x5f, 0x1b, 0x37, 0x42, 0xed, 0x95, 0xac, 0x64, 0xf7, 0x99,
-	0xf8, 0x6d, 0x3a, 0x3e, 0x67, 0x78, 0x4d, 0xa1, 0x5d, 0x97, 0x13, 0xf4, 0xb2,
-	0xa0, 0xeb, 0xe0, 0xa6, 0xa, 0x6a, 0xf, 0x39, 0x13, 0x5b, 0xf2, 0x24, 0x93,
-	0xea, 0xc0, 0xb3, 0xad, 0x66, 0xae, 0xb9, 0x6f, 0xa4, 0x38, 0xdc, 0xc8, 0xa8,
-	0x39, 0x3b, 0xa6, 0x63, 0xdb, 0xf2, 0x6c, 0x66, 0xb2, 0xfa, 0xee, 0xc6, 0x4c,
-	0xc3, 0x84, 0xbe, 0x3e, 0xa0, 0xcc, 0x5b, 0x2, 0x94, 0x92, 0x9, 0x81, 0x1, 0x93,
-	0x84, 0x6d, 0xf5, 0xe9, 0xcf, 0x5d, 0x3f, 0x59, 0xf5, 0xb6, 0xd6, 0x91, 0x8f,
-	0x79, 0x5c, 0x38, 0xa9, 0x7f, 0x30, 0x5d, 0xc5, 0x6, 0x1c, 0x8d, 0xba, 0xd6,
-	0xd6, 0x84, 0x59, 0x1, 0xeb, 0x1d, 0x6c, 0x55, 0xa1, 0xf0, 0x64, 0x35, 0x7d,
-	0x4e, 0xca, 0xe, 0xa0, 0x10, 0xd3, 0xb6, 0x71, 0xd8, 0x80, 0x49, 0x32, 0x72,
-	0xdf, 0xfe, 0x7e, 0xa, 0xa3, 0xb4, 0xc8, 0x4e, 0x94, 0xb7, 0x37, 0xdd, 0x5,
-	0xb9, 0x58, 0x69, 0x94, 0x87, 0x26, 0x81, 0x99, 0x6b, 0x9d, 0xc4, 0x6f, 0xf6,
-	0xae, 0xa5, 0xa7, 0x25, 0xc4, 0xb1, 0x11, 0x28, 0x69, 0xf, 0x77, 0x54, 0xda,
-	0x2d, 0xf5, 0x84, 0x52, 0xde, 0x6b, 0x19, 0x98, 0x6b, 0xad, 0xf6, 0xf5, 0xc7,
-	0x28, 0xc1, 0xd6, 0x3d, 0xc5, 0x28, 0xd, 0xc9, 0xd5, 0x54, 0xb3, 0xdd, 0xd1,
-	0x9e, 0x4b, 0xe9, 0x5, 0xa1, 0x15, 0x40, 0x29, 0x99, 0xab, 0x14, 0x80, 0x49,
-	0xcc, 0x79, 0x12, 0xd3, 0x4, 0xda, 0xfb, 0xf1, 0x63, 0x94, 0xe1, 0xcd, 0x42,
-	0xa5, 0xbe, 0x66, 0x92, 0xac, 0x43, 0x3e, 0x4f, 0xf7, 0xf4, 0x20, 0xb3, 0x8d,
-	0x96, 0xcc, 0x69, 0x9a, 0xc5, 0xc7, 0xc1, 0x7e, 0x80, 0x12, 0xc6, 0xdf, 0xd5,
-	0xbc, 0xb9, 0xda, 0x72, 0x5f, 0x1e, 0xbe, 0x3f, 0x83, 0xa2, 0xdd, 0x34, 0x4e,
-	0xa, 0x54, 0xe9, 0x5e, 0xb8, 0xde, 0x13, 0x7d, 0xee, 0x46, 0x8e, 0x2a, 0xbc,
-	0x59, 0x3c, 0x6d, 0xec, 0x8d, 0x3a, 0xa, 0xed, 0xd5, 0x35, 0x64, 0xb9, 0x63,
-	0x9b, 0xb0, 0xbc, 0xc9, 0x46, 0xa8, 0x26, 0xf3, 0xee, 0x77, 0x3a, 0xee, 0xdf,
-	0xd3, 0x2b, 0x6c, 0x33, 0x7d, 0x66, 0x7d, 0x3d, 0x48, 0x29, 0x16, 0x9c, 0x27,
-	0x9b, 0x80, 0x49, 0x4c, 0xe1, 0x8d, 0x4b, 0x6f, 0xef, 0x8b, 0x9c, 0x2f, 0x9b,
-	0x37, 0x7a, 0x27, 0x2a, 0x90, 0x8c, 0xaa, 0x2f, 0xc8, 0xea, 0x2d, 0xd1, 0x3f,
-	0x16, 0x13, 0xf3, 0x6a, 0x66, 0x82, 0x35, 0x92, 0xb5, 0x85, 0x93, 0xfa, 0x87,
-	0x30, 0x80, 0xa2, 0xa9, 0xfd, 0xce, 0xd4, 0x11, 0xf9, 0x72, 0x27, 0x6a, 0x23,
-	0x6d, 0xee, 0x24, 0x95, 0x30, 0x29, 0x4c, 0x3e, 0xdd, 0xf6, 0x73, 0x81, 0x4c,
-	0xb4, 0xfe, 0x14, 0x76, 0x41, 0x90, 0x49, 0xb4, 0x9e, 0x36, 0xbe, 0x2, 0x24,
-	0xbd, 0xe7, 0x51, 0x2f, 0x5a, 0x75, 0xa9, 0x8f, 0xb9, 0xe6, 0x77, 0x3, 0x3f,
-	0x2, 0xc7, 0xed, 0x30, 0x4a, 0xef, 0x4d, 0x7e, 0x4c, 0xd3, 0xba, 0xcf, 0xdc,
-	0x89, 0x69, 0x79, 0x0, 0x98, 0x44, 0x28, 0x33, 0x32, 0xc1, 0xcf, 0xb5, 0xf6,
-	0x8, 0x92, 0xae, 0x5e, 0x2f, 0x79, 0xe5, 0x5, 0x24, 0xd4, 0xa9, 0xf3, 0xb1,
-	0xca, 0x95, 0x5c, 0xb7, 0xf4, 0x4a, 0xb3, 0xa5, 0x5f, 0xa, 0xf3, 0xf1, 0x78,
-	0xe, 0x45, 0xed, 0xba, 0x6b, 0x6e, 0xdc, 0xcf, 0x73, 0xf6, 0x7a, 0xf6, 0x50,
-	0x8b, 0xec, 0xf8, 0x75, 0x47, 0x69, 0x3a, 0x2a, 0x56, 0xe9, 0xb3, 0x9f, 0x8e,
-	0x2f, 0x5a, 0x6b, 0xd2, 0x3b, 0x61, 0xe6, 0x29, 0x0, 0x92, 0x81, 0xaf, 0x52,
-	0x6b, 0xf2, 0x25, 0xee, 0xb3, 0x77, 0x9d, 0xa8, 0xfb, 0xcf, 0xd9, 0xd2, 0xec,
-	0x8a, 0x10, 0x6f, 0x5, 0xff, 0xb5, 0x64, 0x86, 0xc5, 0xd9, 0xb, 0x23, 0xe4,
-	0x38, 0x7f, 0x3d, 0xf7, 0xc1, 0x2f, 0xdc, 0xa6, 0x26, 0xd3, 0x33, 0xb8, 0x2c,
-	0x55, 0x30, 0xe9, 0x9e, 0x16, 0x94, 0xfc, 0xb8, 0x92, 0x30, 0x6b, 0x39, 0x7c,
-	0x80, 0xa4, 0x1a, 0x47, 0x85, 0xad, 0x65, 0x34, 0xb1, 0xe2, 0x48, 0xb8, 0x93,
-	0x1f, 0x7b, 0xf8, 0xec, 0xcf, 0x96, 0x67, 0x3f, 0x6a, 0xbe, 0x7d, 0xe9, 0x3b,
-	0x11, 0x7a, 0xef, 0x10, 0xe6, 0xc3, 0xa, 0x39, 0x9a, 0xad, 0x2b, 0x5f, 0xf9,
-	0x93, 0x34, 0xb9, 0x93, 0x54, 0xc1, 0xc4, 0x4f, 0xd2, 0xb5, 0xe7, 0x4a, 0xc2,
-	0xb5, 0xf2, 0xd3, 0xa3, 0x2b, 0x6d, 0x3b, 0xa5, 0xfa, 0xb9, 0x9a, 0xb3, 0xd3,
-	0xe9, 0x91, 0x6a, 0xc9, 0x91, 0xb0, 0xd0, 0xa3, 0x4e, 0xf4, 0x7c, 0xe9, 0xbf,
-	0xa6, 0xbe, 0x27, 0x68, 0xcb, 0x0, 0x77, 0x53, 0x87, 0x51, 0xff, 0x61, 0x3e,
-	0x9b, 0xcf, 0xfc, 0x49, 0x5a, 0xdc, 0x49, 0x6a, 0x60, 0x62, 0xee, 0x2d, 0xf8,
-	0x49, 0xf0, 0x85, 0xec, 0x4a, 0x3c, 0x9d, 0xda, 0x30, 0x9b, 0xcc, 0x7d, 0xde,
-	0x6, 0x76, 0xb1, 0x38, 0x25, 0x4b, 0xc2, 0x9b, 0xea, 0xb0, 0xcb, 0x92, 0xee,
-	0xf7, 0x72, 0xb6, 0xf4, 0xec, 0x9d, 0x26, 0xfa, 0x18, 0x70, 0xb7, 0x84, 0x12,
-	0xee, 0x98, 0xfc, 0x89, 0x8f, 0xcf, 0x90, 0x16, 0x77, 0x92, 0xa, 0x98, 0x98,
-	0x2f, 0x90, 0x69, 0x3b, 0x29, 0x57, 0xb2, 0x70, 0x52, 0xff, 0xe0, 0xe5, 0x7a,
-	0xb9, 0x90, 0xde, 0xca, 0xe5, 0x8, 0x51, 0x4b, 0xf2, 0x25, 0xb7, 0xa2, 0x3d,
-	0x85, 0x96, 0xe7, 0xcb, 0xcf, 0xb6, 0x34, 0xcb, 0x7a, 0x90, 0xfc, 0x49, 0x58,
-	0xcd, 0x8c, 0x5a, 0x37, 0xbf, 0x76, 0xfc, 0x84, 0x5d, 0x85, 0x49, 0xde, 0x0,
-	0x4c, 0x42, 0x50, 0xc1, 0x79, 0xb2, 0xe9, 0xcb, 0x46, 0x87, 0xe8, 0x4a, 0x5c,
-	0x6b, 0xeb, 0xa5, 0xd9, 0x52, 0x5c, 0x2d, 0xd, 0x90, 0x2f, 0x19, 0xf4, 0xf2,
-	0xa0, 0x8f, 0x7e, 0x8a, 0x12, 0xdd, 0xef, 0xca, 0x5, 0x8a, 0xef, 0xfa, 0xf,
-	0xa1, 0xd5, 0xb0, 0xc2, 0x9d, 0xb6, 0xd6, 0xeb, 0xde, 0x97, 0x34, 0xaf, 0xd9,
-	0x5e, 0x77, 0xa2, 0xd2, 0xe0, 0x4a, 0x58, 0x29, 0x5f, 0x54, 0xd6, 0x5a, 0x7f,
-	0xe, 0x2b, 0x17, 0xe0, 0xa5, 0x47, 0x4a, 0x3e, 0x13, 0xae, 0xbd, 0xef, 0xa7,
-	0x5e, 0x4c, 0x3a, 0x5f, 0xe2, 0x2, 0xc1, 0xbc, 0xe9, 0x7d, 0xca, 0x5, 0x8a,
-	0x50, 0x7b, 0xc5, 0x77, 0x3d, 0x4a, 0x48, 0xe1, 0x8e, 0xb, 0x3f, 0xaf, 0xf7,
-	0x77, 0xba, 0x75, 0x27, 0x6b, 0x80, 0x49, 0x8c, 0xae, 0xc4, 0x2c, 0xac, 0x10,
-	0xea, 0x4a, 0x3a, 0x7d, 0x39, 0x9c, 0x3d, 0x6f, 0xf3, 0x76, 0xda, 0xeb, 0x79,
-	0x1d, 0x6c, 0x3e, 0xe5, 0xa8, 0x79, 0x4a, 0xde, 0x96, 0x4, 0x7e, 0xfe, 0x6e,
-	0x58, 0xea, 0x17, 0x28, 0xee, 0x9a, 0x30, 0x85, 0x93, 0x21, 0xc8, 0xdc, 0xdf,
-	0xf1, 0xf8, 0xb3, 0xcd, 0x8, 0x14, 0xc0, 0x64, 0xc, 0x3, 0xed, 0xd3, 0x95, 0x90,
-	0x50, 0x28, 0xb3, 0x75, 0x3d, 0x9d, 0xdc, 0xf4, 0x46, 0x64, 0xe4, 0x31, 0x4f,
-	0x72, 0x7, 0xd2, 0x64, 0xf3, 0x25, 0xa6, 0xdb, 0xfc, 0x98, 0xd3, 0xc, 0x83, 0x0,
-	0x45, 0x11, 0x6d, 0x86, 0x91, 0x14, 0x75, 0xd7, 0xaa, 0x90, 0xb7, 0x5c, 0xf,
-	0x89, 0x14, 0x5f, 0x9d, 0xd4, 0x4b, 0x80, 0x49, 0xa0, 0x30, 0xa3, 0xbe, 0xe6,
-	0xd7, 0x42, 0xb, 0xb7, 0x3f, 0x85, 0x91, 0x27, 0xf1, 0x96, 0x70, 0xe5, 0x6a,
-	0x52, 0xb3, 0x76, 0xac, 0x81, 0x3d, 0xf3, 0x7c, 0x92, 0xe1, 0x4d, 0x58, 0x93,
-	0x5, 0x3a, 0x40, 0xd1, 0xbe, 0x42, 0xd5, 0xb0, 0x7a, 0x8f, 0x74, 0xc2, 0x2d,
-	0x6f, 0xb7, 0x8b, 0x27, 0x58, 0xbd, 0x7, 0x4c, 0x2, 0x7d, 0x38, 0x7f, 0xe7,
-	0xeb, 0x66, 0x73, 0x8f, 0xe9, 0x12, 0xfc, 0xf4, 0x92, 0xf5, 0x7a, 0x7a, 0x90,
-	0x69, 0x98, 0x24, 0x39, 0x47, 0x78, 0x8c, 0xf0, 0x66, 0xd0, 0xa6, 0xf6, 0x73,
-	0xca, 0xe3, 0xfe, 0xee, 0x61, 0x39, 0x5, 0xaf, 0x6b, 0xa9, 0x73, 0x4c, 0x6c,
-	0x67, 0x22, 0xd6, 0x5a, 0x98, 0x98, 0x2f, 0xc9, 0xff, 0xc5, 0xb1, 0xb1, 0x13,
-	0xaf, 0xee, 0xdb, 0xc6, 0x5b, 0x9e, 0x84, 0xe, 0xa3, 0xec, 0xd8, 0x96, 0x6,
-	0x25, 0x59, 0xfb, 0x10, 0x46, 0x78, 0x33, 0x10, 0x28, 0x3e, 0x6a, 0x40, 0x26,
-	0x54, 0x38, 0x5, 0x65, 0x26, 0x19, 0xeb, 0xb1, 0xd4, 0xde, 0xd6, 0xd6, 0x98,
-	0xd6, 0xc2, 0x64, 0x42, 0xf9, 0x3f, 0x57, 0x6f, 0xde, 0xe8, 0xb1, 0x16, 0xd7,
-	0xe2, 0x69, 0x63, 0xd3, 0xcb, 0x9b, 0xb6, 0x63, 0xaf, 0x25, 0xf7, 0xae, 0x24,
-	0xb1, 0xe4, 0x2b, 0x73, 0x2d, 0xca, 0xc1, 0x69, 0xe7, 0xcb, 0xcf, 0xb6, 0xbc,
-	0x56, 0xca, 0x86, 0xe9, 0x4e, 0x9a, 0x2d, 0x6f, 0x47, 0xc5, 0xb6, 0xb6, 0xc6,
-	0x54, 0xb6, 0xbe, 0xf1, 0xfc, 0x76, 0x38, 0x77, 0xbf, 0xfc, 0x71, 0xda, 0xc,
-	0xf8, 0x29, 0x8c, 0x8b, 0xa2, 0xa5, 0x41, 0x1a, 0x25, 0xa4, 0x13, 0x81, 0x49,
-	0x1c, 0xd, 0xc1, 0xd, 0xac, 0x3c, 0x16, 0x95, 0x85, 0xe5, 0x4e, 0xdc, 0x35,
-	0xe5, 0xe5, 0xa8, 0xd8, 0xdd, 0x1b, 0x36, 0x86, 0x3a, 0x56, 0xc2, 0xa4, 0xe0,
-	0xd0, 0x9a, 0xff, 0x85, 0x2d, 0x47, 0x63, 0xbd, 0x65, 0x27, 0xa7, 0x77, 0xbd,
-	0x85, 0x37, 0xe1, 0x1c, 0x3d, 0x67, 0x22, 0x5f, 0xa2, 0xf8, 0x45, 0xec, 0x69,
-	0x92, 0x88, 0x1b, 0x82, 0xdf, 0x6f, 0xec, 0x4e, 0x53, 0x23, 0xaf, 0xee, 0x24,
-	0x8c, 0x36, 0x5, 0x5d, 0x77, 0xbd, 0xe3, 0x71, 0xbd, 0xae, 0x1, 0x26, 0x5e,
-	0x3e, 0x94, 0xf2, 0x9f, 0xb1, 0x6e, 0xb5, 0x28, 0xf0, 0x2, 0x73, 0x6d, 0xaa,
-	0xd7, 0x69, 0x6c, 0x1d, 0x57, 0x92, 0xcf, 0x9a, 0x92, 0x47, 0xc8, 0x5a, 0x8c,
-	0xf7, 0xc7, 0xf1, 0x71, 0x9c, 0xa7, 0x67, 0x9d, 0x13, 0x1e, 0x6f, 0xed, 0xb,
-	0x58, 0x9c, 0x8d, 0xd0, 0xdc, 0x89, 0x97, 0x10, 0xcb, 0xc2, 0x59, 0xce, 0xd6,
-	0xc1, 0x24, 0x48, 0xe2, 0x55, 0x88, 0xab, 0xe3, 0x84, 0x1d, 0x5e, 0x6d, 0x2a,
-	0x5c, 0xc9, 0xef, 0xeb, 0x59, 0xe6, 0x63, 0x4, 0xc9, 0x65, 0xb3, 0x75, 0xf5,
-	0x2e, 0xee, 0xdf, 0xf1, 0x6c, 0x79, 0xf6, 0xa3, 0x97, 0xc4, 0x68, 0x98, 0xa7,
-	0x2c, 0xb7, 0x5a, 0x3e, 0x79, 0x71, 0x43, 0xb6, 0x85, 0x3a, 0xd6, 0xc1, 0x24,
-	0xc8, 0x39, 0xba, 0x88, 0xe, 0x1c, 0xe2, 0x74, 0x6b, 0x59, 0x4a, 0x70, 0x25,
-	0xfe, 0x14, 0xf7, 0x49, 0xce, 0xad, 0xd6, 0x89, 0xdd, 0xc6, 0x6e, 0xb6, 0xb4,
-	0xa7, 0x3b, 0x3c, 0x61, 0x35, 0x81, 0xf6, 0x7a, 0xb2, 0x33, 0xe5, 0x4c, 0x97,
-	0x0, 0x93, 0xe1, 0x88, 0xf7, 0x7f, 0xec, 0xa5, 0x82, 0x9f, 0xe2, 0xf8, 0xa9,
-	0x65, 0x69, 0xb5, 0x69, 0x1f, 0x18, 0xe9, 0xc8, 0x71, 0xa8, 0x98, 0xb5, 0x3c,
-	0xc9, 0xb0, 0xd0, 0x83, 0x84, 0x76, 0xa2, 0x8, 0xcf, 0x7, 0xff, 0xd2, 0xf4,
-	0x29, 0x6d, 0xa1, 0x8e, 0x55, 0x30, 0x9, 0x32, 0x77, 0xc5, 0x7d, 0x63, 0x4,
-	0x2d, 0x54, 0x33, 0xcd, 0x82, 0x3d, 0x86, 0x54, 0xbd, 0x56, 0x80, 0xc0, 0x48,
-	0x6f, 0xe3, 0xc4, 0x3, 0x13, 0x61, 0x3a, 0xb4, 0xa1, 0xca, 0xd8, 0x84, 0x3b,
-	0xa3, 0xaa, 0x54, 0x43, 0x2c, 0x77, 0x37, 0x35, 0x34, 0xa3, 0x4e, 0x93, 0xd8,
-	0xae, 0x99, 0xce, 0xca, 0x32, 0x57, 0x12, 0x80, 0xb4, 0x72, 0x1c, 0x87, 0x2b,
-	0xb9, 0x95, 0x70, 0x6e, 0x22, 0x67, 0x46, 0x3a, 0x7a, 0x98, 0x98, 0xdb, 0xd8,
-	0x11, 0xd6, 0x93, 0xf8, 0xe, 0xb5, 0x3c, 0x54, 0xa9, 0x86, 0x59, 0xee, 0x3e,
-	0xea, 0xf6, 0xbb, 0xfb, 0xe2, 0xd, 0xeb, 0x14, 0x29, 0x8b, 0x30, 0x59, 0xd,
-	0xf0, 0xea, 0xfa, 0x12, 0xb5, 0x2b, 0x71, 0xdf, 0x10, 0x79, 0xaf, 0x76, 0xfd,
-	0xf3, 0x91, 0xa8, 0xff, 0x45, 0xeb, 0x48, 0xcc, 0xac, 0x23, 0xab, 0xba, 0xd6,
-	0x79, 0x6a, 0x19, 0x10, 0xa2, 0x5b, 0xf0, 0x12, 0x56, 0xdb, 0xd2, 0xe5, 0xce,
-	0x2a, 0x98, 0x4, 0xed, 0x23, 0x2a, 0xdc, 0x3e, 0xe, 0xc6, 0x2d, 0xef, 0x6f,
-	0x10, 0x11, 0xfa, 0x4, 0x7c, 0xfc, 0xf1, 0x0, 0x23, 0x75, 0x26, 0x9d, 0x84,
-	0xab, 0x7d, 0x61, 0xe5, 0xa8, 0x3a, 0x10, 0x77, 0xd, 0x87, 0x35, 0x2b, 0xd8,
-	0xd3, 0x31, 0xb1, 0x45, 0x53, 0x1, 0xec, 0x71, 0x26, 0x3a, 0xd8, 0xdc, 0x95,
-	0x20, 0xf9, 0x12, 0x53, 0x57, 0xe2, 0xe3, 0x82, 0xda, 0xb8, 0x65, 0xfa, 0x99,
-	0x94, 0x50, 0x64, 0xc7, 0x92, 0x9a, 0x65, 0xdd, 0x56, 0x27, 0xe8, 0x6e, 0xf0,
-	0x91, 0x77, 0x77, 0xc2, 0x4c, 0x8c, 0x6a, 0x1e, 0x1e, 0xea, 0x10, 0x23, 0xcc,
-	0xf9, 0xe3, 0xa1, 0x28, 0xf5, 0x26, 0x80, 0x15, 0xe, 0xb4, 0xe0, 0xfc, 0xc4,
-	0xb5, 0xe3, 0xd6, 0xb0, 0x64, 0xd7, 0x98, 0x44, 0x53, 0x63, 0xe2, 0x6e, 0x54,
-	0xdb, 0xdb, 0x5f, 0xb6, 0x6e, 0x7e, 0xed, 0xc, 0x3b, 0x2a, 0x66, 0xa6, 0xd0,
-	0x42, 0x8f, 0xb3, 0xd7, 0xb3, 0x87, 0x43, 0x8f, 0xa5, 0x45, 0x8a, 0xb6, 0x34,
-	0x9b, 0xb6, 0x7, 0x26, 0x1, 0xae, 0xb2, 0x8b, 0x88, 0x6f, 0x57, 0x62, 0x3a,
-	0xa8, 0x79, 0xac, 0x76, 0xa5, 0x31, 0x6b, 0x58, 0x20, 0xbf, 0x66, 0x87, 0xf7,
-	0xcf, 0x97, 0x9f, 0x59, 0x7f, 0x81, 0xd2, 0x34, 0x34, 0x12, 0xd9, 0x1f, 0xb6,
-	0xc1, 0x43, 0x4d, 0x8c, 0xca, 0xf0, 0x50, 0xc7, 0x8a, 0x6e, 0x77, 0xb6, 0xc0,
-	0x24, 0xf0, 0x71, 0x1a, 0xcb, 0x37, 0xbf, 0x7f, 0xc4, 0xf7, 0xf5, 0x6d, 0x85,
-	0x10, 0xe7, 0xf1, 0xfd, 0xc2, 0x66, 0x64, 0x84, 0xd7, 0xa6, 0x3e, 0x5e, 0x5c,
-	0x66, 0x9a, 0x26, 0x20, 0xb6, 0x6e, 0x86, 0x57, 0xa9, 0x86, 0x9a, 0x18, 0x15,
-	0x3a, 0x1a, 0xfe, 0x9f, 0x35, 0x60, 0xd2, 0xf7, 0x21, 0x2, 0x3d, 0x78, 0xad,
-	0xa9, 0xe6, 0x9f, 0x3f, 0xde, 0xdb, 0x40, 0x8e, 0x53, 0xc3, 0x92, 0x75, 0xb9,
-	0xf6, 0xdb, 0x75, 0x11, 0x67, 0xcb, 0xb3, 0x2f, 0xaf, 0x6f, 0xe4, 0xb9, 0xb9,
-	0xc3, 0x12, 0x70, 0x62, 0x5e, 0xe7, 0x8, 0x38, 0xfe, 0x52, 0xf9, 0x71, 0x73,
-	0x27, 0x43, 0x4f, 0x76, 0x42, 0x4c, 0x8c, 0x8e, 0xa, 0x75, 0x82, 0xa4, 0x8,
-	0x32, 0xb, 0x13, 0x66, 0x15, 0x28, 0x61, 0xd5, 0x6e, 0x5f, 0x57, 0xfd, 0x85,
-	0x38, 0xf5, 0xa2, 0xbf, 0x58, 0x5f, 0x70, 0x1c, 0xec, 0x71, 0x63, 0x9d, 0x2d,
-	0xcf, 0x7e, 0xfc, 0x7b, 0x69, 0xf6, 0x79, 0x5b, 0x64, 0xc5, 0x4f, 0x2e, 0xab,
-	0xdb, 0xd9, 0x3f, 0x95, 0x13, 0x10, 0x87, 0xd7, 0x1e, 0x85, 0x3d, 0xfe, 0x63,
-	0xf0, 0x5a, 0x64, 0x22, 0xe4, 0x4c, 0xee, 0x1f, 0x46, 0xb0, 0x64, 0x9e, 0xdf,
-	0x5, 0xe8, 0x7b, 0x52, 0x7f, 0xc0, 0x1a, 0x96, 0x3c, 0xeb, 0x9f, 0xd7, 0x73,
-	0xc7, 0x67, 0x4b, 0xb3, 0x2b, 0x5e, 0xa0, 0x92, 0x66, 0x90, 0xd0, 0x7d, 0xdd,
-	0x49, 0xf5, 0x71, 0x63, 0x12, 0x6e, 0x41, 0xd9, 0xd0, 0x16, 0x1b, 0xfe, 0x3b,
-	0x12, 0x66, 0x13, 0x26, 0x41, 0xf3, 0x25, 0x1, 0x4f, 0x72, 0xde, 0xfb, 0xfb,
-	0x19, 0x6d, 0x38, 0x93, 0x31, 0xa1, 0x22, 0xc2, 0xef, 0x1e, 0xb, 0x7f, 0x5c,
-	0xdb, 0x9e, 0x85, 0x11, 0x21, 0xc2, 0x7a, 0x48, 0xee, 0x24, 0xbc, 0xc4, 0xe8,
-	0xa8, 0x16, 0x1b, 0x36, 0x74, 0xad, 0x4f, 0x1c, 0x26, 0x13, 0x1c, 0xf4, 0x81,
-	0xcb, 0x65, 0xb4, 0x21, 0x4e, 0xb0, 0x1a, 0x16, 0xe8, 0xcf, 0x78, 0xff, 0xba,
-	0x75, 0xf5, 0xb2, 0xbf, 0x36, 0xc3, 0x80, 0x84, 0xda, 0x2b, 0x59, 0x78, 0xbe,
-	0xad, 0xd6, 0xf5, 0xe1, 0x10, 0xd2, 0xbc, 0x8, 0x33, 0x94, 0x1c, 0x96, 0xec,
-	0x9e, 0x20, 0x35, 0x93, 0x7b, 0x98, 0x8, 0x49, 0xa0, 0xb2, 0x6c, 0xd1, 0xfe,
-	0x4e, 0x72, 0xa, 0x5, 0x7f, 0x49, 0xde, 0xa0, 0x35, 0x2c, 0xd0, 0xe3, 0xe1,
-	0xa8, 0xe9, 0xab, 0xda, 0x75, 0x29, 0x59, 0x1, 0x49, 0xef, 0x77, 0x1b, 0x54,
-	0xa5, 0x1a, 0xf6, 0x18, 0x10, 0x19, 0x92, 0x37, 0xb1, 0xe1, 0x44, 0xc7, 0x49,
-	0x3c, 0x5f, 0xe2, 0x3e, 0x70, 0x91, 0xe8, 0x7f, 0x8e, 0xdf, 0x26, 0xbc, 0x42,
-	0x28, 0x54, 0x8b, 0xc0, 0xa5, 0x10, 0x51, 0xe6, 0x8e, 0xda, 0x85, 0xe4, 0x88,
-	0x89, 0x1f, 0xcb, 0xc7, 0x85, 0xba, 0xc1, 0x59, 0xf8, 0xb, 0xb1, 0x6c, 0xe,
-	0xd8, 0x48, 0xff, 0x97, 0x7b, 0x67, 0x12, 0x78, 0xee, 0x8a, 0xf2, 0xbb, 0xd9,
-	0xfd, 0x65, 0xd7, 0x45, 0xf4, 0x77, 0x6c, 0x7f, 0x68, 0x9c, 0x50, 0x27, 0xec,
-	0x19, 0xcc, 0xcd, 0xb6, 0xae, 0xe, 0x7d, 0x29, 0xe7, 0xd9, 0x99, 0x8c, 0x53,
-	0x6, 0xec, 0xa7, 0xc6, 0x24, 0xc8, 0x25, 0x42, 0x26, 0x95, 0xfb, 0x7c, 0xc9,
-	0x8b, 0xf2, 0xc5, 0x8c, 0xe3, 0x3c, 0xf9, 0x63, 0x91, 0xb6, 0xdb, 0x54, 0xc3,
-	0x15, 0x83, 0x87, 0xa1, 0xce, 0x62, 0xa5, 0x71, 0xfc, 0xd8, 0x8b, 0xf1, 0xd5,
-	0x49, 0xbd, 0x14, 0xd6, 0x3d, 0x23, 0xf7, 0x99, 0xff, 0x55, 0x69, 0xd4, 0x6c,
-	0x39, 0xbd, 0xb1, 0xa, 0x26, 0x71, 0x75, 0xeb, 0xea, 0x54, 0x23, 0xfa, 0xb,
-	0xa5, 0x6e, 0x49, 0xe7, 0xb2, 0x3d, 0xa3, 0x39, 0xce, 0x94, 0x89, 0xf7, 0x8a,
-	0x79, 0x75, 0xd0, 0xa2, 0x75, 0x26, 0x89, 0x16, 0x2b, 0x8d, 0x4b, 0x53, 0xe6,
-	0x2d, 0x74, 0x14, 0xc5, 0x30, 0xac, 0x14, 0xc6, 0x3a, 0x47, 0x44, 0x54, 0x8a,
-	0x21, 0xa4, 0xaa, 0xe, 0xa8, 0x2b, 0x99, 0xcf, 0x7d, 0x98, 0x13, 0xd7, 0x4b,
-	0xd6, 0xef, 0x1f, 0xf0, 0x5b, 0x10, 0x97, 0x76, 0x2d, 0x9e, 0x34, 0x56, 0x17,
-	0x4f, 0x1b, 0x5f, 0x95, 0x4c, 0x7c, 0x55, 0x44, 0x9b, 0xa3, 0xde, 0x7e, 0xae,
-	0xd3, 0x63, 0x92, 0x35, 0x66, 0x39, 0xf8, 0xab, 0xd2, 0xb8, 0x30, 0xfd, 0x61,
-	0xf2, 0xcc, 0x92, 0x1, 0x65, 0x4, 0x2a, 0x64, 0xc0, 0xc, 0x3a, 0x78, 0x8, 0x3b,
-	0xa4, 0x4a, 0x1d, 0x4c, 0x54, 0xc, 0x24, 0xf, 0x4a, 0xed, 0xbc, 0x34, 0x8e,
-	0x76, 0x43, 0x99, 0xc5, 0x4a, 0xa3, 0xec, 0x42, 0x21, 0xf0, 0x4d, 0x60, 0x91,
-	0xa2, 0x12, 0xde, 0x73, 0x61, 0x64, 0x53, 0xe7, 0xaf, 0x38, 0x65, 0xc6, 0x62,
-	0x78, 0x68, 0x3a, 0x3d, 0xae, 0x34, 0x91, 0xb5, 0xa7, 0x8c, 0xb9, 0x70, 0x26,
-	0x71, 0x8e, 0x64, 0x48, 0x5b, 0x48, 0x33, 0x55, 0x98, 0xbe, 0x8, 0x6b, 0xf8,
-	0xb8, 0xfb, 0x9c, 0x5d, 0x67, 0x93, 0x5f, 0x97, 0xf2, 0xc8, 0xd1, 0x6d, 0xc8,
-	0xa7, 0x2c, 0xed, 0xf6, 0xe0, 0x5c, 0x61, 0xd2, 0xa3, 0x2f, 0x12, 0xcd, 0x99,
-	0x98, 0x89, 0x70, 0x11, 0x9f, 0xa, 0x7, 0xad, 0xc, 0x74, 0xdf, 0xd6, 0x83, 0x5f,
-	0xc4, 0x52, 0x25, 0x91, 0x7f, 0xef, 0xd7, 0x8b, 0xba, 0xbc, 0x95, 0x4e, 0xa6,
-	0x5d, 0x29, 0x9d, 0x8a, 0xcb, 0x81, 0x66, 0xc4, 0x7, 0xa9, 0xdd, 0x28, 0xec,
-	0xb1, 0xeb, 0x52, 0x16, 0x2a, 0x75, 0xb2, 0xbd, 0x2f, 0x49, 0xe8, 0x28, 0xd1,
-	0xf2, 0x8d, 0xf9, 0xe1, 0x11, 0x71, 0xd8, 0xa7, 0x2c, 0x26, 0x9, 0x7b, 0xfa,
-	0xf3, 0xf1, 0xcd, 0xdc, 0x49, 0x96, 0x1f, 0xe7, 0x12, 0x26, 0x44, 0x3c, 0x43,
-	0x63, 0xd2, 0xe4, 0x45, 0xb9, 0x5e, 0x74, 0x1c, 0x2a, 0x4e, 0xb0, 0x9a, 0x17,
-	0xd1, 0x33, 0x7d, 0x37, 0x28, 0xe7, 0xc7, 0xd9, 0x28, 0xc3, 0xde, 0xd6, 0xec,
-	0x86, 0x67, 0xcc, 0xfd, 0xcb, 0x88, 0x9c, 0xde, 0xbf, 0xcb, 0x4, 0xf5, 0xbe,
-	0x6c, 0x53, 0xb1, 0xc8, 0x74, 0x49, 0x42, 0x35, 0x11, 0xfd, 0x9d, 0x49, 0x55,
-	0x6f, 0x49, 0x5f, 0x26, 0xdd, 0x45, 0xcc, 0x9c, 0x6e, 0x45, 0x4, 0x92, 0x3c,
-	0x3, 0xc5, 0xd, 0x41, 0x14, 0xd1, 0x76, 0xf4, 0xf9, 0x19, 0x3e, 0xe, 0xcb, 0x4d,
-	0x66, 0xc7, 0x99, 0x8c, 0x71, 0xdb, 0xd1, 0x51, 0x6a, 0xef, 0xaf, 0xd3, 0x9f,
-	0xc5, 0xfe, 0xd, 0xcd, 0xee, 0x86, 0x8e, 0xa1, 0x0, 0xce, 0x57, 0x78, 0x25,
-	0x77, 0x6f, 0xa8, 0xe, 0x74, 0x88, 0xd, 0x6c, 0xba, 0xf1, 0x75, 0xd5, 0x75,
-	0x39, 0x4c, 0xfc, 0xdd, 0x75, 0x36, 0x71, 0x40, 0xa6, 0xd3, 0x1c, 0xca, 0x29,
-	0xc7, 0x91, 0xb0, 0xeb, 0x0, 0xe5, 0x47, 0x35, 0x2f, 0xd7, 0x12, 0xda, 0xed,
-	0xeb, 0xaa, 0x33, 0xf9, 0x34, 0xb7, 0x61, 0x73, 0xb2, 0xce, 0x64, 0x9c, 0xf3,
-	0x72, 0x4b, 0xcf, 0xda, 0x7d, 0x38, 0x1f, 0x77, 0x33, 0x97, 0x8c, 0xcb, 0xe9,
-	0x3a, 0x1b, 0x3, 0x99, 0xce, 0xfd, 0xb, 0xf7, 0x9f, 0x6f, 0x51, 0x0, 0x66, 0xaa,
-	0xf0, 0x74, 0x2f, 0xce, 0xcc, 0xbf, 0x22, 0xe7, 0x80, 0x88, 0x9e, 0xe7, 0x61,
-	0x33, 0x75, 0xeb, 0x4d, 0x2e, 0xa3, 0x7e, 0xbe, 0xa2, 0xf5, 0x17, 0x66, 0x86,
-	0x33, 0x81, 0x3c, 0xb8, 0x99, 0xee, 0xe9, 0xd3, 0x1d, 0x60, 0x98, 0x8f, 0xdd,
-	0x5, 0xe4, 0xda, 0xe8, 0x71, 0xe0, 0xd2, 0x69, 0xa4, 0x1d, 0xf3, 0xe0, 0x26,
-	0x91, 0xe2, 0xc2, 0x49, 0xfd, 0x83, 0xd, 0x83, 0xb4, 0x62, 0x52, 0x95, 0xe2,
-	0x3b, 0xa5, 0x4, 0x4c, 0x5e, 0x94, 0xeb, 0xc5, 0x82, 0x43, 0x6b, 0x40, 0x87,
-	0x67, 0x17, 0x53, 0x72, 0xdf, 0x44, 0x6e, 0x3c, 0xde, 0xf, 0x17, 0x52, 0xfa,
-	0xd0, 0x4f, 0x8, 0x61, 0x6, 0xb4, 0x27, 0x10, 0x6, 0xb2, 0x32, 0xdd, 0xed, 0x72,
-	0x1, 0x93, 0x4e, 0xd8, 0x1a, 0x2d, 0x4c, 0xe2, 0xca, 0xcd, 0x58, 0xb, 0x93,
-	0x5e, 0x65, 0x25, 0x13, 0x97, 0x70, 0x54, 0x1b, 0xe, 0x5c, 0x48, 0x26, 0xb6,
-	0x5d, 0x5b, 0x2d, 0x5a, 0x7f, 0x1a, 0xf5, 0xe6, 0x37, 0x2d, 0x18, 0x12, 0x4a,
-	0xda, 0x75, 0x1a, 0x5, 0xd5, 0xd7, 0x72, 0x91, 0x8c, 0x15, 0xf9, 0xf7, 0x61,
-	0x72, 0x1e, 0xce, 0x24, 0x14, 0x99, 0x61, 0x44, 0x4c, 0x6f, 0x89, 0xa9, 0x6f,
-	0x86, 0xb0, 0x80, 0x6, 0x21, 0x6f, 0x54, 0x52, 0xa3, 0xfb, 0x66, 0x74, 0xba,
-	0xcc, 0x49, 0x82, 0xe1, 0x9b, 0xb9, 0xb5, 0xbd, 0x9f, 0xfd, 0x30, 0x55, 0x55,
-	0xf3, 0xba, 0xc6, 0x9d, 0x58, 0x0, 0x2, 0x7e, 0x44, 0xfb, 0x32, 0xf4, 0xd2,
-	0xdb, 0xc5, 0xfd, 0x4e, 0x24, 0x49, 0xe8, 0xd9, 0x35, 0x64, 0x3b, 0x2a, 0xdd,
-	0x92, 0xbe, 0x74, 0x28, 0x5a, 0x67, 0xf2, 0xcf, 0xeb, 0xb9, 0xe3, 0x41, 0xb5,
-	0x26, 0xa9, 0x86, 0x89, 0x6b, 0x9f, 0xcd, 0x5b, 0x8f, 0xe5, 0x4d, 0x67, 0xc1,
-	0x8, 0xc, 0x88, 0x9d, 0x4a, 0x3c, 0xb4, 0x74, 0x43, 0x5d, 0x74, 0xaf, 0x8b,
-	0x4e, 0x4a, 0x25, 0x7b, 0x39, 0xd5, 0x9, 0xbe, 0x30, 0xea, 0x6b, 0xae, 0x75,
-	0xbd, 0x3, 0x8, 0xe0, 0x91, 0xdc, 0x5b, 0x9f, 0xd5, 0xa5, 0xa7, 0x70, 0x28,
-	0x61, 0x69, 0x9d, 0x7c, 0x6b, 0xc1, 0xa8, 0xf5, 0x47, 0xad, 0x49, 0x8c, 0x4d,
-	0xb6, 0x92, 0x6, 0xb5, 0xe3, 0xcf, 0x85, 0x5c, 0xcc, 0x14, 0x9c, 0x27, 0x9b,
-	0xac, 0xd4, 0x86, 0xd, 0x8b, 0x13, 0xea, 0x5a, 0x6b, 0xd1, 0x43, 0x17, 0x91,
-	0xd, 0xcd, 0x86, 0xe9, 0xbe, 0xdf, 0x6f, 0xa6, 0xdb, 0x61, 0x7e, 0x5b, 0x79,
-	0x7e, 0xd9, 0x1f, 0x82, 0xe4, 0xa9, 0xc9, 0x96, 0x13, 0x8, 0x22, 0x2, 0x1b, 0x2,
-	0xf9, 0x97, 0x88, 0xc6, 0xb, 0x28, 0xcf, 0x30, 0x31, 0x9, 0x55, 0x45, 0x7b,
-	0x80, 0x88, 0xcd, 0xb1, 0x32, 0x15, 0xb3, 0x12, 0x8e, 0x65, 0x2e, 0x4, 0x8d,
-	0xab, 0x63, 0x5f, 0xc0, 0x69, 0x8a, 0xa1, 0xae, 0xc3, 0x61, 0x6e, 0x64, 0xb1,
-	0xf2, 0xf3, 0xc0, 0xf4, 0xb9, 0x40, 0x48, 0x63, 0xb7, 0xf4, 0x70, 0x98, 0x24,
-	0x7d, 0xb1, 0xd0, 0x6b, 0x38, 0x96, 0xc9, 0x10, 0x34, 0xa2, 0x8e, 0x7d, 0xbf,
-	0xf7, 0x4e, 0x11, 0x4a, 0xbe, 0x1, 0xba, 0x33, 0x8, 0x24, 0x53, 0x93, 0xd3,
-	0x65, 0x16, 0x14, 0x97, 0x65, 0x26, 0xc4, 0x60, 0xbe, 0x4c, 0xfa, 0xa5, 0x90,
-	0xf4, 0x69, 0x43, 0x12, 0x8a, 0xaa, 0x63, 0x9f, 0x8d, 0x2f, 0x78, 0x35, 0x10,
-	0x24, 0xa8, 0x52, 0x4d, 0x8f, 0x95, 0x66, 0xe5, 0x61, 0xf6, 0x50, 0xb2, 0x73,
-	0x93, 0xf3, 0x38, 0x4, 0xde, 0xfd, 0x9d, 0xe3, 0xea, 0xd8, 0x67, 0xae, 0x57,
-	0xd8, 0x6, 0x13, 0x73, 0xab, 0x14, 0x20, 0x49, 0x5b, 0x60, 0x5e, 0xf4, 0xb0,
-	0xb2, 0x13, 0x5e, 0x6c, 0xb9, 0x1c, 0x2, 0x9f, 0x2b, 0x78, 0x3e, 0x80, 0x49,
-	0x22, 0xb7, 0x4a, 0xa1, 0x30, 0x34, 0x12, 0xfe, 0xcd, 0x1b, 0x9d, 0x68, 0x7,
-	0xf9, 0xa1, 0x83, 0xb7, 0xb3, 0xea, 0x4c, 0x44, 0x62, 0x83, 0x89, 0xd, 0xbd,
-	0x61, 0x1f, 0xc0, 0x64, 0x82, 0xd5, 0x7b, 0xec, 0xcb, 0x14, 0x1a, 0x13, 0x91,
-	0x99, 0x51, 0x33, 0x88, 0xba, 0xb3, 0x6a, 0xf7, 0x13, 0x8a, 0xc3, 0x6a, 0x79,
-	0xe9, 0xb8, 0xf6, 0xa0, 0xa6, 0x87, 0xfd, 0x8d, 0xb0, 0x1d, 0x6b, 0x23, 0x5b,
-	0x90, 0x8f, 0x52, 0x7e, 0xdf, 0x70, 0x90, 0x9d, 0xf2, 0x32, 0x4b, 0x59, 0xb8,
-	0xfd, 0x29, 0x89, 0xcf, 0xa6, 0x49, 0xef, 0xe4, 0xf3, 0x5b, 0x89, 0xef, 0xf4,
-	0xca, 0x86, 0x7c, 0x94, 0x7a, 0x18, 0x7a, 0x23, 0x57, 0x92, 0x5e, 0x77, 0x72,
-	0xd7, 0xfb, 0x76, 0xe8, 0x82, 0xd3, 0x44, 0x1f, 0xe3, 0xd, 0x6f, 0xb8, 0x9a,
-	0xb7, 0xc6, 0xd2, 0x14, 0x71, 0xc2, 0xf9, 0xf7, 0x8a, 0xe6, 0x6e, 0x77, 0x3e,
-	0xb2, 0xa, 0x26, 0xb6, 0x7c, 0x28, 0x28, 0x3a, 0xb5, 0x6e, 0x7e, 0xed, 0xc4,
-	0x55, 0xe0, 0xe4, 0x6e, 0x28, 0xe1, 0xf6, 0x7a, 0x9e, 0x9e, 0xef, 0xfd, 0x2c,
-	0xa8, 0x58, 0x13, 0xce, 0xf6, 0xc1, 0x24, 0x6f, 0xd9, 0xe7, 0x4c, 0xbc, 0x1,
-	0x89, 0xf7, 0x35, 0xdf, 0xbe, 0x3c, 0x5b, 0x9e, 0xf5, 0xb4, 0x69, 0xbf, 0xad,
-	0x3c, 0xbf, 0xd4, 0xd4, 0x7e, 0x17, 0xc7, 0xc0, 0x28, 0x21, 0xbd, 0x95, 0xdb,
-	0x5b, 0xc2, 0x11, 0x9e, 0x9e, 0x4d, 0xd0, 0xc3, 0xb, 0x93, 0xb6, 0xdc, 0xff,
-	0x79, 0x0, 0x93, 0x5b, 0xd1, 0x9f, 0xb1, 0x3d, 0xd3, 0x10, 0xd3, 0x70, 0x4d,
-	0x88, 0xb7, 0xae, 0x6f, 0x7e, 0xfd, 0xc7, 0x85, 0x88, 0xdf, 0xd, 0x6b, 0xa6,
-	0xcf, 0x51, 0x7b, 0x25, 0x4a, 0xa0, 0x68, 0x96, 0xf5, 0x3c, 0x86, 0x37, 0xbd,
-	0x51, 0x2b, 0x83, 0xc6, 0x85, 0x86, 0x4, 0xe9, 0x7, 0xe9, 0x8, 0x5b, 0xa6, 0xfc,
-	0x3d, 0x80, 0xc9, 0x3f, 0xaf, 0xe7, 0x8e, 0xe3, 0x8e, 0xa9, 0x21, 0x7f, 0x2e,
-	0xa4, 0x2d, 0xb2, 0xf2, 0xf7, 0xd2, 0xec, 0xf3, 0xb3, 0xe5, 0xd9, 0x8f, 0xe3,
-	0x14, 0x44, 0xf5, 0x80, 0x12, 0x76, 0xc8, 0x63, 0x42, 0x1b, 0xe1, 0x77, 0x79,
-	0x4, 0x9, 0xf5, 0xc6, 0xb7, 0x98, 0xd3, 0xab, 0xf8, 0x1c, 0x99, 0x2d, 0xd7,
-	0x25, 0xfe, 0x28, 0x5a, 0x3b, 0x5f, 0x7e, 0xb6, 0x95, 0xd8, 0x11, 0x22, 0xf4,
-	0xd8, 0xe6, 0x3c, 0x76, 0xdf, 0xf2, 0x3d, 0x17, 0x12, 0xe6, 0xc2, 0x71, 0x17,
-	0xfc, 0x75, 0xeb, 0xea, 0x65, 0x58, 0x2f, 0x10, 0xf7, 0xb3, 0x36, 0x5b, 0xfa,
-	0xe5, 0xd9, 0xeb, 0xd9, 0xc3, 0xfc, 0x7e, 0x61, 0x52, 0x14, 0x89, 0xd6, 0x29,
-	0xf4, 0xd, 0x9a, 0xb3, 0x2a, 0xcf, 0xf9, 0xe8, 0xdd, 0x1c, 0x77, 0xd1, 0x2e,
-	0x9c, 0xd4, 0xbf, 0x2b, 0xe6, 0x6d, 0x6c, 0xe7, 0x44, 0x1c, 0x88, 0xbb, 0x40,
-	0x3e, 0x37, 0x6f, 0xf4, 0xe1, 0xb7, 0x95, 0x67, 0x91, 0x26, 0x4b, 0xbb, 0xee,
-	0x66, 0xeb, 0xd5, 0x49, 0xfd, 0x68, 0x42, 0xa9, 0xed, 0x40, 0x4d, 0xa7, 0x99,
-	0x6b, 0x9a, 0xf4, 0xce, 0xf9, 0xd2, 0xb3, 0x5c, 0xbf, 0x84, 0xee, 0x4e, 0x59,
-	0x84, 0x8e, 0x22, 0x5e, 0x20, 0x33, 0xf7, 0xff, 0xd3, 0x9e, 0xca, 0xe2, 0x81,
-	0x2d, 0x8, 0xce, 0x5f, 0xcf, 0x7d, 0x58, 0xa8, 0xfc, 0x38, 0x64, 0x72, 0x76,
-	0x6d, 0x1c, 0x45, 0x8, 0x80, 0x44, 0x62, 0x95, 0x8f, 0xef, 0xdb, 0x70, 0xd2,
-	0xdb, 0x61, 0xdf, 0xbb, 0xfb, 0x79, 0xcd, 0x42, 0xe6, 0xdb, 0xcf, 0x41, 0x2d,
-	0xbd, 0xfb, 0xb3, 0xbe, 0xad, 0xcc, 0xd5, 0xb2, 0xf2, 0x1d, 0x9a, 0x11, 0xb5,
-	0x4c, 0x97, 0x67, 0xcb, 0xd1, 0x3a, 0xb3, 0xfe, 0x12, 0xe, 0x4e, 0xfc, 0x9a,
-	0xc4, 0x83, 0x10, 0xcf, 0x1b, 0x71, 0x27, 0x14, 0x6f, 0xa0, 0xd4, 0x3e, 0xdc,
-	0xdc, 0x2, 0x91, 0x1c, 0xb, 0xc9, 0x51, 0xab, 0x75, 0x7d, 0x18, 0xd7, 0x85,
-	0xb0, 0x20, 0x1b, 0xde, 0x71, 0xee, 0xef, 0xfe, 0x84, 0x35, 0x98, 0x7d, 0xa1,
-	0x52, 0x5f, 0x53, 0xc2, 0x7b, 0x6e, 0x48, 0xdd, 0xbc, 0xb9, 0xda, 0xb2, 0xf5,
-	0xf7, 0xf7, 0xa3, 0xc5, 0xd3, 0xc6, 0x9e, 0x90, 0x5c, 0x9e, 0x2f, 0x3f, 0xdb,
-	0x8a, 0xf2, 0xfb, 0x78, 0x32, 0xc9, 0x17, 0xbd, 0x7f, 0x77, 0xc3, 0x5f, 0x5b,
-	0x9e, 0x1d, 0xfb, 0xfd, 0x45, 0x3a, 0x23, 0x13, 0xe8, 0x3d, 0xa, 0xdc, 0x82,
-	0xb9, 0xf, 0x11, 0x7d, 0x34, 0xee, 0x64, 0xbe, 0xb4, 0xab, 0x7, 0x92, 0x87,
-	0x61, 0x52, 0xfb, 0x5d, 0xda, 0x8f, 0x91, 0xff, 0xaa, 0x34, 0x2e, 0xa2, 0xfe,
-	0x3d, 0x16, 0x4f, 0x1a, 0xab, 0xcc, 0x72, 0xd0, 0x5b, 0x4f, 0x67, 0xcb, 0xb3,
-	0x2f, 0xad, 0xf, 0x73, 0x1e, 0x8f, 0xaf, 0x8d, 0x25, 0xfd, 0xe8, 0xfe, 0x63,
-	0xa6, 0xf2, 0x15, 0xa8, 0xd4, 0xad, 0xbc, 0x9c, 0x7, 0x5c, 0x1e, 0x75, 0x1f,
-	0x66, 0xf2, 0x1e, 0x93, 0xaa, 0x36, 0xdb, 0x57, 0xc7, 0x59, 0x78, 0xfb, 0x8e,
-	0xd, 0x92, 0xd3, 0x9f, 0xbb, 0x4a, 0x68, 0xf3, 0xe1, 0x83, 0x92, 0xa2, 0xa2,
-	0x89, 0xaf, 0x8b, 0xa7, 0x8d, 0xad, 0xb3, 0xe5, 0xd9, 0x54, 0x9e, 0x26, 0xf6,
-	0xee, 0x46, 0x45, 0xd, 0x44, 0x21, 0x3d, 0xcf, 0x5d, 0xf, 0x20, 0x96, 0xdd,
-	0xc4, 0xe, 0xdc, 0x9d, 0xbe, 0xb, 0x96, 0xfd, 0xfe, 0xc1, 0x4a, 0x6e, 0x38,
-	0x64, 0x5a, 0x8, 0x6a, 0x2a, 0x76, 0x7b, 0x6c, 0xe4, 0x6, 0x32, 0x51, 0xf, 0x1c,
-	0x4f, 0xbb, 0xba, 0x7d, 0x72, 0x76, 0x99, 0x64, 0x6d, 0x48, 0x2e, 0x60, 0x77,
-	0xb1, 0xf2, 0xf3, 0x4d, 0xb3, 0xf5, 0x6b, 0x3d, 0x6d, 0xe0, 0x75, 0x5f, 0xac,
-	0x71, 0xdc, 0x41, 0x62, 0xc5, 0x2f, 0xee, 0x26, 0x41, 0xf0, 0xed, 0xe7, 0x4c,
-	0xc0, 0xe4, 0x31, 0xd, 0xda, 0x40, 0x26, 0xe7, 0xc2, 0x6a, 0x5e, 0x48, 0xfe,
-	0xc7, 0xcc, 0x66, 0x30, 0x77, 0x5a, 0x5b, 0x41, 0x76, 0xb, 0xbd, 0xaa, 0x9d,
-	0x99, 0xb2, 0xfc, 0x1d, 0xe0, 0xf0, 0x12, 0xd6, 0xfc, 0x98, 0x67, 0x71, 0x3c,
-	0xf5, 0xc9, 0x61, 0xa1, 0xd5, 0xa9, 0xc9, 0xe9, 0xe2, 0x42, 0xe5, 0xc7, 0x7a,
-	0xaa, 0xc2, 0x1e, 0xe1, 0x17, 0xe6, 0xaa, 0x42, 0xe4, 0x79, 0x9, 0x77, 0xff,
-	0x88, 0x95, 0xcd, 0xa6, 0x62, 0x99, 0x35, 0xdc, 0x3b, 0x29, 0x78, 0x14, 0x32,
-	0xa4, 0x66, 0x4c, 0x45, 0x1f, 0xf3, 0xff, 0x75, 0x41, 0x43, 0x49, 0x9f, 0x1e,
-	0xb9, 0xe1, 0x9, 0xf5, 0xfa, 0x51, 0x88, 0xfc, 0xeb, 0x86, 0x29, 0xb7, 0xa4,
-	0x2f, 0x1, 0x8d, 0x0, 0x20, 0x39, 0xa9, 0x7f, 0x50, 0xc2, 0xdb, 0x7e, 0x6, 0x2b,
-	0x75, 0xa0, 0xe3, 0x94, 0x17, 0x2a, 0xf5, 0xad, 0xd4, 0x14, 0xbf, 0x89, 0xfc,
-	0x1b, 0xb5, 0x9b, 0x32, 0xa1, 0x94, 0x48, 0xb1, 0x6b, 0x85, 0xad, 0xab, 0xe5,
-	0x71, 0x92, 0xfc, 0xe1, 0x7d, 0x9b, 0xf3, 0x70, 0xd0, 0xc3, 0xeb, 0x9d, 0x24,
-	0xdc, 0x41, 0xe7, 0xc1, 0xaa, 0xbb, 0x7, 0xd0, 0xe8, 0xef, 0xba, 0x3, 0x86,
-	0x87, 0x8b, 0xb6, 0x3, 0x9, 0x32, 0xbd, 0x3a, 0xa9, 0x96, 0xa5, 0x63, 0xca,
-	0xa4, 0xd5, 0x75, 0xa3, 0xbb, 0x41, 0xc3, 0x5c, 0xd7, 0xb9, 0x32, 0xf1, 0xde,
-	0xc2, 0x49, 0xbd, 0x38, 0x6a, 0x28, 0xbb, 0xd, 0x21, 0x5c, 0xab, 0x1d, 0xfd,
-	0x1c, 0x65, 0xd3, 0x66, 0x42, 0xee, 0xe2, 0x6a, 0xeb, 0x9a, 0x4d, 0x31, 0x96,
-	0x3d, 0x14, 0x3a, 0x44, 0x82, 0x16, 0xbf, 0xd, 0x74, 0x8a, 0x74, 0x98, 0xc6,
-	0x3c, 0x4a, 0xe8, 0x2e, 0xef, 0xf4, 0xe7, 0xae, 0x22, 0xda, 0x74, 0x43, 0x9c,
-	0xb3, 0xa5, 0xd9, 0xff, 0xd8, 0xf6, 0xf9, 0x14, 0x96, 0x3f, 0x14, 0x16, 0x44,
-	0x16, 0x2b, 0x8d, 0xb2, 0xc3, 0x5c, 0xe, 0x3b, 0x4c, 0xed, 0xe6, 0x51, 0xca,
-	0xae, 0x3, 0xc8, 0xf3, 0x33, 0x56, 0xcc, 0xab, 0xb6, 0x86, 0x38, 0x80, 0x9,
-	0x14, 0x9a, 0x3a, 0x45, 0x8d, 0xd1, 0xe5, 0xba, 0xdc, 0x70, 0x69, 0xaa, 0x30,
-	0x7d, 0xb1, 0x50, 0xf9, 0x91, 0xcb, 0x12, 0x84, 0xfe, 0x7c, 0x49, 0x52, 0x1d,
-	0xf3, 0x0, 0x13, 0x28, 0x16, 0xc5, 0xd1, 0x30, 0xba, 0x93, 0x47, 0x71, 0xca,
-	0xb6, 0xcc, 0x4e, 0x8e, 0x53, 0x77, 0x6d, 0x39, 0x63, 0xbe, 0x91, 0xc, 0x98,
-	0x40, 0xb1, 0xab, 0xd5, 0x8a, 0xa7, 0xa7, 0x86, 0xb, 0x14, 0x37, 0x94, 0x5a,
-	0xa8, 0xd4, 0xd7, 0xf2, 0xf4, 0x7c, 0x99, 0xf8, 0xad, 0x81, 0xb6, 0xd0, 0x27,
-	0x6b, 0xc3, 0x30, 0x6c, 0x3, 0x28, 0xc, 0x99, 0x93, 0xb0, 0x18, 0xe7, 0xdd,
-	0x2a, 0xe1, 0xbd, 0x7c, 0x1, 0x85, 0x8d, 0x33, 0x69, 0xde, 0x5c, 0xed, 0x3,
-	0x26, 0x50, 0xe6, 0xa5, 0x45, 0x62, 0x4d, 0xc, 0x1a, 0xa0, 0x9c, 0xfe, 0xdc,
-	0xcd, 0xfa, 0x73, 0x35, 0xf7, 0x71, 0x44, 0x66, 0x84, 0x78, 0xdf, 0xe6, 0x13,
-	0x2d, 0xc0, 0x4, 0xa, 0x13, 0x26, 0x47, 0x9, 0x2c, 0xe0, 0xcd, 0xc5, 0xd3, 0xc6,
-	0x5e, 0xc6, 0x63, 0x9c, 0xb7, 0x1d, 0x57, 0x62, 0xf7, 0xc8, 0x10, 0xc0, 0x4,
-	0xa, 0x4d, 0xff, 0xbc, 0x9e, 0x3b, 0x8e, 0xa3, 0x51, 0xf5, 0x9f, 0x7b, 0x4d,
-	0xd6, 0x32, 0xd, 0x14, 0xa6, 0x55, 0x61, 0x3e, 0xb6, 0xbd, 0xa8, 0x12, 0x30,
-	0x81, 0xc2, 0x55, 0x42, 0x35, 0x10, 0x59, 0x5, 0x4a, 0x2f, 0xc4, 0xb9, 0xd5,
-	0xf6, 0xf, 0x32, 0x3, 0x4c, 0xa0, 0xb0, 0x61, 0x72, 0x94, 0xdc, 0xb, 0x3c, 0x83,
-	0x40, 0x51, 0xf2, 0xde, 0x75, 0x25, 0x69, 0xb8, 0x17, 0x6, 0x98, 0x40, 0xa1,
-	0x2a, 0xe9, 0x66, 0xd2, 0x59, 0x2, 0xca, 0x8b, 0xf2, 0xc5, 0xc, 0xb, 0xad, 0xa6,
-	0xc1, 0x95, 0x0, 0x26, 0x50, 0x34, 0xe6, 0x84, 0x9, 0x40, 0x9, 0x41, 0x53, 0x93,
-	0xd3, 0x6b, 0x69, 0x71, 0x25, 0x80, 0x9, 0x14, 0x8d, 0x34, 0x27, 0xde, 0xb4,
-	0xa7, 0xb, 0x94, 0xcd, 0x34, 0x3f, 0x46, 0x66, 0xda, 0x48, 0x8b, 0x2b, 0x1,
-	0x4c, 0xa0, 0xc8, 0x42, 0x9d, 0x24, 0x4e, 0x75, 0x1e, 0x1, 0xca, 0x6e, 0x5a,
-	0xb, 0xdb, 0x5e, 0x9d, 0xd4, 0x4b, 0x42, 0x54, 0x4b, 0x53, 0xf, 0x1d, 0xc0, 0x4,
-	0x8a, 0x28, 0xd6, 0xb1, 0xe3, 0x66, 0x6b, 0x5a, 0x2b, 0x65, 0x27, 0x94, 0xda,
-	0x4e, 0x93, 0x2b, 0x1, 0x4c, 0xa0, 0xc8, 0x64, 0xd3, 0xdc, 0x6a, 0x26, 0xb5,
-	0x9b, 0xa6, 0xdb, 0xc6, 0x9d, 0x1b, 0xc2, 0xe9, 0x72, 0x25, 0x80, 0x9, 0x14,
-	0x99, 0xcc, 0x46, 0x88, 0xf1, 0xae, 0xce, 0x50, 0x98, 0x74, 0x6f, 0x1b, 0xa7,
-	0x5, 0x28, 0x85, 0x49, 0xde, 0x68, 0xde, 0x5c, 0x6d, 0xa5, 0xed, 0x3b, 0x7,
-	0x4c, 0xa0, 0xc8, 0xa4, 0xb5, 0x45, 0xee, 0xc4, 0x5, 0x8a, 0x38, 0x7b, 0xb6,
-	0x37, 0x58, 0x72, 0x5d, 0x9, 0x13, 0x7f, 0x4f, 0x63, 0x57, 0x39, 0xc0, 0x4,
-	0x8a, 0x4c, 0x71, 0xf4, 0x45, 0xf5, 0x17, 0xee, 0xc8, 0xfc, 0xd4, 0xe4, 0x74,
-	0xd9, 0xe6, 0x67, 0x36, 0xe5, 0xa8, 0xf9, 0xb4, 0xce, 0xe, 0x42, 0xf, 0x58,
-	0x28, 0x52, 0x2d, 0x56, 0x7e, 0x1e, 0xd8, 0x36, 0x56, 0x56, 0x88, 0xf7, 0xcf,
-	0x96, 0x67, 0xd7, 0xf1, 0xed, 0xc0, 0x99, 0x40, 0xa9, 0x8a, 0x75, 0xf8, 0xb3,
-	0x7d, 0x6f, 0xd0, 0xf4, 0xd7, 0xa0, 0xc0, 0x99, 0x40, 0xb9, 0xd4, 0x5f, 0x95,
-	0xc6, 0xc5, 0xdd, 0xbc, 0x17, 0x8b, 0xd4, 0x16, 0x59, 0xc1, 0x2c, 0x24, 0x38,
-	0x13, 0x28, 0x4d, 0xe6, 0xc4, 0xa2, 0x44, 0x6c, 0xbf, 0x26, 0x94, 0x3a, 0xe8,
-	0xcd, 0x8, 0x86, 0x0, 0x13, 0x28, 0x5, 0x6a, 0xb5, 0xaf, 0x3f, 0xda, 0x50, 0x11,
-	0xfb, 0x87, 0x2d, 0x17, 0x99, 0x99, 0x9a, 0x54, 0x7, 0xf8, 0x86, 0x0, 0x13,
-	0x28, 0x25, 0x32, 0xc7, 0x9c, 0x96, 0xce, 0x7a, 0x61, 0x92, 0xf9, 0x3c, 0xb4,
-	0x7e, 0x4, 0x4c, 0xa0, 0xcc, 0xc8, 0xe6, 0x96, 0x83, 0xa6, 0xf5, 0xe3, 0x49,
-	0x63, 0x15, 0xdf, 0x12, 0x60, 0x2, 0xa5, 0xc2, 0x9d, 0xcc, 0xd5, 0x92, 0x6e,
-	0x4d, 0x30, 0x62, 0x27, 0xec, 0x21, 0x7f, 0x2, 0x98, 0x58, 0x23, 0x2c, 0xc6,
-	0xe1, 0xba, 0xd5, 0x62, 0xed, 0xcc, 0x17, 0x93, 0x3f, 0x29, 0xa8, 0x3d, 0x7c,
-	0x4b, 0x80, 0x89, 0x25, 0xba, 0xbe, 0x44, 0xfc, 0x3d, 0x58, 0xdd, 0x86, 0xd3,
-	0xc7, 0x16, 0x3, 0xa5, 0xb4, 0x70, 0x52, 0xff, 0x80, 0x6f, 0xa, 0x30, 0xb1,
-	0xc0, 0xca, 0x3f, 0xbf, 0xd4, 0x22, 0x47, 0x99, 0x1f, 0xbd, 0x30, 0x96, 0x3b,
-	0xb1, 0x7c, 0x5c, 0x3, 0xf3, 0x76, 0x5e, 0xe7, 0x19, 0x3, 0x26, 0x16, 0xbe,
-	0x7d, 0x5d, 0xae, 0x0, 0x28, 0xe9, 0x74, 0x27, 0x9d, 0x4d, 0xe1, 0x1c, 0xd8,
-	0x7e, 0x21, 0x10, 0x30, 0xc9, 0x89, 0x7a, 0x17, 0xb5, 0x16, 0x2b, 0x3f, 0xb1,
-	0x28, 0x53, 0xe8, 0x4e, 0x48, 0xa4, 0x58, 0x98, 0x7c, 0xba, 0x8d, 0x6f, 0xa,
-	0x30, 0xb1, 0x42, 0xa6, 0x1f, 0x85, 0x70, 0x71, 0x6a, 0x72, 0xba, 0xc, 0xa0,
-	0xa4, 0xd1, 0x9d, 0xd0, 0xe6, 0xab, 0x93, 0x7a, 0x9, 0xdf, 0x16, 0x60, 0x72,
-	0xa7, 0xa4, 0x36, 0xf2, 0xb7, 0x95, 0xe7, 0x97, 0xcd, 0x9b, 0xab, 0x15, 0x62,
-	0x32, 0x40, 0x41, 0x1c, 0x9e, 0x32, 0x77, 0x42, 0x44, 0x8e, 0xc2, 0xe9, 0xe,
-	0x60, 0xd2, 0xa7, 0x29, 0x67, 0xba, 0x94, 0xd4, 0x46, 0x76, 0x81, 0x22, 0xd4,
-	0x36, 0x40, 0x61, 0x72, 0xca, 0x28, 0x8c, 0x4a, 0x97, 0x3b, 0x71, 0xc3, 0x1d,
-	0x9c, 0xee, 0x0, 0x26, 0x77, 0x32, 0x43, 0xa1, 0x64, 0xe2, 0x7d, 0x52, 0xe,
-	0xe5, 0x7c, 0xe9, 0xbf, 0x55, 0x3, 0x14, 0x33, 0xba, 0x40, 0xe, 0xb0, 0x38,
-	0xfb, 0x42, 0xc1, 0x96, 0xb6, 0xbe, 0xa7, 0x8, 0x4e, 0x77, 0x0, 0x93, 0x87,
-	0xe2, 0xdb, 0xcf, 0x49, 0x26, 0xd4, 0x7a, 0x40, 0x11, 0xe6, 0x4b, 0x77, 0x71,
-	0x2e, 0x56, 0x1a, 0x65, 0x14, 0xb8, 0x75, 0xab, 0x62, 0x89, 0xf7, 0xad, 0x5f,
-	0x3e, 0xe4, 0xa0, 0x76, 0x8, 0x30, 0xb9, 0xdf, 0xcc, 0x4c, 0x3c, 0x93, 0x64,
-	0x98, 0xd1, 0xf, 0x14, 0x16, 0x29, 0x4d, 0x15, 0xd4, 0x57, 0x84, 0x3d, 0x9d,
-	0x44, 0xb5, 0x8d, 0x37, 0x8a, 0x1f, 0xc0, 0x44, 0xa4, 0x94, 0xd6, 0xf9, 0x3b,
-	0x80, 0x49, 0x44, 0x8b, 0x96, 0x15, 0x25, 0x3a, 0xf2, 0xe0, 0x37, 0xa0, 0xcc,
-	0xb8, 0x61, 0x4f, 0xe7, 0xf8, 0x38, 0xbf, 0x2e, 0xc5, 0xe4, 0x95, 0xb4, 0xfe,
-	0x64, 0xbf, 0x3b, 0x51, 0xbb, 0x38, 0x95, 0x3, 0x4c, 0xee, 0x17, 0xad, 0xd0,
-	0xa7, 0xa4, 0x3b, 0x94, 0xf7, 0x3, 0xa5, 0xf3, 0xd6, 0xa3, 0x55, 0xe3, 0x52,
-	0x72, 0xdc, 0x46, 0xf0, 0xfc, 0xf5, 0xdc, 0x7, 0x5b, 0xc6, 0x62, 0xc, 0x71,
-	0x27, 0x33, 0xa8, 0x3d, 0x1, 0x4c, 0xee, 0x64, 0x8a, 0xc9, 0x98, 0x2e, 0xa7,
-	0xa, 0x4f, 0xf7, 0x92, 0xe, 0xbb, 0x9a, 0x2d, 0xfd, 0x52, 0x88, 0xab, 0x74,
-	0x37, 0xd7, 0x45, 0x76, 0x17, 0x4f, 0x1b, 0x5f, 0xf3, 0x5a, 0xdb, 0x20, 0x9a,
-	0xb6, 0x52, 0xb0, 0x59, 0x36, 0x91, 0xeb, 0x2, 0x4c, 0xee, 0xc3, 0x1d, 0x73,
-	0x82, 0xc0, 0x89, 0x5f, 0xe8, 0xfa, 0xb6, 0x32, 0x57, 0x6b, 0xde, 0x5c, 0xad,
-	0xf4, 0x5f, 0xcb, 0x67, 0x92, 0x79, 0x87, 0xb9, 0xbc, 0x58, 0x69, 0xe4, 0xae,
-	0x2e, 0xa5, 0x3b, 0x9f, 0xd8, 0xfa, 0x7e, 0xac, 0xb8, 0x59, 0x3c, 0x2c, 0x14,
-	0xcc, 0xa1, 0xdc, 0x90, 0xc2, 0x75, 0x2, 0x9a, 0x65, 0xfd, 0x7c, 0x69, 0x2e,
-	0xf1, 0xd3, 0x4, 0x17, 0x6c, 0x8a, 0xf9, 0xf, 0xb, 0x2d, 0xc4, 0xfb, 0xc2, 0xed,
-	0x4f, 0xae, 0x93, 0xc9, 0xfa, 0x77, 0xb2, 0x50, 0xa9, 0xaf, 0x29, 0x52, 0xdb,
-	0x36, 0x36, 0x9e, 0xfe, 0x5d, 0x68, 0x44, 0xd, 0x98, 0x3c, 0x4, 0x4a, 0xa5,
-	0x51, 0x66, 0x91, 0x92, 0xe6, 0xdb, 0x97, 0x36, 0x6c, 0x56, 0x73, 0xb2, 0xa3,
-	0x68, 0xcf, 0xd, 0x79, 0xfe, 0x80, 0xa, 0xf3, 0xf1, 0xad, 0xd6, 0x3b, 0x59,
-	0x5c, 0xc0, 0x69, 0x82, 0x48, 0x1f, 0xe4, 0xab, 0x67, 0xcb, 0xb3, 0x2f, 0x81,
-	0xf, 0xc0, 0xc4, 0xe8, 0x45, 0xf9, 0x62, 0x66, 0xaa, 0x30, 0x7d, 0xd1, 0x59,
-	0x1c, 0xed, 0x15, 0x1b, 0x80, 0xe2, 0xc6, 0xe3, 0xae, 0x8d, 0x76, 0x21, 0xf7,
-	0xf8, 0xb7, 0xc5, 0x35, 0x4d, 0x7a, 0xa7, 0xd5, 0xba, 0x3e, 0x4c, 0xe3, 0xf8,
-	0xc8, 0xfe, 0xdf, 0xb3, 0xe0, 0xd0, 0x1a, 0x2b, 0xb5, 0xf1, 0x18, 0x3c, 0xd3,
-	0x20, 0x5b, 0x5c, 0x2d, 0x60, 0x62, 0x89, 0x5e, 0x9d, 0xd4, 0x4b, 0xe, 0x73,
-	0x59, 0x98, 0x2f, 0x6d, 0x1, 0x4a, 0x7f, 0x18, 0x36, 0xe2, 0xed, 0xb8, 0x4f,
-	0x42, 0x47, 0xa6, 0xc2, 0x37, 0x45, 0x2e, 0x84, 0x89, 0xdf, 0xda, 0x36, 0xe1,
-	0x2f, 0xd8, 0xce, 0xe1, 0xda, 0xdf, 0x4b, 0xb3, 0xcf, 0x81, 0x10, 0xc0, 0xe4,
-	0x8f, 0x7c, 0x85, 0x6d, 0x40, 0x59, 0xa8, 0xfc, 0x98, 0x67, 0x72, 0x76, 0x7,
-	0xba, 0x94, 0xfb, 0x10, 0xa8, 0xd3, 0xf9, 0x5d, 0xe8, 0xa8, 0xd9, 0xbe, 0x3a,
-	0xb6, 0xc9, 0xb1, 0xb8, 0xee, 0xaf, 0x50, 0x78, 0xb2, 0xca, 0xa2, 0xde, 0x10,
-	0xd3, 0x6a, 0x5a, 0x5d, 0x8, 0xdc, 0x9, 0x60, 0xe2, 0x3b, 0x7f, 0x62, 0x1b,
-	0x50, 0x7a, 0x2e, 0x85, 0x98, 0xb6, 0xbd, 0x6e, 0x44, 0x61, 0x3e, 0x16, 0xad,
-	0xbf, 0x68, 0xa2, 0xe3, 0x76, 0xfb, 0xba, 0x1a, 0x37, 0x5c, 0x5c, 0xb7, 0xa7,
-	0x88, 0x4a, 0xac, 0xd4, 0x9b, 0x51, 0x20, 0x84, 0x3b, 0x1, 0x4c, 0x32, 0x99,
-	0x3f, 0x79, 0x52, 0x98, 0xfe, 0x4a, 0x22, 0x45, 0x1b, 0x81, 0x62, 0xde, 0xf0,
-	0x93, 0x4f, 0xb7, 0x15, 0x91, 0xef, 0xc2, 0xb6, 0x6e, 0x2d, 0x4b, 0x55, 0x44,
-	0x7f, 0x77, 0x1, 0xa3, 0x94, 0xbe, 0xc, 0xe3, 0x77, 0x7b, 0x51, 0xae, 0x17,
-	0x1d, 0x87, 0x8a, 0x6, 0x1c, 0xac, 0xfe, 0x47, 0x44, 0xf3, 0x4c, 0x92, 0xbb,
-	0xb, 0x71, 0x70, 0x27, 0x80, 0xc9, 0xa0, 0xb0, 0xc2, 0x75, 0x28, 0x33, 0x36,
-	0x2, 0x85, 0xee, 0x12, 0xb4, 0xbc, 0x1b, 0x46, 0xce, 0xa1, 0x5b, 0x81, 0x7b,
-	0xf7, 0xfb, 0xb9, 0x6e, 0x66, 0xe0, 0x22, 0x51, 0xfc, 0x82, 0x88, 0x67, 0xba,
-	0xb, 0xa6, 0x98, 0xa6, 0x93, 0x17, 0xb8, 0x13, 0xc0, 0x24, 0x99, 0x90, 0xe2,
-	0xa4, 0xb1, 0xca, 0x2c, 0x7, 0xbd, 0xcd, 0x66, 0x23, 0x50, 0x7a, 0xa1, 0xc4,
-	0x84, 0xe2, 0x8d, 0x4c, 0x24, 0x32, 0xe1, 0x4e, 0x0, 0x93, 0xec, 0x3a, 0x94,
-	0xfa, 0x9a, 0x12, 0xde, 0xbb, 0x7, 0x8a, 0xde, 0xb2, 0x75, 0xa1, 0x18, 0xa7,
-	0x32, 0xa9, 0xb6, 0x99, 0x4, 0x37, 0x5a, 0xe1, 0x4e, 0x0, 0x13, 0x2b, 0x1d,
-	0xca, 0x69, 0x63, 0xaf, 0x7f, 0x83, 0xa, 0xf1, 0x56, 0xaf, 0x49, 0xb4, 0xad,
-	0x50, 0x29, 0x38, 0xb4, 0xa6, 0x94, 0x7a, 0x8f, 0x10, 0x4, 0xee, 0x4, 0x30,
-	0xb1, 0x1f, 0x28, 0xfb, 0x67, 0xcb, 0xb3, 0xeb, 0x69, 0x8, 0xd5, 0x48, 0xc9,
-	0xfb, 0xac, 0x84, 0x40, 0x42, 0x5c, 0x15, 0xd6, 0x9f, 0x5a, 0xad, 0xeb, 0xc3,
-	0xa9, 0xc9, 0xe9, 0x5d, 0x5b, 0x5d, 0x18, 0xaa, 0x62, 0x1, 0x13, 0xbf, 0x40,
-	0xa9, 0x36, 0x6f, 0xf4, 0xbb, 0x6f, 0x2b, 0x73, 0x35, 0xdb, 0x3f, 0xfb, 0x5d,
-	0x8d, 0x47, 0xa, 0x8b, 0xc4, 0xba, 0x27, 0x50, 0x9f, 0x9b, 0x37, 0xfa, 0xb0,
-	0xff, 0x59, 0x9b, 0xaa, 0xe5, 0xc9, 0xe9, 0xb2, 0xad, 0xa7, 0x46, 0x79, 0xbf,
-	0xb3, 0x3, 0x98, 0xf8, 0x5, 0xa, 0xf3, 0xe5, 0xad, 0xd6, 0xef, 0xd2, 0xb4, 0x68,
-	0xcc, 0x26, 0x74, 0xa6, 0x4b, 0xc4, 0xf4, 0x96, 0x99, 0x4a, 0xb6, 0x85, 0x42,
-	0x9d, 0x93, 0x25, 0x39, 0x16, 0x92, 0xa3, 0x56, 0x8b, 0x8e, 0x87, 0xc1, 0xba,
-	0xff, 0xd4, 0xcd, 0x3a, 0x8, 0x32, 0x1d, 0x9e, 0x2d, 0x3d, 0x7b, 0x7, 0x98,
-	0x40, 0x9e, 0x81, 0x62, 0x62, 0x64, 0x91, 0x1d, 0xd3, 0xd4, 0x27, 0x85, 0x32,
-	0x89, 0x5b, 0x47, 0xcd, 0xb, 0xcb, 0x1b, 0x66, 0x9e, 0x8f, 0xbb, 0xb8, 0xec,
-	0xae, 0xf6, 0x85, 0xf5, 0x17, 0x22, 0x5d, 0xf5, 0x7b, 0x62, 0xd6, 0x9f, 0x24,
-	0xb7, 0x4d, 0xd7, 0x37, 0xf2, 0x3c, 0xd, 0xce, 0x15, 0x30, 0xb1, 0xc, 0x28,
-	0x69, 0xa, 0x7b, 0xbc, 0x0, 0xa6, 0x57, 0x84, 0x46, 0xcc, 0xff, 0xe7, 0x42,
-	0xa6, 0xfb, 0x9f, 0xe6, 0x83, 0xb8, 0x0, 0x3, 0xc, 0xa6, 0x4b, 0x12, 0xaa, 0x89,
-	0xe8, 0xef, 0x4c, 0xaa, 0x2a, 0xaa, 0x5d, 0xb, 0xeb, 0xa8, 0x7d, 0xe1, 0xf4,
-	0xe7, 0x6e, 0x90, 0x22, 0xbe, 0xa8, 0xa5, 0x89, 0x3e, 0x9e, 0x2f, 0x3f, 0xdb,
-	0x2, 0x4c, 0x20, 0xff, 0x40, 0xe9, 0xdc, 0x8d, 0xd9, 0xb1, 0xf9, 0xb4, 0x27,
-	0x4c, 0xd, 0xea, 0x4, 0xd7, 0x6e, 0x53, 0x2d, 0x6e, 0xa8, 0xf6, 0xae, 0x41,
-	0xd8, 0x16, 0xb2, 0x9d, 0x2d, 0xcd, 0xfe, 0x7, 0x30, 0x81, 0x46, 0xbf, 0x11,
-	0x7, 0x35, 0x32, 0x62, 0x3e, 0x6e, 0xb6, 0xf4, 0x7a, 0x5e, 0x2d, 0x6e, 0x32,
-	0x6e, 0xca, 0xce, 0x84, 0x6c, 0x5e, 0x8f, 0x89, 0x31, 0x6b, 0xd8, 0xa7, 0xce,
-	0x5f, 0xcf, 0x7d, 0x70, 0x17, 0xcb, 0x1f, 0x54, 0xee, 0x8d, 0xaf, 0xc8, 0x71,
-	0x63, 0xe8, 0xb8, 0x65, 0x9a, 0x84, 0x73, 0x7b, 0xdd, 0xb6, 0x51, 0x19, 0x4c,
-	0xea, 0x7d, 0x1e, 0xbf, 0xf, 0xc0, 0x24, 0x8, 0x50, 0x96, 0xe6, 0xf6, 0xdb,
-	0x22, 0x2b, 0xbf, 0x2f, 0xe2, 0x5e, 0x63, 0xe8, 0xbf, 0x2a, 0x8d, 0xb, 0xc,
-	0xbd, 0x8e, 0x47, 0xad, 0x56, 0xab, 0xd6, 0x7f, 0xc7, 0xc8, 0xa, 0x98, 0x88,
-	0x94, 0xf2, 0xd8, 0x78, 0x1a, 0x30, 0x9, 0x28, 0x33, 0x2b, 0x97, 0xda, 0x2b,
-	0xbd, 0xe, 0xf3, 0xf, 0x63, 0x1e, 0x29, 0x76, 0x1a, 0x43, 0xe7, 0x7b, 0x26,
-	0x4e, 0xd4, 0x72, 0x5d, 0xe0, 0x54, 0x61, 0xfa, 0xc2, 0xc6, 0x56, 0x7, 0x85,
-	0x49, 0xde, 0xc8, 0xdb, 0xf7, 0x81, 0x9c, 0x49, 0x18, 0x71, 0x7b, 0xe1, 0xe9,
-	0xde, 0xb0, 0xc2, 0x30, 0x4d, 0xf4, 0xb1, 0x75, 0xf3, 0x6b, 0x27, 0xcd, 0xad,
-	0x16, 0x6d, 0x52, 0xe7, 0xa2, 0xa3, 0xda, 0xb6, 0xba, 0x5f, 0x4a, 0xe, 0xef,
-	0xeb, 0x0, 0x26, 0x21, 0x69, 0x50, 0x62, 0xf6, 0xce, 0xac, 0x30, 0x9b, 0xc9,
-	0x75, 0xad, 0xf6, 0xf5, 0x47, 0x40, 0x25, 0xc3, 0x10, 0x79, 0x60, 0x50, 0xf9,
-	0x5d, 0x9a, 0xda, 0x6a, 0x2, 0x26, 0x36, 0x1, 0xa5, 0xf2, 0x63, 0x5e, 0x91,
-	0x73, 0x30, 0xac, 0xc2, 0xd4, 0x40, 0x45, 0x64, 0xbf, 0x75, 0x23, 0x9f, 0x70,
-	0xf2, 0xe3, 0xfd, 0xb9, 0x32, 0x4d, 0x6c, 0xa7, 0xf0, 0x5a, 0x40, 0x2a, 0xee,
-	0x73, 0x1, 0x26, 0x29, 0xe, 0x7b, 0xfa, 0x17, 0x5b, 0x5e, 0xe6, 0xe2, 0x4, 0x51,
-	0x77, 0xfc, 0xc7, 0x46, 0x9a, 0xdb, 0x3f, 0x5e, 0xdf, 0xfc, 0xfa, 0x4f, 0x5e,
-	0x9c, 0x28, 0x60, 0x12, 0xed, 0x46, 0xd8, 0xf3, 0x52, 0x3d, 0x6a, 0xfa, 0xb6,
-	0x92, 0xfe, 0x8c, 0x6, 0x3b, 0xf7, 0x17, 0x14, 0xd3, 0x36, 0x4b, 0x67, 0xc8,
-	0xb, 0x63, 0x2b, 0x2f, 0x5, 0x8d, 0x80, 0x89, 0x25, 0x2e, 0xa5, 0x3f, 0x4, 0x22,
-	0xbe, 0xfd, 0x9c, 0x37, 0xb7, 0x62, 0xf2, 0x21, 0xac, 0xde, 0x67, 0xae, 0x8b,
-	0x7d, 0x8e, 0x12, 0xb1, 0x80, 0x49, 0x4c, 0x2e, 0x85, 0x15, 0xed, 0xfa, 0x7a,
-	0xd3, 0x32, 0xd7, 0xb4, 0xc8, 0x61, 0x96, 0xc1, 0x62, 0xe6, 0x29, 0xcb, 0xc4,
-	0x7b, 0xc5, 0xbc, 0x9a, 0xe5, 0xa6, 0x4e, 0x79, 0x69, 0x4d, 0x0, 0x98, 0xc4,
-	0x69, 0xdf, 0x9d, 0x27, 0x9b, 0xc3, 0x4e, 0x7c, 0x46, 0x81, 0x45, 0x8b, 0x1c,
-	0xa5, 0x7d, 0x51, 0x9a, 0x51, 0x18, 0xcc, 0x6f, 0xb3, 0xe, 0x90, 0xdf, 0x42,
-	0x9d, 0x5c, 0x24, 0x62, 0x1, 0x93, 0xd8, 0xa1, 0x32, 0x62, 0x4, 0xa8, 0xa7,
-	0x70, 0x88, 0xe, 0x49, 0xf8, 0xcb, 0xad, 0xe8, 0xaa, 0xed, 0x70, 0x31, 0x27,
-	0x31, 0xe2, 0x94, 0x88, 0xe5, 0xd, 0x11, 0x97, 0xb2, 0x36, 0x88, 0xcb, 0x6b,
-	0xf8, 0x9a, 0x87, 0xcb, 0x7f, 0x80, 0x49, 0x92, 0x39, 0x82, 0x90, 0x6a, 0x26,
-	0x4c, 0x2, 0x57, 0xa4, 0x4a, 0x2c, 0xdf, 0x82, 0xf4, 0x7, 0x9, 0x15, 0x94, 0x8e,
-	0x9a, 0x17, 0xd2, 0xf3, 0x36, 0xe, 0xe1, 0x72, 0x37, 0x35, 0x13, 0x5d, 0x26,
-	0xe1, 0x88, 0x6c, 0x19, 0x90, 0xf, 0x98, 0x64, 0x58, 0xa6, 0xd1, 0x4f, 0x4,
-	0x27, 0x17, 0xbd, 0x7e, 0x22, 0xbd, 0x79, 0x38, 0x9a, 0xc8, 0x38, 0x98, 0x71,
-	0x5b, 0x5, 0xf4, 0xee, 0x1c, 0x4d, 0xb0, 0x9a, 0x17, 0xd1, 0x33, 0xbd, 0x99,
-	0x3a, 0x36, 0x1f, 0xdf, 0xf6, 0x17, 0xc, 0xba, 0xff, 0x9e, 0xc4, 0x4d, 0xe3,
-	0x34, 0x37, 0xd3, 0x2, 0x4c, 0x0, 0x15, 0x4f, 0x9b, 0x6c, 0xd4, 0x25, 0xb9,
-	0x34, 0xf, 0xdd, 0x1a, 0x54, 0x75, 0xec, 0xf7, 0x94, 0x2d, 0xa4, 0xcf, 0x72,
-	0x7c, 0xb6, 0x34, 0xbb, 0x2, 0x98, 0x40, 0xa9, 0xc, 0x7f, 0x72, 0x2b, 0xe6,
-	0x9a, 0x26, 0xbd, 0x33, 0xaa, 0x6e, 0xe7, 0xb1, 0x66, 0x57, 0x51, 0xea, 0xef,
-	0xe5, 0x67, 0xc, 0x98, 0x40, 0x9, 0x38, 0x15, 0x93, 0xb8, 0xdc, 0xc0, 0x80,
-	0x2d, 0x7f, 0xa1, 0x9d, 0xb0, 0xfe, 0xe4, 0xa7, 0xf8, 0x2f, 0xce, 0xf6, 0x8f,
-	0x59, 0xcf, 0x9b, 0x0, 0x26, 0x96, 0xab, 0xdb, 0x4d, 0xcc, 0x5, 0xca, 0xfb,
-	0x3c, 0xe, 0x6, 0xf7, 0x14, 0xca, 0x8c, 0x59, 0xe8, 0x17, 0x57, 0x83, 0xea,
-	0xac, 0xd7, 0x9b, 0x0, 0x26, 0x29, 0x73, 0x2b, 0x79, 0x28, 0xf2, 0xf2, 0x6,
-	0x11, 0x3a, 0x14, 0x92, 0xa3, 0xb0, 0xae, 0x20, 0xf8, 0xb9, 0xfe, 0x10, 0xd8,
-	0x99, 0x64, 0x3c, 0x9, 0xb, 0x98, 0x0, 0x2c, 0xa9, 0x71, 0x20, 0x24, 0x74, 0x48,
-	0x42, 0x47, 0xcd, 0xf6, 0xd5, 0x71, 0x14, 0x97, 0xe7, 0xa2, 0x9e, 0xc9, 0x3,
-	0x98, 0x40, 0xa9, 0x0, 0x8b, 0x29, 0xc, 0xcb, 0x5a, 0x28, 0xd4, 0xad, 0xfc,
-	0x65, 0xe1, 0x2f, 0x71, 0xf5, 0x5, 0xe9, 0x3e, 0xcb, 0xbd, 0x28, 0x9e, 0x23,
-	0x60, 0x2, 0xa5, 0x4a, 0xbd, 0xe9, 0x7d, 0xb6, 0x16, 0x8e, 0xd, 0x75, 0x1f,
-	0x7d, 0xc3, 0xb9, 0x46, 0x4d, 0xf6, 0x8b, 0xfc, 0x19, 0x46, 0x50, 0x8b, 0x2,
-	0x98, 0x40, 0xa9, 0x57, 0xf7, 0x46, 0xee, 0xbc, 0x90, 0xfc, 0x2f, 0x89, 0x9,
-	0x7e, 0x3, 0xc1, 0xc1, 0x52, 0x13, 0x2d, 0xdf, 0x34, 0xd1, 0x71, 0xbb, 0x7d,
-	0x5d, 0xb5, 0xa9, 0xef, 0x47, 0x14, 0xb5, 0x28, 0x59, 0x6f, 0x47, 0x0, 0x98,
-	0xe4, 0xd6, 0xc1, 0x74, 0x26, 0xf8, 0xdd, 0x57, 0xb2, 0xaa, 0x37, 0x14, 0x66,
-	0x91, 0x1a, 0x73, 0x4d, 0x88, 0x8c, 0xb3, 0xe8, 0xaf, 0xc2, 0x55, 0x4a, 0x5f,
-	0xa6, 0xe9, 0x78, 0x74, 0x54, 0x3b, 0x4e, 0x3f, 0xc2, 0x69, 0xe, 0x94, 0xeb,
-	0x90, 0xc9, 0x71, 0x9e, 0x78, 0xb6, 0xfa, 0xb6, 0xb9, 0x8b, 0x50, 0x9d, 0x9d,
-	0x52, 0x7, 0xe3, 0x24, 0x66, 0xf3, 0x70, 0xd9, 0xf, 0x30, 0x81, 0xa0, 0x18,
-	0xc2, 0x1e, 0xdc, 0xcd, 0x81, 0x20, 0xe8, 0xf, 0x97, 0xe2, 0x28, 0xb5, 0xe7,
-	0x27, 0x14, 0xec, 0xc, 0xb8, 0xbf, 0x5a, 0xc9, 0x7a, 0x2f, 0x58, 0xc0, 0x4,
-	0x82, 0x2, 0xc8, 0xeb, 0xc5, 0xcc, 0xbc, 0x80, 0x4, 0x30, 0x81, 0xa0, 0xb1,
-	0xa1, 0xd2, 0x29, 0x1e, 0xfc, 0xfd, 0x94, 0xc, 0x4d, 0xc2, 0x21, 0x8, 0x82, 0x2,
-	0xa, 0xb3, 0x86, 0x21, 0x8, 0x2, 0x4c, 0x20, 0x8, 0x2, 0x4c, 0x20, 0x8, 0x2,
-	0x4c, 0x20, 0x8, 0x82, 0x0, 0x13, 0x8, 0x82, 0x0, 0x13, 0x8, 0x82, 0x0, 0x13,
-	0x8, 0x82, 0x0, 0x13, 0x8, 0x82, 0x20, 0xc0, 0x4, 0x82, 0x20, 0xc0, 0x4, 0x82,
-	0x20, 0xc0, 0x4, 0x82, 0x20, 0xc0, 0x4, 0x82, 0x20, 0x8, 0x30, 0x81, 0x20, 0x8,
-	0x30, 0x81, 0x20, 0x8, 0x30, 0x81, 0x20, 0x8, 0x2, 0x4c, 0x20, 0x8, 0x2, 0x4c,
-	0x20, 0x8, 0x2, 0x4c, 0x20, 0x8, 0x2, 0x4c, 0x20, 0x8, 0x82, 0x0, 0x13, 0x8,
-	0x82, 0x0, 0x13, 0x8, 0x82, 0x0, 0x13, 0x8, 0x82, 0xf2, 0xa0, 0xff, 0x1f, 0x0,
-	0x0, 0xff, 0xff, 0x32, 0xcb, 0x6a, 0x4a, 0x7, 0x5d, 0x26, 0x83, 0x0, 0x0, 0x0,
-	0x0, 0x49, 0x45, 0x4e, 0x44, 0xae, 0x42, 0x60, 0x82}
@@ -5,37 +5,62 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
 	"github.com/Bo0mer/flyontime/pkg/flyontime"
-	"github.com/lunixbochs/vtclean"
 	"github.com/nlopes/slack"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
+// Notifier is a Slack bot. It receives messages using Socket Mode if AppToken
+// is set, the Events API if SigningSecret is set (see EventsHandler) or the
+// legacy RTM API otherwise.
 type Notifier struct {
-	Token     string
-	ChannelID string
-	Logger    lager.Logger
+	Token         string // Bot token.
+	AppToken      string // App-level token for Socket Mode.
+	SigningSecret string // Signing secret for the Events API and slash commands.
+	ChannelID     string
+	Retention     time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect     backoff.Policy // Delays between Socket Mode reconnects. Defaults to backoff.DefaultPolicy.
+	Logger        lager.Logger
+
+	// SlashResponseType is the type of responses to slash commands, either
+	// ResponseEphemeral (the default) or ResponseInChannel.
+	SlashResponseType string
 
 	initOnce sync.Once
+	login    chat.Init // obtains the bot user
 	slack    *slack.Client
 	selfID   string
+	conn     chat.Connection
 
-	commands  chan *flyontime.Command
-	callbacks map[string]*flyontime.Notification
-	// messages keeps track of previous messages
-	messages map[messageKey]*slack.MessageEvent
+	router    chat.Router
+	callbacks chat.Registry // maps attachment callback ids to notifications
+	threads   chat.Registry // maps message timestamps to notifications
+	messages  chat.Store    // keeps track of previous messages, see messageKey
+	events    chat.Store    // keeps track of handled event ids
 }
 
-func (s *Notifier) init() {
+// setup applies the defaults.
+func (s *Notifier) setup() {
 	s.initOnce.Do(func() {
+		s.conn.Reconnect = s.Reconnect
+		s.callbacks.Name = "slack_callbacks"
+		s.callbacks.TTL = s.Retention
+		s.messages.Name = "slack_messages"
+		s.messages.TTL = s.Retention
+		s.threads.Name = "slack_threads"
+		s.threads.TTL = s.Retention
+		s.events.Name = "slack_events"
+		s.events.TTL = time.Hour
 		s.slack = slack.New(s.Token)
-		s.commands = make(chan *flyontime.Command)
-		s.callbacks = make(map[string]*flyontime.Notification)
-		s.messages = make(map[messageKey]*slack.MessageEvent)
 		if s.Logger == nil {
 			s.Logger = lager.NewLogger("")
 		}
@@ -43,8 +68,40 @@ func (s *Notifier) init() {
 	})
 }
 
+func (s *Notifier) init() error {
+	s.setup()
+	return s.login.Do(func() error {
+		auth, err := s.slack.AuthTest()
+		if err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		s.selfID = auth.UserID
+		if s.AppToken == "" && s.SigningSecret != "" {
+			// Events are pushed over HTTP, hence there is no
+			// connection to keep track of.
+			s.conn.Up()
+		}
+		return nil
+	})
+}
+
 func (s *Notifier) Commands() <-chan *flyontime.Command {
-	s.init()
+	s.setup()
+
+	switch {
+	case s.AppToken != "":
+		logger := s.Logger.Session("socket-mode")
+		go s.conn.Run(logger, func() error {
+			if err := s.init(); err != nil {
+				return err
+			}
+			return s.listenSocketMode(logger)
+		})
+		return s.router.Commands()
+	case s.SigningSecret != "":
+		// Events are received by EventsHandler.
+		return s.router.Commands()
+	}
 
 	go func() {
 		rtm := s.slack.NewRTM()
@@ -54,13 +111,25 @@ func (s *Notifier) Commands() <-chan *flyontime.Command {
 			switch ev := msg.Data.(type) {
 			case *slack.ConnectedEvent:
 				s.selfID = ev.Info.User.ID
+				s.conn.Up()
+			case *slack.ConnectionErrorEvent:
+				s.conn.Down(ev.ErrorObj)
+			case *slack.InvalidAuthEvent:
+				s.conn.Down(errors.New("invalid auth"))
+			case *slack.DisconnectedEvent:
+				s.conn.Down(errors.New("disconnected"))
 			case *slack.MessageEvent:
 				s.handleMessageEvent(ev)
 			}
 		}
 	}()
 
-	return s.commands
+	return s.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Slack.
+func (s *Notifier) Health() (map[string]interface{}, error) {
+	return s.conn.Health(s.init)
 }
 
 func (s *Notifier) handleMessageEvent(m *slack.MessageEvent) {
@@ -80,106 +149,96 @@ func (s *Notifier) handleMessageEvent(m *slack.MessageEvent) {
 		s.handleReplyMessage(m)
 		return
 	}
-	if strings.HasPrefix(m.Msg.Text, fmt.Sprintf("<@%s> ", s.selfID)) {
+	if strings.HasPrefix(m.Msg.Text, fmt.Sprintf("<@%s>", s.selfID)) {
 		s.handleMentionMessage(m)
 		return
 	}
 
-	// TODO(borshukov): We need some way to get rid of old messages.
-	s.messages[messageKey{m.User, m.Timestamp}] = m
-	return
+	s.messages.Put(messageKey(m.User, m.Timestamp), m)
 }
 
 func (s *Notifier) handleDirectMessage(m *slack.MessageEvent) {
-	cmd, args := parseCommand(m.Msg.Text)
-	s.run(&flyontime.Command{Name: cmd, Args: args}, s.replyToIM(m.Channel))
+	s.run(m.Msg.Text, nil, s.replyToIM(m.Channel))
 }
 
 func (s *Notifier) handleReplyMessage(m *slack.MessageEvent) {
 	totalReplies := len(m.SubMessage.Replies)
 	lastReply := m.SubMessage.Replies[totalReplies-1]
-	reply, ok := s.messages[messageKey{lastReply.User, lastReply.Timestamp}]
+	v, ok := s.messages.Get(messageKey(lastReply.User, lastReply.Timestamp))
 	if !ok {
 		return
 	}
+	reply := v.(*slack.MessageEvent)
 	atts := m.SubMessage.Attachments
 	if len(atts) == 0 || atts[0].CallbackID == "" {
 		return
 	}
 	callbackID := atts[0].CallbackID
-	n, ok := s.callbacks[callbackID]
+	n, ok := s.callbacks.Get(callbackID)
 	if !ok {
 		return
 	}
-	cmd, args := parseCommand(reply.Text)
-	s.run(&flyontime.Command{Name: cmd, Args: args, Job: &n.Job}, s.replyToThread(m.SubMessage.ThreadTimestamp))
+	s.run(reply.Text, &n.Job, s.replyToThread(s.ChannelID, m.SubMessage.ThreadTimestamp))
 }
 
 func (s *Notifier) handleMentionMessage(m *slack.MessageEvent) {
-	words := strings.Split(strings.TrimSpace(m.Msg.Text), " ")
-	if len(words) < 2 {
-		// TODO(borshukov): Log.
-		return
-	}
-
-	cmd, args := words[1], words[2:]
-	s.run(&flyontime.Command{Name: cmd, Args: args}, s.replyToThread(m.ThreadTimestamp))
+	text := strings.TrimPrefix(m.Msg.Text, fmt.Sprintf("<@%s>", s.selfID))
+	s.run(text, nil, s.replyToThread(s.ChannelID, m.ThreadTimestamp))
 }
 
-func (s *Notifier) run(c *flyontime.Command, reply replyFunc) {
-	go func() {
-		responses := make(chan string)
-		c.Responses = responses
-		// Send the command.
-		s.commands <- c
-		// And post each response as a message.
-		for r := range responses {
-			reply(r)
-		}
-	}()
+// run parses text as a command for job (if any) and sends it for execution.
+func (s *Notifier) run(text string, job *flyontime.Job, reply chat.Reply) {
+	s.router.Route(s.Logger.Session("run"), slackText(text), job, reply)
 }
 
-type replyFunc func(reply string)
-
-func (s *Notifier) replyToThread(ts string) replyFunc {
-	return func(reply string) {
-		s.slack.PostMessage(s.ChannelID, reply, slack.PostMessageParameters{
+// replyToThread returns a function that posts replies in channelID, in the
+// thread started by ts if it is not empty.
+func (s *Notifier) replyToThread(channelID, ts string) chat.Reply {
+	return func(reply string) error {
+		_, _, err := s.slack.PostMessage(channelID, reply, slack.PostMessageParameters{
 			ThreadTimestamp: ts,
 			Markdown:        true,
 		})
+		return err
 	}
 }
 
-func (s *Notifier) replyToIM(channelID string) replyFunc {
-	return func(reply string) {
-		s.slack.PostMessage(channelID, reply, slack.PostMessageParameters{
+func (s *Notifier) replyToIM(channelID string) chat.Reply {
+	return func(reply string) error {
+		_, _, err := s.slack.PostMessage(channelID, reply, slack.PostMessageParameters{
 			Markdown: true,
 		})
+		return err
 	}
 }
 
 func (s *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
-	s.init()
+	if err := s.init(); err != nil {
+		return err
+	}
 	callbackID := uuid.NewV4().String()
 	p := slack.PostMessageParameters{
 		Attachments: []slack.Attachment{
 			slack.Attachment{
-				Color:      colorFor(n.Severity),
+				Color:      chat.Color(n.Severity),
 				AuthorName: "Concourse",
 				AuthorIcon: "https://concourse.ci/favicon.ico",
 				Title:      n.Title,
 				TitleLink:  n.DashboardLink,
-				Text:       formatCode(n.JobOutput),
+				Text:       chat.FormatCode(n.JobOutput, 0),
 				MarkdownIn: []string{"text"},
 				CallbackID: callbackID,
 			},
 		},
 	}
-	_, _, err := s.slack.PostMessage(s.ChannelID, "", p)
+	_, ts, err := s.slack.PostMessage(s.ChannelID, "", p)
 	if err != nil {
 		return err
 	}
-	s.callbacks[callbackID] = n
+	if n.HasJob() {
+		s.callbacks.Put(callbackID, n)
+		s.threads.Put(ts, n)
+	}
 	return nil
 }
 
@@ -225,39 +284,28 @@ func tempLogoFile() (string, error) {
 		return "", err
 	}
 	defer fd.Close()
-	_, err = fd.Write(concourseLogoPNG[:])
+	_, err = fd.Write(chat.LogoPNG)
 
-	return fd.Name(), nil
+	return fd.Name(), err
 }
 
-func colorFor(severity flyontime.Severity) string {
-	if severity == flyontime.SeverityInfo {
-		return "good"
-	}
-	return "danger"
+// messageKey returns the key under which the message posted by user at ts is
+// stored.
+func messageKey(user, ts string) string {
+	return user + "/" + ts
 }
 
-func formatCode(code string) string {
-	if code == "" {
-		return ""
-	}
-	return fmt.Sprintf("```\n%s\n```", vtclean.Clean(code, false))
-}
-
-type messageKey struct {
-	User      string
-	Timestamp string
-}
-
-func parseCommand(text string) (string, []string) {
-	s := strings.Split(text, " ")
-	switch len(s) {
-	case 0:
-		return "", nil
-	case 1:
-		return s[0], nil
-	default:
-		return s[0], s[1:]
-	}
+var slackLink = regexp.MustCompile(`<([^<>|]*)(?:\|([^<>]*))?>`)
 
+// slackText converts text formatted by Slack back to plain text, i.e. it
+// replaces links with their labels and unescapes the control characters.
+func slackText(text string) string {
+	text = slackLink.ReplaceAllStringFunc(text, func(link string) string {
+		m := slackLink.FindStringSubmatch(link)
+		if m[2] != "" {
+			return m[2]
+		}
+		return m[1]
+	})
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(text)
 }
@@ -0,0 +1,54 @@
+package slacker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRequestAge is the maximum age of requests accepted from Slack, which
+// protects against replay attacks.
+const maxRequestAge = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned when a request is not signed with the
+	// signing secret of the app.
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrStaleRequest is returned when a request is too old (or from the
+	// future).
+	ErrStaleRequest = errors.New("stale request")
+)
+
+// VerifyRequest verifies that a request with header and body has been sent
+// by Slack, by checking its signature using the signing secret of the app.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func VerifyRequest(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrStaleRequest
+	}
+	if age := now.Sub(time.Unix(sec, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return ErrStaleRequest
+	}
+
+	expected := []byte(Sign(secret, ts, body))
+	if !hmac.Equal([]byte(header.Get("X-Slack-Signature")), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the signature of a request with timestamp ts and body, as
+// sent by Slack in the X-Slack-Signature header.
+func Sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", ts)
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
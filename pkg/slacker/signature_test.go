@@ -0,0 +1,55 @@
+package slacker_test
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Bo0mer/flyontime/pkg/slacker"
+)
+
+var _ = Describe("VerifyRequest", func() {
+	var now time.Time
+	var header http.Header
+	var body []byte
+
+	BeforeEach(func() {
+		now = time.Unix(1531420618, 0)
+		body = []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&command=%2Fconcourse&text=pipelines")
+		header = http.Header{}
+		header.Set("X-Slack-Request-Timestamp", "1531420618")
+		header.Set("X-Slack-Signature", Sign("s3cr3t", "1531420618", body))
+	})
+
+	It("should accept requests signed with the secret", func() {
+		Ω(VerifyRequest("s3cr3t", header, body, now)).Should(Succeed())
+	})
+
+	It("should reject requests signed with another secret", func() {
+		Ω(VerifyRequest("other", header, body, now)).Should(Equal(ErrInvalidSignature))
+	})
+
+	It("should reject requests whose body has been tampered with", func() {
+		Ω(VerifyRequest("s3cr3t", header, append(body, '!'), now)).Should(Equal(ErrInvalidSignature))
+	})
+
+	It("should reject old requests", func() {
+		Ω(VerifyRequest("s3cr3t", header, body, now.Add(6*time.Minute))).Should(Equal(ErrStaleRequest))
+	})
+
+	It("should reject requests without timestamp", func() {
+		header.Del("X-Slack-Request-Timestamp")
+		Ω(VerifyRequest("s3cr3t", header, body, now)).Should(Equal(ErrStaleRequest))
+	})
+
+	It("should compute signatures as documented by Slack", func() {
+		// Example from https://api.slack.com/authentication/verifying-requests-from-slack
+		secret := "8f742231b10e8888abcd99yyyzzz85a5"
+		ts := strconv.Itoa(1531420618)
+		body := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c")
+		Ω(Sign(secret, ts, body)).Should(Equal("v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503"))
+	})
+})
@@ -0,0 +1,27 @@
+package slacker_test
+
+import (
+	"testing"
+
+	"github.com/nlopes/slack"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSlacker(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Slacker Suite")
+}
+
+var api *webAPI
+
+// The URL of the Slack Web API is global, thus a single stub is used by all
+// specs. Each spec resets it, see webAPI.reset.
+var _ = BeforeSuite(func() {
+	api = newWebAPI()
+	slack.SLACK_API = api.URL + "/"
+})
+
+var _ = AfterSuite(func() {
+	api.Close()
+})
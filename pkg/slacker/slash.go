@@ -0,0 +1,111 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/pkg/errors"
+)
+
+// Response types of slash commands.
+const (
+	// ResponseEphemeral responses are visible only to the user who invoked
+	// the command.
+	ResponseEphemeral = "ephemeral"
+	// ResponseInChannel responses are visible to everyone in the channel.
+	ResponseInChannel = "in_channel"
+)
+
+// responseClient posts responses to slash commands.
+var responseClient = &http.Client{Timeout: 30 * time.Second}
+
+// slashCommand holds the fields of slash command requests that are of
+// interest, see https://api.slack.com/interactivity/slash-commands
+type slashCommand struct {
+	Command     string `json:"command"`
+	Text        string `json:"text"`
+	UserID      string `json:"user_id"`
+	ChannelID   string `json:"channel_id"`
+	ResponseURL string `json:"response_url"`
+}
+
+// SlashCommandHandler returns a handler for slash command requests, e.g.
+// "/concourse pause my-pipeline". Requests are verified using SigningSecret.
+// Responses to the commands are posted using the response URL of each request.
+func (s *Notifier) SlashCommandHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setup()
+		logger := s.Logger.Session("slash-command")
+
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+		if err != nil {
+			logger.Error("read-body.fail", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := VerifyRequest(s.SigningSecret, r.Header, body, time.Now()); err != nil {
+			logger.Error("verify.fail", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			logger.Error("parse-form.fail", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.handleSlashCommand(logger, &slashCommand{
+			Command:     form.Get("command"),
+			Text:        form.Get("text"),
+			UserID:      form.Get("user_id"),
+			ChannelID:   form.Get("channel_id"),
+			ResponseURL: form.Get("response_url"),
+		})
+		// Responses are posted asynchronously, thus there is nothing to
+		// respond with right away.
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// handleSlashCommand handles slash commands received either over HTTP or
+// Socket Mode.
+func (s *Notifier) handleSlashCommand(logger lager.Logger, sc *slashCommand) {
+	logger = logger.WithData(lager.Data{"command": sc.Command, "user": sc.UserID})
+	s.router.Route(logger, slackText(sc.Text), nil, s.replyToResponseURL(sc.ResponseURL))
+}
+
+// replyToResponseURL returns a function that posts replies to the response
+// URL of a slash command. Note that Slack accepts up to five responses per
+// command.
+func (s *Notifier) replyToResponseURL(responseURL string) chat.Reply {
+	responseType := s.SlashResponseType
+	if responseType == "" {
+		responseType = ResponseEphemeral
+	}
+	return func(reply string) error {
+		body, err := json.Marshal(map[string]string{
+			"response_type": responseType,
+			"text":          reply,
+		})
+		if err != nil {
+			return err
+		}
+		resp, err := responseClient.Post(responseURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("posting to response url: %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package slacker_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/slacker"
+)
+
+var _ = Describe("SlashCommandHandler", func() {
+	var notifier *Notifier
+	var commands <-chan *flyontime.Command
+	var form url.Values
+
+	BeforeEach(func() {
+		api.reset("")
+		notifier = &Notifier{
+			Token:         "xoxb-t0k3n",
+			SigningSecret: "s3cr3t",
+			ChannelID:     "C1",
+			Logger:        lagertest.NewTestLogger("slack"),
+		}
+		commands = notifier.Commands()
+		form = url.Values{
+			"command":      {"/concourse"},
+			"text":         {"pause my-pipeline"},
+			"user_id":      {"U1"},
+			"channel_id":   {"C1"},
+			"response_url": {api.URL + "/response"},
+		}
+	})
+
+	handle := func(secret string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		notifier.SlashCommandHandler().ServeHTTP(w, signedRequest("/slack/commands", secret, []byte(form.Encode())))
+		return w
+	}
+
+	It("should send the command and post the responses to the response URL", func() {
+		Ω(handle("s3cr3t").Code).Should(Equal(http.StatusOK))
+
+		var c *flyontime.Command
+		Eventually(commands).Should(Receive(&c))
+		Ω(c.Name).Should(Equal("pause"))
+		Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+		Ω(c.Job).Should(BeNil())
+
+		c.Responses <- "Paused."
+		close(c.Responses)
+
+		Eventually(api.responsesPosted).Should(ConsistOf(map[string]interface{}{
+			"response_type": "ephemeral",
+			"text":          "Paused.",
+		}))
+		Ω(api.postedMessages()).Should(BeEmpty())
+	})
+
+	Context("when responses should be visible in the channel", func() {
+		BeforeEach(func() {
+			notifier.SlashResponseType = ResponseInChannel
+		})
+
+		It("should post them as such", func() {
+			Ω(handle("s3cr3t").Code).Should(Equal(http.StatusOK))
+
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			c.Responses <- "Paused."
+			close(c.Responses)
+
+			Eventually(api.responsesPosted).Should(ConsistOf(map[string]interface{}{
+				"response_type": "in_channel",
+				"text":          "Paused.",
+			}))
+		})
+	})
+
+	Context("when the command is invalid", func() {
+		BeforeEach(func() {
+			form.Set("text", `pause "my-pipeline`)
+		})
+
+		It("should post the reason to the response URL", func() {
+			Ω(handle("s3cr3t").Code).Should(Equal(http.StatusOK))
+			Eventually(api.responsesPosted).Should(ConsistOf(HaveKeyWithValue("text", ContainSubstring("Invalid command"))))
+			Consistently(commands).ShouldNot(Receive())
+		})
+	})
+
+	Context("when the request is not signed with the signing secret", func() {
+		It("should reject it", func() {
+			Ω(handle("other").Code).Should(Equal(http.StatusUnauthorized))
+			Consistently(commands).ShouldNot(Receive())
+			Ω(api.responsesPosted()).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package slacker
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/gorilla/websocket"
+	"github.com/nlopes/slack"
+	"github.com/pkg/errors"
+)
+
+// apiClient calls the methods of the Web API that are not provided by the
+// slack package.
+var apiClient = &http.Client{Timeout: 30 * time.Second}
+
+// envelope wraps the payloads received over Socket Mode, see
+// https://api.slack.com/apis/connections/socket-implement
+type envelope struct {
+	Type       string          `json:"type"`
+	EnvelopeID string          `json:"envelope_id"`
+	Reason     string          `json:"reason"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+type ack struct {
+	EnvelopeID string `json:"envelope_id"`
+}
+
+// listenSocketMode connects to Slack using Socket Mode and handles the
+// received events until the connection fails or Slack asks for a reconnect.
+func (s *Notifier) listenSocketMode(logger lager.Logger) error {
+	url, err := s.openConnection()
+	if err != nil {
+		return err
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to socket mode")
+	}
+	defer conn.Close()
+
+	for {
+		var env envelope
+		if err := conn.ReadJSON(&env); err != nil {
+			return errors.Wrap(err, "error reading from socket mode")
+		}
+		if env.EnvelopeID != "" {
+			// Acknowledge envelopes right away, so that Slack does not
+			// retry their delivery.
+			if err := conn.WriteJSON(ack{EnvelopeID: env.EnvelopeID}); err != nil {
+				return errors.Wrap(err, "error acknowledging envelope")
+			}
+		}
+
+		switch env.Type {
+		case "hello":
+			s.conn.Up()
+			logger.Info("connected")
+		case "disconnect":
+			return errors.Errorf("disconnect requested: %s", env.Reason)
+		case "events_api":
+			var cb eventCallback
+			if err := json.Unmarshal(env.Payload, &cb); err != nil {
+				logger.Error("decode-event.fail", err)
+				continue
+			}
+			s.handleEvent(logger, &cb)
+		case "slash_commands":
+			var sc slashCommand
+			if err := json.Unmarshal(env.Payload, &sc); err != nil {
+				logger.Error("decode-slash-command.fail", err)
+				continue
+			}
+			s.handleSlashCommand(logger, &sc)
+		}
+	}
+}
+
+// openConnection returns the WebSocket URL for a new Socket Mode connection.
+func (s *Notifier) openConnection() (string, error) {
+	req, err := http.NewRequest(http.MethodPost, slack.SLACK_API+"apps.connections.open", strings.NewReader(""))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.AppToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		URL   string `json:"url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return "", errors.Wrapf(err, "apps.connections.open: %s", resp.Status)
+	}
+	if !r.OK {
+		return "", errors.Errorf("apps.connections.open: %s", r.Error)
+	}
+	return r.URL, nil
+}
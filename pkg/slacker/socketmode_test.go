@@ -0,0 +1,151 @@
+package slacker_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/slacker"
+)
+
+var appTokens int32
+
+var _ = Describe("Socket Mode", func() {
+	var notifier *Notifier
+	var commands <-chan *flyontime.Command
+
+	BeforeEach(func() {
+		appToken := fmt.Sprintf("xapp-%d", atomic.AddInt32(&appTokens, 1))
+		api.reset(appToken)
+		notifier = &Notifier{
+			Token:     "xoxb-t0k3n",
+			AppToken:  appToken,
+			ChannelID: "C1",
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+			Logger:    lagertest.NewTestLogger("slack"),
+		}
+		commands = notifier.Commands()
+		Eventually(api.connected).Should(Equal(1))
+	})
+
+	It("should be connected", func() {
+		Eventually(func() error {
+			_, err := notifier.Health()
+			return err
+		}).Should(Succeed())
+	})
+
+	Context("when an event is received", func() {
+		BeforeEach(func() {
+			api.send(map[string]interface{}{
+				"type":        "events_api",
+				"envelope_id": "env1",
+				"payload": callback("E1", map[string]interface{}{
+					"type":    "app_mention",
+					"channel": "C1",
+					"user":    "U1",
+					"text":    "<@UBOT> pause my-pipeline",
+					"ts":      "1500000001.000001",
+				}),
+			})
+		})
+
+		It("should acknowledge it and send its command", func() {
+			Eventually(api.acknowledged).Should(ConsistOf("env1"))
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("pause"))
+			Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+		})
+	})
+
+	Context("when a slash command is received", func() {
+		BeforeEach(func() {
+			api.send(map[string]interface{}{
+				"type":        "slash_commands",
+				"envelope_id": "env1",
+				"payload": map[string]interface{}{
+					"command":      "/concourse",
+					"text":         "pause my-pipeline",
+					"user_id":      "U1",
+					"channel_id":   "C1",
+					"response_url": api.URL + "/response",
+				},
+			})
+		})
+
+		It("should acknowledge it and post the responses to the response URL", func() {
+			Eventually(api.acknowledged).Should(ConsistOf("env1"))
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("pause"))
+
+			c.Responses <- "Paused."
+			close(c.Responses)
+
+			Eventually(api.responsesPosted).Should(ConsistOf(map[string]interface{}{
+				"response_type": "ephemeral",
+				"text":          "Paused.",
+			}))
+		})
+	})
+
+	Context("when a disconnect is requested", func() {
+		BeforeEach(func() {
+			api.send(map[string]interface{}{"type": "disconnect", "reason": "refresh_requested"})
+		})
+
+		It("should reconnect", func() {
+			Eventually(api.connected).Should(Equal(2))
+		})
+	})
+})
+
+var _ = Describe("Socket Mode when Slack cannot be reached at startup", func() {
+	var notifier *Notifier
+	var commands <-chan *flyontime.Command
+
+	BeforeEach(func() {
+		appToken := fmt.Sprintf("xapp-%d", atomic.AddInt32(&appTokens, 1))
+		api.reset(appToken)
+		api.failAuth(2)
+		notifier = &Notifier{
+			Token:     "xoxb-t0k3n",
+			AppToken:  appToken,
+			ChannelID: "C1",
+			Reconnect: backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+			Logger:    lagertest.NewTestLogger("slack"),
+		}
+		commands = notifier.Commands()
+	})
+
+	It("should retry obtaining the bot user and then connect", func() {
+		Eventually(api.connected).Should(Equal(1))
+		Eventually(func() error {
+			_, err := notifier.Health()
+			return err
+		}).Should(Succeed())
+
+		api.send(map[string]interface{}{
+			"type":        "events_api",
+			"envelope_id": "env1",
+			"payload": callback("E1", map[string]interface{}{
+				"type":    "app_mention",
+				"channel": "C1",
+				"user":    "U1",
+				"text":    "<@UBOT> pause my-pipeline",
+				"ts":      "1500000001.000001",
+			}),
+		})
+		var c *flyontime.Command
+		Eventually(commands).Should(Receive(&c))
+		Ω(c.Name).Should(Equal("pause"))
+		Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+	})
+})
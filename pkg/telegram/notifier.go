@@ -0,0 +1,347 @@
+// Package telegram implements a Telegram bot that sends notifications to a
+// chat and accepts commands as replies to them, inline keyboard buttons or
+// mentions in that chat.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/lunixbochs/vtclean"
+	"github.com/pkg/errors"
+)
+
+// DefaultAPI is the default URL of the Telegram Bot API.
+const DefaultAPI = "https://api.telegram.org"
+
+// DefaultPollTimeout is the default duration for which the Bot API holds
+// getUpdates requests when there are no new updates.
+const DefaultPollTimeout = 30 * time.Second
+
+// maxMessage is the maximum length of message texts.
+const maxMessage = 4096
+
+// keyboard holds the inline keyboard buttons attached to notifications. The
+// callback data of each button is the command it sends.
+var keyboard = [][]button{{
+	{Text: "Rerun", CallbackData: "rerun"},
+	{Text: "Mute 1h", CallbackData: "mute 1h"},
+	{Text: "Pause", CallbackData: "pause"},
+}}
+
+type Notifier struct {
+	Token       string
+	ChatID      string // Numeric id or @username of the chat.
+	API         string // Defaults to DefaultAPI.
+	PollTimeout time.Duration
+	Client      *http.Client
+	Retention   time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect   backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
+	Logger      lager.Logger
+
+	initOnce sync.Once
+	login    chat.Init // obtains the bot user
+	conn     chat.Connection
+	self     user
+
+	router   chat.Router
+	messages chat.Registry // maps message id to notification
+}
+
+// setup applies the defaults.
+func (t *Notifier) setup() {
+	t.initOnce.Do(func() {
+		t.conn.Reconnect = t.Reconnect
+		t.messages.Name = "telegram_messages"
+		t.messages.TTL = t.Retention
+		if t.API == "" {
+			t.API = DefaultAPI
+		}
+		if t.PollTimeout == 0 {
+			t.PollTimeout = DefaultPollTimeout
+		}
+		if t.Client == nil {
+			t.Client = &http.Client{Timeout: t.PollTimeout + 30*time.Second}
+		}
+		if t.Logger == nil {
+			t.Logger = lager.NewLogger("")
+		}
+	})
+}
+
+func (t *Notifier) init() error {
+	t.setup()
+	return t.login.Do(func() error {
+		if err := t.call("getMe", struct{}{}, &t.self); err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		return nil
+	})
+}
+
+func (t *Notifier) Commands() <-chan *flyontime.Command {
+	t.setup()
+	logger := t.Logger.Session("commands")
+
+	var offset int64
+	go t.conn.Run(logger, func() error {
+		if err := t.init(); err != nil {
+			return err
+		}
+		for {
+			var updates []update
+			err := t.call("getUpdates", getUpdates{
+				Offset:         offset,
+				Timeout:        int(t.PollTimeout / time.Second),
+				AllowedUpdates: []string{"message", "callback_query"},
+			}, &updates)
+			if err != nil {
+				return errors.Wrap(err, "error getting updates")
+			}
+			t.conn.Up()
+			for _, u := range updates {
+				offset = u.ID + 1
+				t.handleUpdate(logger, u)
+			}
+		}
+	})
+
+	return t.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Telegram.
+func (t *Notifier) Health() (map[string]interface{}, error) {
+	return t.conn.Health(t.init)
+}
+
+func (t *Notifier) handleUpdate(logger lager.Logger, u update) {
+	switch {
+	case u.CallbackQuery != nil:
+		t.handleCallbackQuery(logger.Session("handle-callback-query"), u.CallbackQuery)
+	case u.Message != nil && u.Message.From != nil && !u.Message.From.IsBot:
+		t.handleMessage(logger, u.Message)
+	}
+}
+
+func (t *Notifier) handleMessage(logger lager.Logger, m *message) {
+	if !t.isConfiguredChat(m.Chat) {
+		// Anyone could message the bot directly, hence messages from
+		// other chats, including private ones, are ignored.
+		return
+	}
+	if m.ReplyTo != nil {
+		if n, ok := t.notification(m.ReplyTo.ID); ok {
+			t.router.Route(logger.Session("handle-reply"), t.stripMention(m.Text), &n.Job, t.replyTo(m.Chat.ID, m.ID))
+			return
+		}
+	}
+	if text := t.stripMention(m.Text); text != m.Text {
+		t.router.Route(logger.Session("handle-mention"), text, nil, t.replyTo(m.Chat.ID, m.ID))
+	}
+}
+
+func (t *Notifier) handleCallbackQuery(logger lager.Logger, q *callbackQuery) {
+	if err := t.call("answerCallbackQuery", answerCallbackQuery{ID: q.ID}, nil); err != nil {
+		logger.Error("answer.fail", err)
+	}
+	if q.Message == nil || !t.isConfiguredChat(q.Message.Chat) {
+		return
+	}
+	n, ok := t.notification(q.Message.ID)
+	if !ok {
+		logger.Info("unknown-notification")
+		return
+	}
+	t.router.Route(logger, q.Data, &n.Job, t.replyTo(q.Message.Chat.ID, q.Message.ID))
+}
+
+// stripMention removes the leading mention of the bot from text. Bot
+// commands, e.g. "/rerun" or "/rerun@flyontime_bot", are converted to plain
+// commands.
+func (t *Notifier) stripMention(text string) string {
+	mention := "@" + t.self.Username
+	if text, ok := chat.StripMention(text, mention); ok {
+		return text
+	}
+	if strings.HasPrefix(text, "/") {
+		fields := strings.SplitN(text[1:], " ", 2)
+		fields[0] = strings.TrimSuffix(fields[0], mention)
+		return strings.Join(fields, " ")
+	}
+	return text
+}
+
+func (t *Notifier) isConfiguredChat(c chatInfo) bool {
+	return fmt.Sprint(c.ID) == t.ChatID || (c.Username != "" && "@"+c.Username == t.ChatID)
+}
+
+func (t *Notifier) notification(messageID int64) (*flyontime.Notification, bool) {
+	return t.messages.Get(strconv.FormatInt(messageID, 10))
+}
+
+func (t *Notifier) replyTo(chatID, messageID int64) chat.Reply {
+	return func(reply string) error {
+		return t.call("sendMessage", sendMessage{
+			ChatID:           chatID,
+			Text:             reply,
+			ReplyToMessageID: messageID,
+		}, nil)
+	}
+}
+
+func (t *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := t.init(); err != nil {
+		return err
+	}
+
+	msg := sendMessage{
+		ChatID:                t.ChatID,
+		Text:                  formatHTML(n),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	}
+	if n.HasJob() {
+		// The buttons send commands for the job of the notification.
+		msg.ReplyMarkup = &replyMarkup{InlineKeyboard: keyboard}
+	}
+	var posted message
+	if err := t.call("sendMessage", msg, &posted); err != nil {
+		return err
+	}
+
+	if n.HasJob() {
+		t.messages.Put(strconv.FormatInt(posted.ID, 10), n)
+	}
+	return nil
+}
+
+// call invokes method of the Bot API with params and decodes its result into
+// result, unless it is nil.
+func (t *Notifier) call(method string, params, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/bot%s/%s", strings.TrimSuffix(t.API, "/"), t.Token, method)
+	resp, err := t.Client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// Do not leak the token, which is part of the URL.
+		return errors.Errorf("%s: request failed", method)
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return errors.Wrapf(err, "%s: %s", method, resp.Status)
+	}
+	if !r.OK {
+		return errors.Errorf("%s: %s", method, r.Description)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(r.Result, result)
+}
+
+func formatHTML(n *flyontime.Notification) string {
+	title := html.EscapeString(n.Title)
+	if n.DashboardLink != "" {
+		title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(n.DashboardLink), title)
+	}
+	text := fmt.Sprintf("%s <b>%s</b>", iconFor(n.Severity), title)
+	if n.JobOutput == "" {
+		return text
+	}
+
+	// Leave room for the title and the formatting.
+	output := chat.Truncate(vtclean.Clean(n.JobOutput, false), maxMessage-len([]rune(text))-64)
+	return fmt.Sprintf("%s\n<pre>%s</pre>", text, html.EscapeString(output))
+}
+
+func iconFor(severity flyontime.Severity) string {
+	switch severity {
+	case flyontime.SeverityInfo:
+		return "✅"
+	case flyontime.SeverityWarn:
+		return "⚠️"
+	default:
+		return "❌"
+	}
+}
+
+type user struct {
+	ID       int64  `json:"id"`
+	IsBot    bool   `json:"is_bot"`
+	Username string `json:"username"`
+}
+
+type chatInfo struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Username string `json:"username"`
+}
+
+type message struct {
+	ID      int64    `json:"message_id"`
+	From    *user    `json:"from"`
+	Chat    chatInfo `json:"chat"`
+	Text    string   `json:"text"`
+	ReplyTo *message `json:"reply_to_message"`
+}
+
+type callbackQuery struct {
+	ID      string   `json:"id"`
+	From    user     `json:"from"`
+	Message *message `json:"message"`
+	Data    string   `json:"data"`
+}
+
+type update struct {
+	ID            int64          `json:"update_id"`
+	Message       *message       `json:"message"`
+	CallbackQuery *callbackQuery `json:"callback_query"`
+}
+
+type getUpdates struct {
+	Offset         int64    `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+type sendMessage struct {
+	ChatID                interface{}  `json:"chat_id"`
+	Text                  string       `json:"text"`
+	ParseMode             string       `json:"parse_mode,omitempty"`
+	DisableWebPagePreview bool         `json:"disable_web_page_preview,omitempty"`
+	ReplyToMessageID      int64        `json:"reply_to_message_id,omitempty"`
+	ReplyMarkup           *replyMarkup `json:"reply_markup,omitempty"`
+}
+
+type replyMarkup struct {
+	InlineKeyboard [][]button `json:"inline_keyboard"`
+}
+
+type button struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+type answerCallbackQuery struct {
+	ID string `json:"callback_query_id"`
+}
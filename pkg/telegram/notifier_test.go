@@ -0,0 +1,371 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/telegram"
+)
+
+// botAPI is a minimal stub of the Telegram Bot API.
+type botAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	calls    map[string][]map[string]interface{} // params by method
+	pending  []map[string]interface{}
+	updateID int
+	sent     int
+	down     int // number of getMe calls to fail
+}
+
+func newBotAPI() *botAPI {
+	api := &botAPI{calls: make(map[string][]map[string]interface{})}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serve))
+	return api
+}
+
+func (api *botAPI) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	Ω(r.Method).Should(Equal("POST"))
+	method := strings.TrimPrefix(r.URL.Path, "/bott0k3n/")
+	var params map[string]interface{}
+	Ω(json.NewDecoder(r.Body).Decode(&params)).Should(Succeed())
+
+	api.mu.Lock()
+	api.calls[method] = append(api.calls[method], params)
+	var updates []map[string]interface{}
+	if method == "getUpdates" {
+		updates, api.pending = api.pending, nil
+	}
+	if method == "sendMessage" {
+		api.sent++
+	}
+	sent := api.sent
+	down := method == "getMe" && api.down > 0
+	if down {
+		api.down--
+	}
+	api.mu.Unlock()
+
+	if down {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"ok": false, "description": "Bad Gateway"}`)
+		return
+	}
+
+	var result interface{}
+	switch method {
+	case "getMe":
+		result = map[string]interface{}{"id": 42, "is_bot": true, "username": "flyontime_bot"}
+	case "sendMessage":
+		result = map[string]interface{}{"message_id": 100 + sent, "chat": map[string]interface{}{"id": -1001, "type": "supergroup"}}
+	case "getUpdates":
+		if len(updates) == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		result = append([]map[string]interface{}{}, updates...)
+	case "answerCallbackQuery":
+		result = true
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"ok": false, "description": "Not Found"}`)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+// update queues an update, which is returned by the next getUpdates call.
+func (api *botAPI) update(u map[string]interface{}) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.updateID++
+	u["update_id"] = api.updateID
+	api.pending = append(api.pending, u)
+}
+
+// message queues a message sent to the chat with the given ID, which replies
+// to the message replyTo, unless it is zero.
+func (api *botAPI) message(id int, chatID int64, chatType, text string, replyTo int) {
+	m := map[string]interface{}{
+		"message_id": id,
+		"from":       map[string]interface{}{"id": 7, "is_bot": false, "username": "alice"},
+		"chat":       map[string]interface{}{"id": chatID, "type": chatType},
+		"text":       text,
+	}
+	if replyTo != 0 {
+		m["reply_to_message"] = map[string]interface{}{"message_id": replyTo}
+	}
+	api.update(map[string]interface{}{"message": m})
+}
+
+// fail makes the next n getMe calls fail, as if the Bot API was down.
+func (api *botAPI) fail(n int) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.down = n
+}
+
+func (api *botAPI) called(method string) []map[string]interface{} {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]map[string]interface{}(nil), api.calls[method]...)
+}
+
+var _ = Describe("Notifier", func() {
+	var api *botAPI
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		api = newBotAPI()
+		notifier = &Notifier{
+			Token:       "t0k3n",
+			ChatID:      "-1001",
+			API:         api.URL,
+			PollTimeout: time.Second,
+			Reconnect:   backoff.Policy{Min: 10 * time.Millisecond, Max: 100 * time.Millisecond},
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from <p1> has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "<boom>",
+		}
+	})
+
+	AfterEach(func() {
+		api.Close()
+	})
+
+	Describe("Notify", func() {
+		It("should send an HTML formatted message with a keyboard of commands", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := api.called("sendMessage")
+			Ω(sent).Should(HaveLen(1))
+			Ω(sent[0]).Should(HaveKeyWithValue("chat_id", "-1001"))
+			Ω(sent[0]).Should(HaveKeyWithValue("parse_mode", "HTML"))
+			Ω(sent[0]).Should(HaveKeyWithValue("text",
+				"❌ <b><a href=\"http://concourse/builds/1\">Job j1 from &lt;p1&gt; has failed.</a></b>\n<pre>&lt;boom&gt;</pre>"))
+			Ω(sent[0]).Should(HaveKeyWithValue("reply_markup", map[string]interface{}{
+				"inline_keyboard": []interface{}{[]interface{}{
+					map[string]interface{}{"text": "Rerun", "callback_data": "rerun"},
+					map[string]interface{}{"text": "Mute 1h", "callback_data": "mute 1h"},
+					map[string]interface{}{"text": "Pause", "callback_data": "pause"},
+				}},
+			}))
+		})
+
+		Context("when the notification is not about a job", func() {
+			BeforeEach(func() {
+				notification = &flyontime.Notification{
+					Severity: flyontime.SeverityWarn,
+					Title:    "Cannot reach Concourse.",
+				}
+			})
+
+			It("should send a message without a keyboard", func() {
+				Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+				sent := api.called("sendMessage")
+				Ω(sent).Should(HaveLen(1))
+				Ω(sent[0]).ShouldNot(HaveKey("reply_markup"))
+			})
+
+			It("should not treat replies to it as commands", func() {
+				commands := notifier.Commands()
+				Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+				api.message(200, -1001, "supergroup", "rerun", 101)
+
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the Bot API fails", func() {
+			BeforeEach(func() {
+				notifier.Token = "wrong"
+			})
+
+			It("should return an error without the token", func() {
+				err := notifier.Notify(context.Background(), notification)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).ShouldNot(ContainSubstring("wrong"))
+			})
+		})
+	})
+
+	Describe("Commands", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			commands = notifier.Commands()
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+		})
+
+		It("should poll for updates", func() {
+			Eventually(func() int { return len(api.called("getUpdates")) }).Should(BeNumerically(">", 1))
+			params := api.called("getUpdates")[0]
+			Ω(params).Should(HaveKeyWithValue("timeout", float64(1)))
+			Ω(params).Should(HaveKeyWithValue("allowed_updates", []interface{}{"message", "callback_query"}))
+		})
+
+		Context("when updates are received", func() {
+			BeforeEach(func() {
+				api.message(1, -1001, "supergroup", "hello there", 0)
+				api.message(2, -1001, "supergroup", "hello again", 0)
+			})
+
+			It("should acknowledge them when polling next", func() {
+				Eventually(func() []map[string]interface{} { return api.called("getUpdates") }).Should(ContainElement(HaveKeyWithValue("offset", float64(3))))
+			})
+		})
+
+		Context("when a notification is replied to", func() {
+			BeforeEach(func() {
+				api.message(200, -1001, "supergroup", "mute 1h", 101)
+			})
+
+			It("should send a command for its job and send the responses as replies", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("mute"))
+				Ω(c.Args).Should(Equal([]string{"1h"}))
+				Ω(c.Job).Should(Equal(&notification.Job))
+
+				c.Responses <- "Muted."
+				close(c.Responses)
+
+				Eventually(func() []map[string]interface{} { return api.called("sendMessage") }).Should(HaveLen(2))
+				reply := api.called("sendMessage")[1]
+				Ω(reply).Should(HaveKeyWithValue("chat_id", float64(-1001)))
+				Ω(reply).Should(HaveKeyWithValue("text", "Muted."))
+				Ω(reply).Should(HaveKeyWithValue("reply_to_message_id", float64(200)))
+			})
+		})
+
+		Context("when a message unrelated to notifications is replied to", func() {
+			BeforeEach(func() {
+				api.message(200, -1001, "supergroup", "mute 1h", 99)
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+
+		Context("when a button of a notification is pressed", func() {
+			BeforeEach(func() {
+				api.update(map[string]interface{}{
+					"callback_query": map[string]interface{}{
+						"id":   "q1",
+						"from": map[string]interface{}{"id": 7, "username": "alice"},
+						"message": map[string]interface{}{
+							"message_id": 101,
+							"chat":       map[string]interface{}{"id": -1001, "type": "supergroup"},
+						},
+						"data": "rerun",
+					},
+				})
+			})
+
+			It("should answer the query and send the command of the button for the job", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("rerun"))
+				Ω(c.Job).Should(Equal(&notification.Job))
+				Ω(api.called("answerCallbackQuery")).Should(ConsistOf(HaveKeyWithValue("callback_query_id", "q1")))
+			})
+		})
+
+		Context("when the bot is mentioned", func() {
+			BeforeEach(func() {
+				api.message(200, -1001, "supergroup", "@flyontime_bot pause my-pipeline", 0)
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when a user whose name starts with the bot's one is mentioned", func() {
+			BeforeEach(func() {
+				api.message(200, -1001, "supergroup", "@flyontime_bot2 pause my-pipeline", 0)
+			})
+
+			It("should neither send any command nor reply", func() {
+				Consistently(commands).ShouldNot(Receive())
+				Ω(api.called("sendMessage")).Should(HaveLen(1))
+			})
+		})
+
+		Context("when a bot command is received", func() {
+			BeforeEach(func() {
+				api.message(200, -1001, "supergroup", "/pause@flyontime_bot my-pipeline", 0)
+			})
+
+			It("should send it as a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+			})
+		})
+
+		Context("when anyone messages the bot directly", func() {
+			BeforeEach(func() {
+				api.message(200, 7, "private", "pause my-pipeline", 0)
+			})
+
+			It("should neither send any command nor reply", func() {
+				Consistently(commands).ShouldNot(Receive())
+				Ω(api.called("sendMessage")).Should(HaveLen(1))
+			})
+		})
+
+		Context("when the bot is mentioned in another chat", func() {
+			BeforeEach(func() {
+				api.message(200, -1002, "supergroup", "@flyontime_bot pause my-pipeline", 0)
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("Commands when the Bot API is down at startup", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			api.fail(2)
+			commands = notifier.Commands()
+			api.message(200, -1001, "supergroup", "@flyontime_bot pause my-pipeline", 0)
+		})
+
+		It("should retry initializing until it succeeds", func() {
+			var c *flyontime.Command
+			Eventually(commands).Should(Receive(&c))
+			Ω(c.Name).Should(Equal("pause"))
+			Ω(len(api.called("getMe"))).Should(BeNumerically(">=", 3))
+			_, err := notifier.Health()
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package telegram_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTelegram(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Telegram Suite")
+}
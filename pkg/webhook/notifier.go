@@ -0,0 +1,193 @@
+// Package webhook implements a notifier that posts notifications to an
+// arbitrary HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader is the header that carries the signature of the request
+// body, when a secret is configured.
+const SignatureHeader = "X-Flyontime-Signature"
+
+// DefaultTimeout is the default maximum time spent delivering a notification.
+const DefaultTimeout = 10 * time.Second
+
+// Notifier posts notifications to URL.
+//
+// By default the request body is the notification encoded as JSON. If
+// Template is set, it is used instead, with the notification as data. The
+// template could use the json function to encode values as JSON.
+//
+// If Secret is set, the request body is signed with HMAC-SHA256 and the
+// signature is sent in the SignatureHeader as "sha256=<hex digest>".
+type Notifier struct {
+	URL      string
+	Template string
+	Headers  map[string]string
+	Secret   string
+	Retries  int           // Number of retries for failed requests.
+	Backoff  time.Duration // Delay before the first retry, doubled after each retry.
+	Timeout  time.Duration // Maximum time spent delivering a notification, including retries. Defaults to DefaultTimeout.
+	Client   *http.Client
+	Logger   lager.Logger
+
+	initOnce sync.Once
+	initErr  error
+	tmpl     *template.Template
+}
+
+func (w *Notifier) init() error {
+	w.initOnce.Do(func() {
+		if w.Client == nil {
+			w.Client = &http.Client{Timeout: 30 * time.Second}
+		}
+		if w.Backoff == 0 {
+			w.Backoff = time.Second
+		}
+		if w.Timeout == 0 {
+			w.Timeout = DefaultTimeout
+		}
+		if w.Logger == nil {
+			w.Logger = lager.NewLogger("")
+		}
+		if w.Template != "" {
+			w.tmpl, w.initErr = template.New("webhook").
+				Funcs(template.FuncMap{"json": toJSON}).
+				Parse(w.Template)
+			w.initErr = errors.Wrap(w.initErr, "error parsing template")
+		}
+	})
+	return w.initErr
+}
+
+func (w *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := w.init(); err != nil {
+		return err
+	}
+	logger := lagerctx.WithSession(ctx, "webhook")
+
+	body, err := w.body(n)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+	backoff := w.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.Retries {
+			return err
+		}
+		logger.Error("post.fail-will-retry", err, lager.Data{"attempt": attempt + 1})
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// post sends body to the configured URL. It reports whether the request
+// could be retried in case of an error.
+func (w *Notifier) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(w.Secret, body))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected response status: %s", resp.Status)
+}
+
+func (w *Notifier) body(n *flyontime.Notification) ([]byte, error) {
+	if w.tmpl == nil {
+		return json.Marshal(payloadFor(n))
+	}
+	var buf bytes.Buffer
+	if err := w.tmpl.Execute(&buf, n); err != nil {
+		return nil, errors.Wrap(err, "error executing template")
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type payload struct {
+	Severity      flyontime.Severity `json:"severity"`
+	Title         string             `json:"title"`
+	DashboardLink string             `json:"dashboard_link"`
+	Job           job                `json:"job"`
+	JobOutput     string             `json:"job_output,omitempty"`
+}
+
+type job struct {
+	Name     string `json:"name"`
+	Pipeline string `json:"pipeline"`
+	Team     string `json:"team"`
+}
+
+func payloadFor(n *flyontime.Notification) payload {
+	return payload{
+		Severity:      n.Severity,
+		Title:         n.Title,
+		DashboardLink: n.DashboardLink,
+		Job: job{
+			Name:     n.Job.Name,
+			Pipeline: n.Job.Pipeline,
+			Team:     n.Job.Team,
+		},
+		JobOutput: n.JobOutput,
+	}
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -0,0 +1,157 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/webhook"
+)
+
+var _ = Describe("Notifier", func() {
+	var server *httptest.Server
+	var requests chan *http.Request
+	var bodies chan []byte
+	var status int32
+
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		requests = make(chan *http.Request, 10)
+		bodies = make(chan []byte, 10)
+		atomic.StoreInt32(&status, http.StatusOK)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			requests <- r
+			bodies <- b
+			w.WriteHeader(int(atomic.LoadInt32(&status)))
+		}))
+
+		notifier = &Notifier{
+			URL:     server.URL,
+			Backoff: time.Millisecond,
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "boom",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post the notification as JSON", func() {
+		Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+		var r *http.Request
+		Eventually(requests).Should(Receive(&r))
+		Ω(r.Method).Should(Equal("POST"))
+		Ω(r.Header.Get("Content-Type")).Should(Equal("application/json"))
+
+		var body map[string]interface{}
+		Ω(json.Unmarshal(<-bodies, &body)).Should(Succeed())
+		Ω(body).Should(HaveKeyWithValue("severity", "error"))
+		Ω(body).Should(HaveKeyWithValue("title", "Job j1 from p1 has failed."))
+		Ω(body).Should(HaveKeyWithValue("dashboard_link", "http://concourse/builds/1"))
+		Ω(body).Should(HaveKeyWithValue("job_output", "boom"))
+		Ω(body["job"]).Should(Equal(map[string]interface{}{"name": "j1", "pipeline": "p1", "team": "t1"}))
+	})
+
+	Context("when custom headers are configured", func() {
+		BeforeEach(func() {
+			notifier.Headers = map[string]string{"Authorization": "Bearer xyz"}
+		})
+
+		It("should send them", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			var r *http.Request
+			Eventually(requests).Should(Receive(&r))
+			Ω(r.Header.Get("Authorization")).Should(Equal("Bearer xyz"))
+		})
+	})
+
+	Context("when a template is configured", func() {
+		BeforeEach(func() {
+			notifier.Template = `{"text": {{json .Title}}, "job": "{{.Job.Pipeline}}/{{.Job.Name}}"}`
+		})
+
+		It("should use it for the body", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			Ω(string(<-bodies)).Should(Equal(`{"text": "Job j1 from p1 has failed.", "job": "p1/j1"}`))
+		})
+
+		Context("but it is invalid", func() {
+			BeforeEach(func() {
+				notifier.Template = "{{.Title"
+			})
+
+			It("should return an error", func() {
+				Ω(notifier.Notify(context.Background(), notification)).ShouldNot(Succeed())
+			})
+		})
+	})
+
+	Context("when a secret is configured", func() {
+		BeforeEach(func() {
+			notifier.Secret = "s3cr3t"
+		})
+
+		It("should sign the body", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+			var r *http.Request
+			Eventually(requests).Should(Receive(&r))
+			body := <-bodies
+			Ω(r.Header.Get(SignatureHeader)).Should(Equal("sha256=" + Sign("s3cr3t", body)))
+		})
+	})
+
+	Context("when the endpoint fails", func() {
+		BeforeEach(func() {
+			atomic.StoreInt32(&status, http.StatusBadGateway)
+			notifier.Retries = 2
+		})
+
+		It("should retry and eventually return an error", func() {
+			Ω(notifier.Notify(context.Background(), notification)).ShouldNot(Succeed())
+			Ω(requests).Should(HaveLen(3))
+		})
+
+		Context("for longer than the timeout", func() {
+			BeforeEach(func() {
+				notifier.Retries = 100
+				notifier.Backoff = 20 * time.Millisecond
+				notifier.Timeout = 50 * time.Millisecond
+			})
+
+			It("should give up once the timeout elapses", func() {
+				start := time.Now()
+				Ω(notifier.Notify(context.Background(), notification)).Should(MatchError(context.DeadlineExceeded))
+				Ω(time.Since(start)).Should(BeNumerically("<", time.Second))
+			})
+		})
+
+		Context("with a client error", func() {
+			BeforeEach(func() {
+				atomic.StoreInt32(&status, http.StatusBadRequest)
+			})
+
+			It("should not retry", func() {
+				Ω(notifier.Notify(context.Background(), notification)).ShouldNot(Succeed())
+				Ω(requests).Should(HaveLen(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,313 @@
+// Package zulip implements a Zulip bot that sends notifications to a stream,
+// using a separate topic for each pipeline/job. Messages mentioning the bot in
+// these topics are treated as commands for the respective job, and elsewhere
+// in the stream as global commands. Commands could also be sent as private
+// messages.
+package zulip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/Bo0mer/flyontime/pkg/backoff"
+	"github.com/Bo0mer/flyontime/pkg/chat"
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	"github.com/pkg/errors"
+)
+
+// maxOutput is the maximum length of job output included in notifications,
+// chosen so that messages fit in the 10000 characters limit of Zulip.
+const maxOutput = 9000
+
+// maxTopic is the maximum length of topic names. Longer ones are truncated by
+// the server, after which they would not match the registered ones.
+const maxTopic = 60
+
+type Notifier struct {
+	Site      string // URL of the Zulip server.
+	Email     string // Email of the bot user.
+	APIKey    string
+	Stream    string
+	Client    *http.Client
+	Retention time.Duration  // For how long replies to notifications are handled. Defaults to chat.DefaultTTL.
+	Reconnect backoff.Policy // Delays between reconnects. Defaults to backoff.DefaultPolicy.
+	Logger    lager.Logger
+
+	initOnce sync.Once
+	login    chat.Init // obtains the bot user
+	conn     chat.Connection
+	self     user
+
+	router chat.Router
+	topics chat.Registry // maps topic to latest notification
+}
+
+// setup applies the defaults.
+func (z *Notifier) setup() {
+	z.initOnce.Do(func() {
+		z.conn.Reconnect = z.Reconnect
+		z.topics.Name = "zulip_topics"
+		z.topics.TTL = z.Retention
+		if z.Client == nil {
+			// Event queue requests are held for up to a few minutes.
+			z.Client = &http.Client{Timeout: 5 * time.Minute}
+		}
+		if z.Logger == nil {
+			z.Logger = lager.NewLogger("")
+		}
+	})
+}
+
+func (z *Notifier) init() error {
+	z.setup()
+	return z.login.Do(func() error {
+		if err := z.call("GET", "users/me", nil, &z.self); err != nil {
+			return errors.Wrap(err, "error obtaining bot info")
+		}
+		return nil
+	})
+}
+
+func (z *Notifier) Commands() <-chan *flyontime.Command {
+	z.setup()
+	logger := z.Logger.Session("commands")
+
+	go z.conn.Run(logger, func() error {
+		if err := z.init(); err != nil {
+			return err
+		}
+		return z.listen(logger)
+	})
+
+	return z.router.Commands()
+}
+
+// Health reports whether the bot is initialized and connected to Zulip.
+func (z *Notifier) Health() (map[string]interface{}, error) {
+	return z.conn.Health(z.init)
+}
+
+// listen registers an event queue and handles the messages received through
+// it until the queue expires or a request fails.
+func (z *Notifier) listen(logger lager.Logger) error {
+	var queue struct {
+		ID          string `json:"queue_id"`
+		LastEventID int64  `json:"last_event_id"`
+	}
+	// Without apply_markdown=false the content of messages arrives rendered
+	// as HTML, in which mentions cannot be recognized.
+	form := url.Values{
+		"event_types":    {`["message"]`},
+		"apply_markdown": {"false"},
+	}
+	err := z.call("POST", "register", form, &queue)
+	if err != nil {
+		return errors.Wrap(err, "error registering event queue")
+	}
+	z.conn.Up()
+
+	lastEventID := queue.LastEventID
+	for {
+		var r struct {
+			Events []struct {
+				ID      int64    `json:"id"`
+				Type    string   `json:"type"`
+				Message *message `json:"message"`
+			} `json:"events"`
+		}
+		q := url.Values{
+			"queue_id":      {queue.ID},
+			"last_event_id": {fmt.Sprint(lastEventID)},
+		}
+		if err := z.call("GET", "events?"+q.Encode(), nil, &r); err != nil {
+			return errors.Wrap(err, "error getting events")
+		}
+		for _, ev := range r.Events {
+			lastEventID = ev.ID
+			if ev.Type != "message" || ev.Message == nil || ev.Message.SenderID == z.self.ID {
+				// Do not reply to self.
+				continue
+			}
+			z.handleMessage(logger, ev.Message)
+		}
+	}
+}
+
+func (z *Notifier) handleMessage(logger lager.Logger, m *message) {
+	if m.Type == "private" {
+		z.router.Route(logger.Session("handle-dm"), z.stripMention(m.Content), nil, z.replyPrivately(m.SenderEmail))
+		return
+	}
+
+	var stream string
+	if err := json.Unmarshal(m.DisplayRecipient, &stream); err != nil || stream != z.Stream {
+		return
+	}
+	text := z.stripMention(m.Content)
+	if text == m.Content {
+		// People discuss the notifications in their topics, hence only
+		// mentions are commands.
+		return
+	}
+	if n, ok := z.notification(m.Topic); ok {
+		z.router.Route(logger.Session("handle-reply"), text, &n.Job, z.replyToTopic(m.Topic))
+		return
+	}
+	z.router.Route(logger.Session("handle-mention"), text, nil, z.replyToTopic(m.Topic))
+}
+
+// stripMention removes the leading mention of the bot, e.g. "@**flyontime**"
+// or "@**flyontime|42**", from text.
+func (z *Notifier) stripMention(text string) string {
+	for _, name := range []string{z.self.FullName, fmt.Sprintf("%s|%d", z.self.FullName, z.self.ID)} {
+		for _, prefix := range []string{"@**", "@_**"} {
+			mention := prefix + name + "**"
+			if strings.HasPrefix(strings.ToLower(text), strings.ToLower(mention)) {
+				return text[len(mention):]
+			}
+		}
+	}
+	return text
+}
+
+func (z *Notifier) notification(topic string) (*flyontime.Notification, bool) {
+	return z.topics.Get(topic)
+}
+
+func (z *Notifier) replyToTopic(topic string) chat.Reply {
+	return func(reply string) error {
+		return z.send(topic, reply)
+	}
+}
+
+func (z *Notifier) replyPrivately(email string) chat.Reply {
+	return func(reply string) error {
+		return z.call("POST", "messages", url.Values{
+			"type":    {"private"},
+			"to":      {email},
+			"content": {reply},
+		}, nil)
+	}
+}
+
+func (z *Notifier) Notify(ctx context.Context, n *flyontime.Notification) error {
+	if err := z.init(); err != nil {
+		return err
+	}
+
+	if !n.HasJob() {
+		return z.send(generalTopic, format(n))
+	}
+	t := topic(n.Job)
+	if err := z.send(t, format(n)); err != nil {
+		return err
+	}
+
+	z.topics.Put(t, n)
+	return nil
+}
+
+// send posts content in topic of the configured stream.
+func (z *Notifier) send(topic, content string) error {
+	return z.call("POST", "messages", url.Values{
+		"type":    {"stream"},
+		"to":      {z.Stream},
+		"topic":   {topic},
+		"content": {content},
+	}, nil)
+}
+
+// call invokes the REST API endpoint at path with form (if not nil) and
+// decodes the response into result (if not nil).
+func (z *Notifier) call(method, path string, form url.Values, result interface{}) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(z.Site, "/")+"/api/v1/"+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(z.Email, z.APIKey)
+
+	resp, err := z.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return errors.Wrapf(err, "%s %s: %s", method, path, resp.Status)
+	}
+	var r struct {
+		Result string `json:"result"`
+		Msg    string `json:"msg"`
+	}
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return err
+	}
+	if r.Result != "success" {
+		return errors.Errorf("%s %s: %s", method, path, r.Msg)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, result)
+}
+
+// generalTopic is the topic in which notifications that are not about a job
+// are sent. Messages in it are not treated as commands.
+const generalTopic = "concourse"
+
+// topic returns the topic in which notifications for job are sent. Long
+// topics keep their end, as job names tell them apart better than pipelines.
+func topic(job flyontime.Job) string {
+	return chat.Truncate(job.Pipeline+"/"+job.Name, maxTopic)
+}
+
+func format(n *flyontime.Notification) string {
+	title := n.Title
+	if n.DashboardLink != "" {
+		title = fmt.Sprintf("[%s](%s)", n.Title, n.DashboardLink)
+	}
+	text := fmt.Sprintf("%s **%s**", emojiFor(n.Severity), title)
+	if n.JobOutput == "" {
+		return text
+	}
+
+	return fmt.Sprintf("%s\n%s", text, chat.FormatCode(n.JobOutput, maxOutput))
+}
+
+func emojiFor(severity flyontime.Severity) string {
+	switch severity {
+	case flyontime.SeverityInfo:
+		return ":check:"
+	case flyontime.SeverityWarn:
+		return ":warning:"
+	default:
+		return ":cross_mark:"
+	}
+}
+
+type user struct {
+	ID       int64  `json:"user_id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+}
+
+type message struct {
+	ID          int64  `json:"id"`
+	SenderID    int64  `json:"sender_id"`
+	SenderEmail string `json:"sender_email"`
+	Type        string `json:"type"`
+	// DisplayRecipient is the name of the stream for stream messages and a
+	// list of users for private messages.
+	DisplayRecipient json.RawMessage `json:"display_recipient"`
+	Topic            string          `json:"subject"`
+	Content          string          `json:"content"`
+}
@@ -0,0 +1,287 @@
+package zulip_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Bo0mer/flyontime/pkg/flyontime"
+	. "github.com/Bo0mer/flyontime/pkg/zulip"
+)
+
+// server is a minimal stub of the Zulip REST API.
+type server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	sent    []url.Values
+	pending []map[string]interface{}
+	eventID int
+}
+
+func newServer() *server {
+	s := &server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *server) serve(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	user, pass, ok := r.BasicAuth()
+	Ω(ok).Should(BeTrue())
+	Ω(user).Should(Equal("flyontime-bot@example.org"))
+	Ω(pass).Should(Equal("k3y"))
+
+	switch r.URL.Path {
+	case "/api/v1/users/me":
+		fmt.Fprint(w, `{"result": "success", "user_id": 42, "email": "flyontime-bot@example.org", "full_name": "flyontime"}`)
+	case "/api/v1/messages":
+		Ω(r.ParseForm()).Should(Succeed())
+		s.mu.Lock()
+		s.sent = append(s.sent, r.PostForm)
+		id := len(s.sent)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"result": "success", "id": %d}`, id)
+	case "/api/v1/register":
+		Ω(r.ParseForm()).Should(Succeed())
+		if r.PostForm.Get("apply_markdown") != "false" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"result": "error", "msg": "content would be rendered as HTML"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": "success", "queue_id": "q1", "last_event_id": -1}`)
+	case "/api/v1/events":
+		Ω(r.URL.Query().Get("queue_id")).Should(Equal("q1"))
+		s.mu.Lock()
+		events := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+		if len(events) == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": "success",
+			"events": events,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"result": "error", "msg": "not found"}`)
+	}
+}
+
+func (s *server) streamMessage(stream, topic, content string) {
+	s.event(map[string]interface{}{
+		"sender_id":         7,
+		"sender_email":      "alice@example.org",
+		"type":              "stream",
+		"display_recipient": stream,
+		"subject":           topic,
+		"content":           content,
+	})
+}
+
+func (s *server) privateMessage(content string) {
+	s.event(map[string]interface{}{
+		"sender_id":         7,
+		"sender_email":      "alice@example.org",
+		"type":              "private",
+		"display_recipient": []map[string]interface{}{{"email": "alice@example.org"}},
+		"content":           content,
+	})
+}
+
+func (s *server) event(m map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, map[string]interface{}{
+		"id":      s.eventID,
+		"type":    "message",
+		"message": m,
+	})
+	s.eventID++
+}
+
+func (s *server) sentMessages() []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]url.Values(nil), s.sent...)
+}
+
+var _ = Describe("Notifier", func() {
+	var s *server
+	var notifier *Notifier
+	var notification *flyontime.Notification
+
+	BeforeEach(func() {
+		s = newServer()
+		notifier = &Notifier{
+			Site:   s.URL,
+			Email:  "flyontime-bot@example.org",
+			APIKey: "k3y",
+			Stream: "ci",
+		}
+		notification = &flyontime.Notification{
+			Severity:      flyontime.SeverityError,
+			Title:         "Job j1 from p1 has failed.",
+			DashboardLink: "http://concourse/builds/1",
+			Job:           flyontime.Job{Name: "j1", Pipeline: "p1", Team: "t1"},
+			JobOutput:     "boom",
+		}
+	})
+
+	AfterEach(func() {
+		s.Close()
+	})
+
+	Describe("Notify", func() {
+		It("should send a message in the topic of the job", func() {
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+			sent := s.sentMessages()
+			Ω(sent).Should(HaveLen(1))
+			Ω(sent[0].Get("type")).Should(Equal("stream"))
+			Ω(sent[0].Get("to")).Should(Equal("ci"))
+			Ω(sent[0].Get("topic")).Should(Equal("p1/j1"))
+			Ω(sent[0].Get("content")).Should(Equal(":cross_mark: **[Job j1 from p1 has failed.](http://concourse/builds/1)**\n```\nboom\n```"))
+		})
+
+		Context("when the notification is not about a job", func() {
+			BeforeEach(func() {
+				notification = &flyontime.Notification{
+					Severity: flyontime.SeverityWarn,
+					Title:    "Cannot reach Concourse.",
+				}
+			})
+
+			It("should send a message in the general topic", func() {
+				Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+
+				sent := s.sentMessages()
+				Ω(sent).Should(HaveLen(1))
+				Ω(sent[0].Get("topic")).Should(Equal("concourse"))
+			})
+		})
+	})
+
+	Describe("Commands", func() {
+		var commands <-chan *flyontime.Command
+
+		BeforeEach(func() {
+			commands = notifier.Commands()
+			Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+		})
+
+		Context("when the bot is mentioned in the topic of a notification", func() {
+			BeforeEach(func() {
+				s.streamMessage("ci", "p1/j1", "@**flyontime** mute 1h")
+			})
+
+			It("should send a command for the notified job", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("mute"))
+				Ω(c.Args).Should(Equal([]string{"1h"}))
+				Ω(c.Job).Should(Equal(&notification.Job))
+			})
+
+			It("should post the responses in the same topic", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				c.Responses <- "Muted."
+				close(c.Responses)
+
+				Eventually(s.sentMessages).Should(HaveLen(2))
+				reply := s.sentMessages()[1]
+				Ω(reply.Get("topic")).Should(Equal("p1/j1"))
+				Ω(reply.Get("content")).Should(Equal("Muted."))
+			})
+		})
+
+		Context("when a message without a mention is received in the topic of a notification", func() {
+			BeforeEach(func() {
+				s.streamMessage("ci", "p1/j1", "looks like a flake")
+			})
+
+			It("should neither send any command nor reply", func() {
+				Consistently(commands).ShouldNot(Receive())
+				Ω(s.sentMessages()).Should(HaveLen(1))
+			})
+		})
+
+		Context("when the job of the notification has a long name", func() {
+			var longTopic string
+
+			BeforeEach(func() {
+				notification.Job.Pipeline = strings.Repeat("p", 40)
+				notification.Job.Name = strings.Repeat("j", 40)
+				Ω(notifier.Notify(context.Background(), notification)).Should(Succeed())
+				longTopic = s.sentMessages()[1].Get("topic")
+				s.streamMessage("ci", longTopic, "@**flyontime** rerun")
+			})
+
+			It("should send the notification in a topic of at most 60 characters", func() {
+				Ω([]rune(longTopic)).Should(HaveLen(60))
+				Ω(longTopic).Should(HaveSuffix("/" + strings.Repeat("j", 40)))
+			})
+
+			It("should send a command for the job when mentioned in that topic", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("rerun"))
+				Ω(c.Job).Should(Equal(&notification.Job))
+			})
+		})
+
+		Context("when the bot is mentioned in another topic", func() {
+			BeforeEach(func() {
+				s.streamMessage("ci", "general", "@**flyontime** pause my-pipeline")
+			})
+
+			It("should send a global command", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pause"))
+				Ω(c.Args).Should(Equal([]string{"my-pipeline"}))
+				Ω(c.Job).Should(BeNil())
+			})
+		})
+
+		Context("when a private message is received", func() {
+			BeforeEach(func() {
+				s.privateMessage("pipelines")
+			})
+
+			It("should reply privately", func() {
+				var c *flyontime.Command
+				Eventually(commands).Should(Receive(&c))
+				Ω(c.Name).Should(Equal("pipelines"))
+				c.Responses <- "p1"
+				close(c.Responses)
+
+				Eventually(s.sentMessages).Should(HaveLen(2))
+				reply := s.sentMessages()[1]
+				Ω(reply.Get("type")).Should(Equal("private"))
+				Ω(reply.Get("to")).Should(Equal("alice@example.org"))
+			})
+		})
+
+		Context("when an unrelated message is received", func() {
+			BeforeEach(func() {
+				s.streamMessage("ci", "general", "hello there")
+			})
+
+			It("should not send any command", func() {
+				Consistently(commands).ShouldNot(Receive())
+			})
+		})
+	})
+})
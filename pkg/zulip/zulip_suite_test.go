@@ -0,0 +1,13 @@
+package zulip_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestZulip(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Zulip Suite")
+}